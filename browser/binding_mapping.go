@@ -0,0 +1,152 @@
+package browser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/grafana/sobek"
+	"github.com/mstoykov/k6-taskqueue-lib/taskqueue"
+
+	"github.com/grafana/xk6-browser/common"
+	"github.com/grafana/xk6-browser/k6ext"
+)
+
+// mapExposeBinding returns the exposeBinding and exposeFunction functions
+// of the JS module. The binding functions of both are JS functions that
+// are called on the event loop. The binding functions of exposeBinding
+// receive the source of the call as their first argument.
+//
+// The binding calls are run in a task queue, which is closed once onClose
+// calls its function, such as when the page of the binding closes.
+func mapExposeBinding(
+	vu moduleVU, expose func(string, common.BindingFunc) error, onClose func(func()), withSource bool,
+) func(string, sobek.Value) (*sobek.Promise, error) {
+	return func(name string, fn sobek.Value) (*sobek.Promise, error) {
+		callable, ok := sobek.AssertFunction(fn)
+		if !ok {
+			return nil, fmt.Errorf("binding %q must be a function", name)
+		}
+
+		// The task queue must be created on the event loop.
+		tq := taskqueue.New(vu.RegisterCallback)
+		go func(ctx context.Context) {
+			<-ctx.Done()
+
+			tq.Close()
+		}(vu.Context())
+		onClose(tq.Close)
+
+		binding := mapBinding(vu, tq, callable, withSource)
+
+		return k6ext.Promise(vu.Context(), func() (any, error) {
+			err := expose(name, binding)
+			if err != nil {
+				tq.Close()
+			}
+			return nil, err
+		}), nil
+	}
+}
+
+// bindingResult is the result of a binding function call.
+type bindingResult struct {
+	value any
+	err   error
+}
+
+// mapBinding returns a binding function that calls the JS function on
+// the event loop through the task queue, and waits for its result. If
+// the JS function returns a promise, the result is the settled value of
+// the promise.
+func mapBinding(vu moduleVU, tq *taskqueue.TaskQueue, fn sobek.Callable, withSource bool) common.BindingFunc {
+	return func(src common.BindingSource, args []any) (any, error) {
+		ctx := vu.Context()
+		done := make(chan bindingResult, 1)
+
+		tq.Queue(func() error {
+			rt := vu.Runtime()
+
+			jsArgs := make([]sobek.Value, 0, len(args)+1)
+			if withSource {
+				jsArgs = append(jsArgs, rt.ToValue(mapping{
+					"context": mapBrowserContext(vu, src.Page.Context()),
+					"frame":   mapFrame(vu, src.Frame),
+					"page":    mapPage(vu, src.Page),
+				}))
+			}
+			for _, arg := range args {
+				jsArgs = append(jsArgs, rt.ToValue(arg))
+			}
+
+			// A binding function that throws rejects the promise in the
+			// page instead of failing the iteration.
+			v, err := fn(sobek.Undefined(), jsArgs...)
+			if err != nil {
+				done <- bindingResult{err: bindingError(err)}
+				return nil
+			}
+			settleBinding(rt, v, done)
+
+			return nil
+		})
+
+		select {
+		case r := <-done:
+			return r.value, r.err
+		case <-ctx.Done():
+			return nil, fmt.Errorf("calling binding: %w", ctx.Err())
+		}
+	}
+}
+
+// settleBinding sends the result of a binding function to done. If the
+// result is a pending promise, it's sent once the promise is settled.
+func settleBinding(rt *sobek.Runtime, v sobek.Value, done chan<- bindingResult) {
+	p, ok := v.Export().(*sobek.Promise)
+	if !ok {
+		done <- bindingResult{value: v.Export()}
+		return
+	}
+
+	switch p.State() {
+	case sobek.PromiseStateFulfilled:
+		done <- bindingResult{value: p.Result().Export()}
+	case sobek.PromiseStateRejected:
+		done <- bindingResult{err: errors.New(valueErrorMessage(p.Result()))}
+	case sobek.PromiseStatePending:
+		obj := v.ToObject(rt)
+		then, _ := sobek.AssertFunction(obj.Get("then"))
+		onFulfilled := func(v sobek.Value) {
+			done <- bindingResult{value: v.Export()}
+		}
+		onRejected := func(v sobek.Value) {
+			done <- bindingResult{err: errors.New(valueErrorMessage(v))}
+		}
+		if _, err := then(obj, rt.ToValue(onFulfilled), rt.ToValue(onRejected)); err != nil {
+			done <- bindingResult{err: bindingError(err)}
+		}
+	}
+}
+
+// bindingError returns the error of a binding function that threw.
+func bindingError(err error) error {
+	var ex *sobek.Exception
+	if errors.As(err, &ex) {
+		return errors.New(valueErrorMessage(ex.Value()))
+	}
+
+	return err
+}
+
+// valueErrorMessage returns the message of a thrown JS value, which is
+// the message property of errors, or the string of other values.
+func valueErrorMessage(v sobek.Value) string {
+	if obj, ok := v.(*sobek.Object); ok {
+		if msg := obj.Get("message"); msg != nil && !sobek.IsUndefined(msg) {
+			return msg.String()
+		}
+	}
+
+	return v.String()
+}
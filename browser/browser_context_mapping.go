@@ -1,12 +1,13 @@
 package browser
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"reflect"
 	"time"
 
 	"github.com/grafana/sobek"
+	"github.com/mstoykov/k6-taskqueue-lib/taskqueue"
 
 	"github.com/grafana/xk6-browser/common"
 	"github.com/grafana/xk6-browser/k6error"
@@ -22,34 +23,17 @@ func mapBrowserContext(vu moduleVU, bc *common.BrowserContext) mapping { //nolin
 				return nil, bc.AddCookies(cookies) //nolint:wrapcheck
 			})
 		},
-		"addInitScript": func(script sobek.Value) *sobek.Promise {
+		"addInitScript": func(script, arg sobek.Value) (*sobek.Promise, error) {
+			source, err := initScriptSource(rt, vu.readFile, script, arg)
+			if err != nil {
+				return nil, err
+			}
 			return k6ext.Promise(vu.Context(), func() (any, error) {
-				if !sobekValueExists(script) {
+				if source == "" {
 					return nil, nil
 				}
-
-				source := ""
-				switch script.ExportType() {
-				case reflect.TypeOf(string("")):
-					source = script.String()
-				case reflect.TypeOf(sobek.Object{}):
-					opts := script.ToObject(rt)
-					for _, k := range opts.Keys() {
-						if k == "content" {
-							source = opts.Get(k).String()
-						}
-					}
-				default:
-					_, isCallable := sobek.AssertFunction(script)
-					if !isCallable {
-						source = fmt.Sprintf("(%s);", script.ToString().String())
-					} else {
-						source = fmt.Sprintf("(%s)(...args);", script.ToString().String())
-					}
-				}
-
 				return nil, bc.AddInitScript(source) //nolint:wrapcheck
-			})
+			}), nil
 		},
 		"browser": func() mapping {
 			// the browser is grabbed from VU.
@@ -75,6 +59,8 @@ func mapBrowserContext(vu moduleVU, bc *common.BrowserContext) mapping { //nolin
 				return bc.Cookies(urls...) //nolint:wrapcheck
 			})
 		},
+		"exposeBinding":  mapExposeBinding(vu, bc.ExposeBinding, bc.OnClose, true),
+		"exposeFunction": mapExposeBinding(vu, bc.ExposeBinding, bc.OnClose, false),
 		"grantPermissions": func(permissions []string, opts sobek.Value) (*sobek.Promise, error) {
 			popts, err := exportTo[common.GrantPermissionsOptions](vu.Runtime(), opts)
 			if err != nil {
@@ -84,6 +70,7 @@ func mapBrowserContext(vu moduleVU, bc *common.BrowserContext) mapping { //nolin
 				return nil, bc.GrantPermissions(permissions, popts)
 			}), nil
 		},
+		"on":                          mapBrowserContextOn(vu, bc),
 		"setDefaultNavigationTimeout": bc.SetDefaultNavigationTimeout,
 		"setDefaultTimeout":           bc.SetDefaultTimeout,
 		"setGeolocation": func(geolocation sobek.Value) (*sobek.Promise, error) {
@@ -109,6 +96,7 @@ func mapBrowserContext(vu moduleVU, bc *common.BrowserContext) mapping { //nolin
 				return nil, bc.SetOffline(offline) //nolint:wrapcheck
 			})
 		},
+		"tracing": mapTracing(vu, bc.Tracing()),
 		"waitForEvent": func(event string, optsOrPredicate sobek.Value) (*sobek.Promise, error) {
 			popts, err := parseWaitForEventOptions(vu.Runtime(), optsOrPredicate, bc.Timeout())
 			if err != nil {
@@ -116,12 +104,21 @@ func mapBrowserContext(vu moduleVU, bc *common.BrowserContext) mapping { //nolin
 			}
 
 			ctx := vu.Context()
+			// The task queue of the predicate must be created on the event
+			// loop. It's closed once waiting for the event is over.
+			var tq *taskqueue.TaskQueue
+			if popts.PredicateFn != nil {
+				tq = taskqueue.New(vu.RegisterCallback)
+				go func() {
+					<-ctx.Done()
+
+					tq.Close()
+				}()
+			}
 			return k6ext.Promise(ctx, func() (result any, reason error) {
 				var runInTaskQueue func(p *common.Page) (bool, error)
 				if popts.PredicateFn != nil {
 					runInTaskQueue = func(p *common.Page) (bool, error) {
-						tq := vu.taskQueueRegistry.get(ctx, p.TargetID())
-
 						var rtn bool
 						var err error
 						// The function on the taskqueue runs in its own goroutine
@@ -147,6 +144,9 @@ func mapBrowserContext(vu moduleVU, bc *common.BrowserContext) mapping { //nolin
 				}
 
 				resp, err := bc.WaitForEvent(event, runInTaskQueue, popts.Timeout)
+				if tq != nil {
+					tq.Close()
+				}
 				panicIfFatalError(ctx, err)
 				if err != nil {
 					return nil, err //nolint:wrapcheck
@@ -175,8 +175,10 @@ func mapBrowserContext(vu moduleVU, bc *common.BrowserContext) mapping { //nolin
 			return rt.ToValue(mpages).ToObject(rt)
 		},
 		"newPage": func() *sobek.Promise {
+			failOnPageError := failIterationOnPageError(vu)
 			return k6ext.Promise(vu.Context(), func() (any, error) {
 				page, err := bc.NewPage()
+				failOnPageError(page)
 				if err != nil {
 					return nil, err //nolint:wrapcheck
 				}
@@ -186,6 +188,62 @@ func mapBrowserContext(vu moduleVU, bc *common.BrowserContext) mapping { //nolin
 	}
 }
 
+// mapBrowserContextOn maps the requested browserContext.on event to the
+// Sobek runtime.
+func mapBrowserContextOn(
+	vu moduleVU, bc *common.BrowserContext,
+) func(common.BrowserContextOnEventName, sobek.Callable) error {
+	rt := vu.Runtime()
+
+	browserContextOnEvents := map[common.BrowserContextOnEventName]func(
+		vu moduleVU, event common.BrowserContextOnEvent,
+	) mapping{
+		common.EventBrowserContextPageCreated: func(vu moduleVU, event common.BrowserContextOnEvent) mapping {
+			return mapPage(vu, event.Page)
+		},
+	}
+
+	return func(eventName common.BrowserContextOnEventName, handleEvent sobek.Callable) error {
+		mapp, ok := browserContextOnEvents[eventName]
+		if !ok {
+			return fmt.Errorf("unknown browser context on event: %q", eventName)
+		}
+
+		// Run the the event handler in a task queue to ensure that the
+		// handler is executed on the event loop. The task queue must be
+		// created on the event loop, and it's closed when the browser
+		// context closes or the iteration ends.
+		tq := taskqueue.New(vu.RegisterCallback)
+		go func(ctx context.Context) {
+			<-ctx.Done()
+
+			tq.Close()
+		}(vu.Context())
+		bc.OnClose(tq.Close)
+
+		eventHandler := func(event common.BrowserContextOnEvent) error {
+			mapping := mapp(vu, event)
+
+			// The task is not queued if the browser context is closed.
+			tq.Queue(func() error {
+				_, err := handleEvent(
+					sobek.Undefined(),
+					rt.ToValue(mapping),
+				)
+				if err != nil {
+					return fmt.Errorf("executing browserContext.on('%s') handler: %w", eventName, err)
+				}
+
+				return nil
+			})
+
+			return nil
+		}
+
+		return bc.On(eventName, eventHandler) //nolint:wrapcheck
+	}
+}
+
 // waitForEventOptions are the options used by the browserContext.waitForEvent API.
 type waitForEventOptions struct {
 	Timeout     time.Duration
@@ -78,9 +78,16 @@ func TestBrowserContextAllOptions(t *testing.T) {
 			extraHTTPHeaders: {
 				'X-Header': 'value',
 			},
+			failOnPageError: true,
 			geolocation: { latitude: 51.509865, longitude: -0.118092, accuracy: 1 },
 			hasTouch: true,
 			httpCredentials: { username: 'admin', password: 'password' },
+			humanize: {
+				mouseSpeed: 800,
+				keystrokeDelay: { distribution: 'lognormal', mean: 150, stdDev: 60 },
+				typoRate: 0,
+				seed: 42,
+			},
 			ignoreHTTPSErrors: true,
 			isMobile: true,
 			javaScriptEnabled: true,
@@ -89,6 +96,7 @@ func TestBrowserContextAllOptions(t *testing.T) {
 			permissions: ['camera', 'microphone'],
 			reducedMotion: 'no-preference',
 			screen: { width: 800, height: 600 },
+			strictSelectors: true,
 			timezoneID: 'Europe/Paris',
 			userAgent: 'my agent',
 			viewport: { width: 800, height: 600 },
@@ -109,6 +117,7 @@ func TestBrowserContextAllOptions(t *testing.T) {
 		ExtraHTTPHeaders: map[string]string{
 			"X-Header": "value",
 		},
+		FailOnPageError: true,
 		Geolocation: &common.Geolocation{
 			Latitude:  51.509865,
 			Longitude: -0.118092,
@@ -119,6 +128,16 @@ func TestBrowserContextAllOptions(t *testing.T) {
 			Username: "admin",
 			Password: "password",
 		},
+		Humanize: &common.Humanize{
+			MouseSpeed: 800,
+			KeystrokeDelay: common.DelayDistribution{
+				Distribution: common.DelayDistributionLogNormal,
+				Mean:         150,
+				StdDev:       60,
+			},
+			TypoRate: new(float64),
+			Seed:     42,
+		},
 		IgnoreHTTPSErrors: true,
 		IsMobile:          true,
 		JavaScriptEnabled: true,
@@ -130,8 +149,9 @@ func TestBrowserContextAllOptions(t *testing.T) {
 			Width:  800,
 			Height: 600,
 		},
-		TimezoneID: "Europe/Paris",
-		UserAgent:  "my agent",
+		StrictSelectors: true,
+		TimezoneID:      "Europe/Paris",
+		UserAgent:       "my agent",
 		Viewport: common.Viewport{
 			Width:  800,
 			Height: 600,
@@ -56,6 +56,7 @@ func mapBrowser(vu moduleVU) mapping { //nolint:funlen,gocognit
 				return mapBrowserContext(vu, bctx), nil
 			}), nil
 		},
+		"selectors": mapSelectors(vu, vu.selectors),
 		"userAgent": func() (string, error) {
 			b, err := vu.browser()
 			if err != nil {
@@ -75,12 +76,15 @@ func mapBrowser(vu moduleVU) mapping { //nolint:funlen,gocognit
 			if err != nil {
 				return nil, fmt.Errorf("parsing browser.newPage options: %w", err)
 			}
+			failOnPageError := failIterationOnPageError(vu)
 			return k6ext.Promise(vu.Context(), func() (any, error) {
 				b, err := vu.browser()
 				if err != nil {
+					failOnPageError(nil)
 					return nil, err
 				}
 				page, err := b.NewPage(popts)
+				failOnPageError(page)
 				if err != nil {
 					return nil, err //nolint:wrapcheck
 				}
@@ -0,0 +1,44 @@
+package browser
+
+import (
+	"github.com/grafana/sobek"
+
+	"github.com/grafana/xk6-browser/common"
+	"github.com/grafana/xk6-browser/k6ext"
+)
+
+// mapDownload to the JS module.
+func mapDownload(vu moduleVU, d *common.Download) mapping {
+	return mapping{
+		"cancel": func() *sobek.Promise {
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return nil, d.Cancel() //nolint:wrapcheck
+			})
+		},
+		"failure": func() *sobek.Promise {
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				failure, err := d.Failure()
+				if err != nil || failure == "" {
+					return nil, err //nolint:wrapcheck
+				}
+				return failure, nil
+			})
+		},
+		"page": func() mapping {
+			return mapPage(vu, d.Page())
+		},
+		"path": func() *sobek.Promise {
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return d.Path() //nolint:wrapcheck
+			})
+		},
+		"saveAs": func(path string) *sobek.Promise {
+			fp := vu.iterationPersister()
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return nil, d.SaveAs(path, fp) //nolint:wrapcheck
+			})
+		},
+		"suggestedFilename": d.SuggestedFilename,
+		"url":               d.URL,
+	}
+}
@@ -144,9 +144,15 @@ func mapElementHandle(vu moduleVU, eh *common.ElementHandle) mapping { //nolint:
 			if err := popts.Parse(vu.Context(), opts); err != nil {
 				return nil, fmt.Errorf("parsing element handle screenshot options: %w", err)
 			}
+			mask, err := exportScreenshotMask(vu.Runtime(), opts)
+			if err != nil {
+				return nil, fmt.Errorf("parsing element handle screenshot options: %w", err)
+			}
+			popts.Mask = mask
 
+			fp := vu.iterationPersister()
 			return k6ext.Promise(vu.Context(), func() (any, error) {
-				bb, err := eh.Screenshot(popts, vu.filePersister)
+				bb, err := eh.Screenshot(popts, fp)
 				if err != nil {
 					return nil, err //nolint:wrapcheck
 				}
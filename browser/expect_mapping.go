@@ -0,0 +1,236 @@
+package browser
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/grafana/sobek"
+
+	"github.com/grafana/xk6-browser/common"
+	"github.com/grafana/xk6-browser/env"
+	"github.com/grafana/xk6-browser/k6ext"
+
+	k6common "go.k6.io/k6/js/common"
+)
+
+// mapExpect maps the expect function to the JS module.
+// expect(target, messageOrOptions) returns the assertions about the
+// target, which is a locator, a page or a response. expect.soft returns
+// assertions that don't fail the iteration.
+func mapExpect(vu moduleVU) *sobek.Object {
+	rt := vu.Runtime()
+
+	expect := func(soft bool) func(sobek.Value, sobek.Value) (mapping, error) {
+		return func(target, opts sobek.Value) (mapping, error) {
+			eopts := common.NewExpectOptions()
+			if err := eopts.Parse(vu.Context(), opts); err != nil {
+				return nil, fmt.Errorf("parsing expect options: %w", err)
+			}
+			eopts.Soft = eopts.Soft || soft
+
+			return mapExpectTarget(vu, target, eopts)
+		}
+	}
+
+	obj := rt.ToValue(expect(false)).ToObject(rt)
+	if err := obj.Set("soft", expect(true)); err != nil {
+		k6common.Throw(rt, fmt.Errorf("mapping expect: %w", err))
+	}
+
+	return obj
+}
+
+// mapExpectTarget maps the assertions about the target, and the
+// negated assertions about it as the not property.
+func mapExpectTarget(vu moduleVU, target sobek.Value, opts *common.ExpectOptions) (mapping, error) {
+	m, ok := exportArg(target).(mapping)
+	if !ok {
+		return nil, errors.New("expect: expected a locator, a page or a response")
+	}
+
+	var mapp func(*common.ExpectOptions) mapping
+	switch {
+	case m[locatorMappingRef] != nil:
+		a := common.NewLocatorAssertions(vu.Context(), m[locatorMappingRef].(locatorRef).l) //nolint:forcetypeassert
+		mapp = func(o *common.ExpectOptions) mapping { return mapLocatorAssertions(vu, a, o) }
+	case m[pageMappingRef] != nil:
+		a := common.NewPageAssertions(vu.Context(), m[pageMappingRef].(pageRef).p) //nolint:forcetypeassert
+		mapp = func(o *common.ExpectOptions) mapping { return mapPageAssertions(vu, a, o) }
+	case m[responseMappingRef] != nil:
+		a := common.NewResponseAssertions(vu.Context(), m[responseMappingRef].(responseRef).r) //nolint:forcetypeassert
+		mapp = func(o *common.ExpectOptions) mapping { return mapResponseAssertions(vu, a, o) }
+	default:
+		return nil, errors.New("expect: expected a locator, a page or a response")
+	}
+
+	not := *opts
+	not.Not = true
+	maps := mapp(opts)
+	maps["not"] = mapp(&not)
+
+	return maps, nil
+}
+
+// mapLocatorAssertions to the JS module.
+func mapLocatorAssertions(vu moduleVU, a *common.LocatorAssertions, eopts *common.ExpectOptions) mapping {
+	return mapping{
+		"toBeHidden": func(opts sobek.Value) *sobek.Promise {
+			mopts := eopts.WithMatcherOptions(vu.Context(), opts)
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return nil, a.ToBeHidden(mopts) //nolint:wrapcheck
+			})
+		},
+		"toBeVisible": func(opts sobek.Value) *sobek.Promise {
+			mopts := eopts.WithMatcherOptions(vu.Context(), opts)
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return nil, a.ToBeVisible(mopts) //nolint:wrapcheck
+			})
+		},
+		"toHaveAttribute": func(name string, value, opts sobek.Value) (*sobek.Promise, error) {
+			// the value is optional: toHaveAttribute(name, opts).
+			if !sobekValueExists(opts) && isOptionsObject(value) {
+				value, opts = nil, value
+			}
+			var (
+				m   *common.StringMatcher
+				err error
+			)
+			if sobekValueExists(value) {
+				if m, err = common.ParseStringMatcher(value); err != nil {
+					return nil, fmt.Errorf("parsing toHaveAttribute value: %w", err)
+				}
+			}
+			mopts := eopts.WithMatcherOptions(vu.Context(), opts)
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return nil, a.ToHaveAttribute(name, m, mopts) //nolint:wrapcheck
+			}), nil
+		},
+		"toHaveCount": func(count int64, opts sobek.Value) *sobek.Promise {
+			mopts := eopts.WithMatcherOptions(vu.Context(), opts)
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return nil, a.ToHaveCount(int(count), mopts) //nolint:wrapcheck
+			})
+		},
+		"toHaveScreenshot": mapToHaveScreenshot(vu, eopts, a.ToHaveScreenshot),
+		"toHaveText": func(expected, opts sobek.Value) (*sobek.Promise, error) {
+			m, err := common.ParseStringMatcher(expected)
+			if err != nil {
+				return nil, fmt.Errorf("parsing toHaveText expected text: %w", err)
+			}
+			mopts := eopts.WithMatcherOptions(vu.Context(), opts)
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return nil, a.ToHaveText(m, mopts) //nolint:wrapcheck
+			}), nil
+		},
+		"toHaveValue": func(expected, opts sobek.Value) (*sobek.Promise, error) {
+			m, err := common.ParseStringMatcher(expected)
+			if err != nil {
+				return nil, fmt.Errorf("parsing toHaveValue expected value: %w", err)
+			}
+			mopts := eopts.WithMatcherOptions(vu.Context(), opts)
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return nil, a.ToHaveValue(m, mopts) //nolint:wrapcheck
+			}), nil
+		},
+	}
+}
+
+// mapPageAssertions to the JS module.
+func mapPageAssertions(vu moduleVU, a *common.PageAssertions, eopts *common.ExpectOptions) mapping {
+	return mapping{
+		"toHaveScreenshot": mapToHaveScreenshot(vu, eopts, a.ToHaveScreenshot),
+		"toHaveTitle": func(expected, opts sobek.Value) (*sobek.Promise, error) {
+			m, err := common.ParseStringMatcher(expected)
+			if err != nil {
+				return nil, fmt.Errorf("parsing toHaveTitle expected title: %w", err)
+			}
+			mopts := eopts.WithMatcherOptions(vu.Context(), opts)
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return nil, a.ToHaveTitle(m, mopts) //nolint:wrapcheck
+			}), nil
+		},
+		"toHaveURL": func(expected, opts sobek.Value) (*sobek.Promise, error) {
+			m, err := common.ParseStringMatcher(expected)
+			if err != nil {
+				return nil, fmt.Errorf("parsing toHaveURL expected URL: %w", err)
+			}
+			mopts := eopts.WithMatcherOptions(vu.Context(), opts)
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return nil, a.ToHaveURL(m, mopts) //nolint:wrapcheck
+			}), nil
+		},
+	}
+}
+
+// mapResponseAssertions to the JS module.
+func mapResponseAssertions(vu moduleVU, a *common.ResponseAssertions, eopts *common.ExpectOptions) mapping {
+	return mapping{
+		"toBeOK": func() *sobek.Promise {
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return nil, a.ToBeOK(eopts) //nolint:wrapcheck
+			})
+		},
+	}
+}
+
+// mapToHaveScreenshot maps the toHaveScreenshot assertion
+// of pages and locators to the JS module.
+func mapToHaveScreenshot(
+	vu moduleVU,
+	eopts *common.ExpectOptions,
+	toHaveScreenshot func(string, *common.ScreenshotAssertionOptions, common.ScreenshotPersister, *common.ExpectOptions) error,
+) func(string, sobek.Value) (*sobek.Promise, error) {
+	return func(name string, opts sobek.Value) (*sobek.Promise, error) {
+		sopts := common.NewScreenshotAssertionOptions()
+		if err := sopts.Parse(vu.Context(), opts); err != nil {
+			return nil, fmt.Errorf("parsing toHaveScreenshot options: %w", err)
+		}
+		mask, err := exportScreenshotMask(vu.Runtime(), opts)
+		if err != nil {
+			return nil, fmt.Errorf("parsing toHaveScreenshot options: %w", err)
+		}
+		sopts.Mask = mask
+		sopts.Dir = vu.snapshots.dir
+		sopts.Update = vu.snapshots.update
+		mopts := eopts.WithMatcherOptions(vu.Context(), opts)
+		fp := vu.iterationPersister()
+		return k6ext.Promise(vu.Context(), func() (any, error) {
+			return nil, toHaveScreenshot(name, sopts, fp, mopts) //nolint:wrapcheck
+		}), nil
+	}
+}
+
+// snapshotsConfig is the configuration of the baseline
+// screenshots of the toHaveScreenshot assertions.
+type snapshotsConfig struct {
+	// dir is the directory of the baselines.
+	dir string
+	// update writes the screenshots as the new baselines.
+	update bool
+}
+
+// parseSnapshotsConfig parses the snapshots config from the
+// K6_BROWSER_SNAPSHOTS_DIR and K6_BROWSER_UPDATE_SNAPSHOTS env vars.
+func parseSnapshotsConfig(envLookup env.LookupFunc) (snapshotsConfig, error) {
+	c := snapshotsConfig{dir: common.DefaultSnapshotsDir}
+	if v, ok := envLookup(env.SnapshotsDir); ok && v != "" {
+		c.dir = v
+	}
+	if v, ok := envLookup(env.UpdateSnapshots); ok && v != "" {
+		update, err := strconv.ParseBool(v)
+		if err != nil {
+			return c, fmt.Errorf("%s should be a boolean: %w", env.UpdateSnapshots, err)
+		}
+		c.update = update
+	}
+
+	return c, nil
+}
+
+// isOptionsObject returns true if v is a plain object, and not a
+// string or a regular expression.
+func isOptionsObject(v sobek.Value) bool {
+	obj, ok := v.(*sobek.Object)
+	return ok && obj.ClassName() == "Object"
+}
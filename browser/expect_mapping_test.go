@@ -0,0 +1,66 @@
+package browser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grafana/xk6-browser/common"
+)
+
+func TestParseSnapshotsConfig(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		env        map[string]string
+		expConfig  snapshotsConfig
+		expErrMssg string
+	}{
+		{
+			name:      "default",
+			expConfig: snapshotsConfig{dir: common.DefaultSnapshotsDir},
+		},
+		{
+			name: "dir and update",
+			env: map[string]string{
+				"K6_BROWSER_SNAPSHOTS_DIR":    "baselines",
+				"K6_BROWSER_UPDATE_SNAPSHOTS": "true",
+			},
+			expConfig: snapshotsConfig{dir: "baselines", update: true},
+		},
+		{
+			name: "empty values",
+			env: map[string]string{
+				"K6_BROWSER_SNAPSHOTS_DIR":    "",
+				"K6_BROWSER_UPDATE_SNAPSHOTS": "",
+			},
+			expConfig: snapshotsConfig{dir: common.DefaultSnapshotsDir},
+		},
+		{
+			name: "invalid update",
+			env: map[string]string{
+				"K6_BROWSER_UPDATE_SNAPSHOTS": "yes please",
+			},
+			expErrMssg: "K6_BROWSER_UPDATE_SNAPSHOTS should be a boolean",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			lookup := func(key string) (string, bool) {
+				v, ok := tc.env[key]
+				return v, ok
+			}
+			c, err := parseSnapshotsConfig(lookup)
+			if tc.expErrMssg != "" {
+				assert.ErrorContains(t, err, tc.expErrMssg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expConfig, c)
+		})
+	}
+}
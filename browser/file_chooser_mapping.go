@@ -0,0 +1,36 @@
+package browser
+
+import (
+	"fmt"
+
+	"github.com/grafana/sobek"
+
+	"github.com/grafana/xk6-browser/common"
+	"github.com/grafana/xk6-browser/k6ext"
+)
+
+// mapFileChooser to the JS module.
+func mapFileChooser(vu moduleVU, fc *common.FileChooser) mapping {
+	return mapping{
+		"element": func() mapping {
+			return mapElementHandle(vu, fc.Element())
+		},
+		"isMultiple": fc.IsMultiple,
+		"page": func() mapping {
+			return mapPage(vu, fc.Page())
+		},
+		"setFiles": func(files sobek.Value, opts sobek.Value) (*sobek.Promise, error) {
+			popts := common.NewElementHandleSetInputFilesOptions(fc.Element().Timeout())
+			if err := popts.Parse(vu.Context(), opts); err != nil {
+				return nil, fmt.Errorf("parsing setFiles options: %w", err)
+			}
+			pfiles := &common.Files{}
+			if err := pfiles.Parse(vu.Context(), files); err != nil {
+				return nil, fmt.Errorf("parsing setFiles parameter: %w", err)
+			}
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return nil, fc.SetFiles(pfiles, popts) //nolint:wrapcheck
+			}), nil
+		},
+	}
+}
@@ -1,13 +1,21 @@
 package browser
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/url"
 	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
 
 	"github.com/grafana/xk6-browser/env"
 	"github.com/grafana/xk6-browser/storage"
+
+	k6event "go.k6.io/k6/event"
+	k6modules "go.k6.io/k6/js/modules"
 )
 
 type presignedURLConfig struct {
@@ -98,3 +106,94 @@ func parsePresignedURLEnvVar(envVarValue string) (presignedURLConfig, error) {
 
 	return presignedURL, nil
 }
+
+// artifactPersister persists the files of a VU, such as screenshots, at
+// the paths expanded from the path template, and lists them in the
+// artifact manifest if there is one.
+type artifactPersister struct {
+	filePersister
+
+	template string
+	manifest *storage.ArtifactManifest
+	// tags are the tags of the iteration that the files are persisted
+	// from. They are captured when the persister is bound to the
+	// iteration, since the VU state can't be read off the event loop.
+	tags storage.ArtifactTags
+}
+
+// Persist persists the file at the path expanded from the template.
+func (p *artifactPersister) Persist(ctx context.Context, path string, data io.Reader) error {
+	tags := p.tags
+	if tags.Timestamp.IsZero() {
+		tags.Timestamp = time.Now()
+	}
+	ep := storage.ExpandPathTemplate(p.template, path, tags)
+
+	if err := p.filePersister.Persist(ctx, ep, data); err != nil {
+		return err //nolint:wrapcheck
+	}
+	if p.manifest == nil {
+		return nil
+	}
+
+	return p.manifest.Add(ctx, ep, path, tags) //nolint:wrapcheck
+}
+
+// ExpandPath returns the path that a file requested to be
+// persisted at path is persisted at.
+func (p *artifactPersister) ExpandPath(path string) string {
+	return storage.ExpandPathTemplate(p.template, path, p.tags)
+}
+
+// withIterationTags returns a copy of the artifact persister that
+// persists the files with the tags of the iteration, and the current
+// time as their timestamp. Other persisters are returned as is.
+func withIterationTags(fp filePersister, scenario string, vuID uint64, iteration int64) filePersister {
+	ap, ok := fp.(*artifactPersister)
+	if !ok {
+		return fp
+	}
+	ip := *ap
+	ip.tags.Scenario = scenario
+	ip.tags.VU = vuID
+	ip.tags.Iteration = iteration
+	ip.tags.Timestamp = time.Now()
+
+	return &ip
+}
+
+// newArtifactManifest returns the artifact manifest if the
+// K6_BROWSER_ARTIFACTS_MANIFEST env var is set, or nil.
+func newArtifactManifest(envLookup env.LookupFunc, testRunID string, fp filePersister) *storage.ArtifactManifest {
+	path, ok := envLookup(env.ArtifactsManifest)
+	if !ok || path == "" {
+		return nil
+	}
+	path = storage.ExpandPathTemplate("", path, storage.ArtifactTags{
+		RunID:     testRunID,
+		Timestamp: time.Now(),
+	})
+
+	return storage.NewArtifactManifest(path, testRunID, fp)
+}
+
+// writeArtifactManifestOnExit writes the artifact manifest when the
+// test run exits. The files that are persisted after that, such as
+// the videos of the pages that are closed at exit, update it.
+func writeArtifactManifestOnExit(
+	vu k6modules.VU, manifest *storage.ArtifactManifest, logger logrus.FieldLogger,
+) {
+	subID, exitCh := vu.Events().Global.Subscribe(k6event.Exit)
+	go func() {
+		defer vu.Events().Global.Unsubscribe(subID)
+
+		e, ok := <-exitCh
+		if !ok {
+			return
+		}
+		defer e.Done()
+		if err := manifest.Write(context.Background()); err != nil {
+			logger.Errorf("writing artifact manifest: %v", err)
+		}
+	}()
+}
@@ -1,11 +1,17 @@
 package browser
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/grafana/xk6-browser/env"
+	"github.com/grafana/xk6-browser/k6ext/k6test"
 	"github.com/grafana/xk6-browser/storage"
 )
 
@@ -172,3 +178,47 @@ func Test_parsePresignedURLEnvVar(t *testing.T) {
 		})
 	}
 }
+
+func TestArtifactPersister(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	vu := k6test.NewVU(t)
+	vu.ActivateVU()
+	vu.StateField.VUID = 3
+	vu.StateField.Iteration = 7
+
+	manifest := filepath.Join(dir, "manifest.json")
+	mvu := moduleVU{
+		VU: vu,
+		filePersister: &artifactPersister{
+			filePersister: &storage.LocalFilePersister{},
+			template:      filepath.Join(dir, "{scenario}", "{vu}-{iter}-{name}.{ext}"),
+			manifest:      storage.NewArtifactManifest(manifest, "", &storage.LocalFilePersister{}),
+		},
+	}
+	// The tags are captured when the persister is bound to the
+	// iteration, not when the file is persisted.
+	fp := mvu.iterationPersister()
+	vu.StateField.Iteration = 8
+
+	want := filepath.Join(dir, "default", "3-7-screenshot.png")
+	ap, ok := fp.(*artifactPersister)
+	require.True(t, ok)
+	assert.Equal(t, want, ap.ExpandPath("screenshot.png"))
+
+	require.NoError(t, fp.Persist(vu.Context(), "screenshot.png", strings.NewReader("a")))
+	assert.FileExists(t, want)
+
+	require.NoError(t, ap.manifest.Write(vu.Context()))
+	b, err := os.ReadFile(manifest) //nolint:forbidigo
+	require.NoError(t, err)
+	var got struct {
+		Artifacts []storage.Artifact `json:"artifacts"`
+	}
+	require.NoError(t, json.Unmarshal(b, &got))
+	require.Len(t, got.Artifacts, 1)
+	assert.Equal(t, "screenshot.png", got.Artifacts[0].Name)
+	assert.Equal(t, want, got.Artifacts[0].Path)
+	assert.Equal(t, map[string]string{"scenario": "default", "vu": "3", "iter": "7"}, got.Artifacts[0].Tags)
+}
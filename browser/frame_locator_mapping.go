@@ -0,0 +1,19 @@
+package browser
+
+import (
+	"github.com/grafana/sobek"
+
+	"github.com/grafana/xk6-browser/common"
+)
+
+// mapFrameLocator API to the JS module.
+func mapFrameLocator(vu moduleVU, fl *common.FrameLocator) mapping {
+	return mapping{
+		"frameLocator": func(selector string) mapping {
+			return mapFrameLocator(vu, fl.FrameLocator(selector))
+		},
+		"locator": func(selector string, opts sobek.Value) mapping {
+			return mapLocator(vu, fl.Locator(selector, opts))
+		},
+	}
+}
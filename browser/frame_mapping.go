@@ -59,6 +59,15 @@ func mapFrame(vu moduleVU, f *common.Frame) mapping {
 				return nil, f.DispatchEvent(selector, typ, exportArg(eventInit), popts) //nolint:wrapcheck
 			}), nil
 		},
+		"dragAndDrop": func(source, target string, opts sobek.Value) (*sobek.Promise, error) {
+			popts := common.NewFrameDragAndDropOptions(f.Timeout())
+			if err := popts.Parse(vu.Context(), opts); err != nil {
+				return nil, fmt.Errorf("parsing frame drag and drop options: %w", err)
+			}
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return nil, f.DragAndDrop(source, target, popts) //nolint:wrapcheck
+			}), nil
+		},
 		"evaluate": func(pageFunc sobek.Value, gargs ...sobek.Value) (*sobek.Promise, error) {
 			if sobekEmptyString(pageFunc) {
 				return nil, fmt.Errorf("evaluate requires a page function")
@@ -98,6 +107,9 @@ func mapFrame(vu moduleVU, f *common.Frame) mapping {
 				return mapElementHandle(vu, fe), nil
 			})
 		},
+		"frameLocator": func(selector string) mapping {
+			return mapFrameLocator(vu, f.FrameLocator(selector))
+		},
 		"getAttribute": func(selector, name string, opts sobek.Value) *sobek.Promise {
 			return k6ext.Promise(vu.Context(), func() (any, error) {
 				s, ok, err := f.GetAttribute(selector, name, opts)
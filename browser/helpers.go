@@ -2,13 +2,20 @@ package browser
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/grafana/sobek"
 
+	"github.com/grafana/xk6-browser/common"
 	"github.com/grafana/xk6-browser/k6error"
 	"github.com/grafana/xk6-browser/k6ext"
+
+	k6common "go.k6.io/k6/js/common"
+	"go.k6.io/k6/lib/fsext"
 )
 
 func panicIfFatalError(ctx context.Context, err error) {
@@ -67,3 +74,90 @@ func sobekValueExists(v sobek.Value) bool {
 func sobekEmptyString(v sobek.Value) bool {
 	return !sobekValueExists(v) || strings.TrimSpace(v.String()) == ""
 }
+
+// newFileReader returns a file reader that reads the files from the file
+// system of the init environment the way that the open function does. The
+// paths are relative to the test script, and the files must be opened in
+// the init context, since k6 only allows reading them afterwards.
+func newFileReader(initEnv *k6common.InitEnvironment) common.FileReader {
+	return func(path string) ([]byte, error) {
+		if initEnv == nil || initEnv.FileSystems["file"] == nil || initEnv.CWD == nil {
+			return nil, fmt.Errorf("reading %q: no file system to read it from", path)
+		}
+		data, err := fsext.ReadFile(initEnv.FileSystems["file"], fsext.Abs(initEnv.CWD.Path, path))
+		if errors.Is(err, fsext.ErrPathNeverRequestedBefore) {
+			return nil, fmt.Errorf("reading %q: the file must be opened with open() in the init context", path)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading %q: %w", path, err)
+		}
+
+		return data, nil
+	}
+}
+
+// initScriptSource returns the source of an init script from a string,
+// a function, or an object with a path or a content property. The path
+// is read with readFile. A function is called with the JSON serialized arg.
+func initScriptSource(rt *sobek.Runtime, readFile common.FileReader, script, arg sobek.Value) (string, error) {
+	if !sobekValueExists(script) {
+		return "", nil
+	}
+
+	switch script.ExportType() {
+	case reflect.TypeOf(string("")):
+		return script.String(), nil
+	case reflect.TypeOf(sobek.Object{}), reflect.TypeOf(map[string]any{}):
+		opts := script.ToObject(rt)
+		if path := opts.Get("path"); sobekValueExists(path) {
+			b, err := readFile(path.String())
+			if err != nil {
+				return "", fmt.Errorf("reading init script: %w", err)
+			}
+			return string(b) + "\n//# sourceURL=" + path.String(), nil
+		}
+		if content := opts.Get("content"); sobekValueExists(content) {
+			return content.String(), nil
+		}
+		return "", nil
+	}
+
+	if _, isCallable := sobek.AssertFunction(script); !isCallable {
+		return fmt.Sprintf("(%s);", script.ToString().String()), nil
+	}
+	var jsonArg []byte
+	if sobekValueExists(arg) {
+		var err error
+		if jsonArg, err = json.Marshal(arg.Export()); err != nil {
+			return "", fmt.Errorf("serializing init script argument: %w", err)
+		}
+	}
+
+	return fmt.Sprintf("(%s)(%s);", script.ToString().String(), jsonArg), nil
+}
+
+// exportScreenshotMask returns the locators of
+// the mask option of the screenshot options.
+func exportScreenshotMask(rt *sobek.Runtime, opts sobek.Value) ([]*common.Locator, error) {
+	if !sobekValueExists(opts) {
+		return nil, nil
+	}
+	mask := opts.ToObject(rt).Get("mask")
+	if !sobekValueExists(mask) {
+		return nil, nil
+	}
+	var values []sobek.Value
+	if err := rt.ExportTo(mask, &values); err != nil {
+		return nil, fmt.Errorf("mask must be an array of locators: %w", err)
+	}
+	locators := make([]*common.Locator, 0, len(values))
+	for i, v := range values {
+		l, err := exportLocator(v)
+		if err != nil {
+			return nil, fmt.Errorf("mask[%d]: %w", i, err)
+		}
+		locators = append(locators, l)
+	}
+
+	return locators, nil
+}
@@ -1,10 +1,15 @@
 package browser
 
 import (
+	"net/url"
+	"strconv"
 	"testing"
 
 	"github.com/grafana/sobek"
 	"github.com/stretchr/testify/require"
+
+	k6common "go.k6.io/k6/js/common"
+	"go.k6.io/k6/lib/fsext"
 )
 
 func TestSobekEmptyString(t *testing.T) {
@@ -24,3 +29,76 @@ func TestSobekEmptyString(t *testing.T) {
 		require.Truef(t, v.ToBoolean(), "got: false, want: true for %q", s)
 	}
 }
+
+func TestInitScriptSource(t *testing.T) {
+	t.Parallel()
+
+	fs := fsext.NewMemMapFs()
+	require.NoError(t, fsext.WriteFile(fs, "/scripts/init.js", []byte("window.fromFile = true;"), 0o600))
+	readFile := newFileReader(&k6common.InitEnvironment{
+		FileSystems: map[string]fsext.Fs{"file": fs},
+		CWD:         &url.URL{Scheme: "file", Path: "/scripts/"},
+	})
+	const path = "./init.js"
+
+	tests := []struct {
+		name, script, arg, want string
+	}{
+		{name: "undefined", script: "undefined", want: ""},
+		{name: "string", script: "'window.a = 1;'", want: "window.a = 1;"},
+		{name: "content", script: "({ content: 'window.a = 1;' })", want: "window.a = 1;"},
+		{
+			name:   "path",
+			script: "({ path: " + strconv.Quote(path) + " })",
+			want:   "window.fromFile = true;\n//# sourceURL=" + path,
+		},
+		{name: "function", script: "(() => { window.a = 1; })", want: "(() => { window.a = 1; })();"},
+		{
+			name:   "function_with_arg",
+			script: "((o) => { window.a = o.a; })",
+			arg:    "({ a: 1 })",
+			want:   `((o) => { window.a = o.a; })({"a":1});`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rt := sobek.New()
+			script, err := rt.RunString(tt.script)
+			require.NoError(t, err)
+			var arg sobek.Value
+			if tt.arg != "" {
+				arg, err = rt.RunString(tt.arg)
+				require.NoError(t, err)
+			}
+
+			got, err := initScriptSource(rt, readFile, script, arg)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewFileReader(t *testing.T) {
+	t.Parallel()
+
+	fs := fsext.NewMemMapFs()
+	require.NoError(t, fsext.WriteFile(fs, "/scripts/lib/a.js", []byte("a"), 0o600))
+	readFile := newFileReader(&k6common.InitEnvironment{
+		FileSystems: map[string]fsext.Fs{"file": fs},
+		CWD:         &url.URL{Scheme: "file", Path: "/scripts/"},
+	})
+
+	for _, path := range []string{"lib/a.js", "./lib/a.js", "/scripts/lib/a.js"} {
+		b, err := readFile(path)
+		require.NoError(t, err, path)
+		require.Equal(t, "a", string(b), path)
+	}
+	_, err := readFile("b.js")
+	require.ErrorContains(t, err, `reading "b.js"`)
+
+	_, err = newFileReader(nil)("lib/a.js")
+	require.ErrorContains(t, err, "no file system")
+}
@@ -1,6 +1,7 @@
 package browser
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/grafana/sobek"
@@ -12,6 +13,25 @@ import (
 // mapLocator API to the JS module.
 func mapLocator(vu moduleVU, lo *common.Locator) mapping { //nolint:funlen
 	return mapping{
+		locatorMappingRef: locatorRef{lo},
+		"blur": func(opts sobek.Value) (*sobek.Promise, error) {
+			popts := common.NewFrameBaseOptions(lo.Timeout())
+			if err := popts.Parse(vu.Context(), opts); err != nil {
+				return nil, fmt.Errorf("parsing blur options: %w", err)
+			}
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return nil, lo.Blur(popts) //nolint:wrapcheck
+			}), nil
+		},
+		"boundingBox": func(opts sobek.Value) (*sobek.Promise, error) {
+			popts := common.NewFrameBaseOptions(lo.Timeout())
+			if err := popts.Parse(vu.Context(), opts); err != nil {
+				return nil, fmt.Errorf("parsing bounding box options: %w", err)
+			}
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return lo.BoundingBox(popts) //nolint:wrapcheck
+			}), nil
+		},
 		"clear": func(opts sobek.Value) (*sobek.Promise, error) {
 			copts := common.NewFrameFillOptions(lo.Timeout())
 			if err := copts.Parse(vu.Context(), opts); err != nil {
@@ -31,6 +51,14 @@ func mapLocator(vu moduleVU, lo *common.Locator) mapping { //nolint:funlen
 				return nil, lo.Click(popts) //nolint:wrapcheck
 			}), nil
 		},
+		"contentFrame": func() mapping {
+			return mapFrameLocator(vu, lo.ContentFrame())
+		},
+		"count": func() *sobek.Promise {
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return lo.Count() //nolint:wrapcheck
+			})
+		},
 		"dblclick": func(opts sobek.Value) *sobek.Promise {
 			return k6ext.Promise(vu.Context(), func() (any, error) {
 				return nil, lo.Dblclick(opts) //nolint:wrapcheck
@@ -81,6 +109,39 @@ func mapLocator(vu moduleVU, lo *common.Locator) mapping { //nolint:funlen
 				return lo.IsHidden() //nolint:wrapcheck
 			})
 		},
+		"evaluate": func(pageFunc sobek.Value, gargs ...sobek.Value) (*sobek.Promise, error) {
+			if sobekEmptyString(pageFunc) {
+				return nil, fmt.Errorf("evaluate requires a page function")
+			}
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return lo.Evaluate(pageFunc.String(), exportArgs(gargs)...)
+			}), nil
+		},
+		"evaluateAll": func(pageFunc sobek.Value, gargs ...sobek.Value) (*sobek.Promise, error) {
+			if sobekEmptyString(pageFunc) {
+				return nil, fmt.Errorf("evaluateAll requires a page function")
+			}
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return lo.EvaluateAll(pageFunc.String(), exportArgs(gargs)...)
+			}), nil
+		},
+		"evaluateHandle": func(pageFunc sobek.Value, gargs ...sobek.Value) (*sobek.Promise, error) {
+			if sobekEmptyString(pageFunc) {
+				return nil, fmt.Errorf("evaluateHandle requires a page function")
+			}
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				jsh, err := lo.EvaluateHandle(pageFunc.String(), exportArgs(gargs)...)
+				if err != nil {
+					return nil, err //nolint:wrapcheck
+				}
+				return mapJSHandle(vu, jsh), nil
+			}), nil
+		},
+		"highlight": func() *sobek.Promise {
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return nil, lo.Highlight() //nolint:wrapcheck
+			})
+		},
 		"fill": func(value string, opts sobek.Value) *sobek.Promise {
 			return k6ext.Promise(vu.Context(), func() (any, error) {
 				return nil, lo.Fill(value, opts) //nolint:wrapcheck
@@ -130,11 +191,65 @@ func mapLocator(vu moduleVU, lo *common.Locator) mapping { //nolint:funlen
 				return lo.InputValue(opts) //nolint:wrapcheck
 			})
 		},
+		"screenshot": func(opts sobek.Value) (*sobek.Promise, error) {
+			popts := common.NewElementHandleScreenshotOptions(lo.Timeout())
+			if err := popts.Parse(vu.Context(), opts); err != nil {
+				return nil, fmt.Errorf("parsing locator screenshot options: %w", err)
+			}
+			mask, err := exportScreenshotMask(vu.Runtime(), opts)
+			if err != nil {
+				return nil, fmt.Errorf("parsing locator screenshot options: %w", err)
+			}
+			popts.Mask = mask
+			rt := vu.Runtime()
+			fp := vu.iterationPersister()
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				bb, err := lo.Screenshot(popts, fp)
+				if err != nil {
+					return nil, err //nolint:wrapcheck
+				}
+
+				ab := rt.NewArrayBuffer(bb)
+
+				return &ab, nil
+			}), nil
+		},
+		"scrollIntoViewIfNeeded": func(opts sobek.Value) (*sobek.Promise, error) {
+			popts := common.NewElementHandleBaseOptions(lo.Timeout())
+			if err := popts.Parse(vu.Context(), opts); err != nil {
+				return nil, fmt.Errorf("parsing scrollIntoViewIfNeeded options: %w", err)
+			}
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return nil, lo.ScrollIntoViewIfNeeded(popts) //nolint:wrapcheck
+			}), nil
+		},
 		"selectOption": func(values sobek.Value, opts sobek.Value) *sobek.Promise {
 			return k6ext.Promise(vu.Context(), func() (any, error) {
 				return lo.SelectOption(values, opts) //nolint:wrapcheck
 			})
 		},
+		"selectText": func(opts sobek.Value) (*sobek.Promise, error) {
+			popts := common.NewElementHandleBaseOptions(lo.Timeout())
+			if err := popts.Parse(vu.Context(), opts); err != nil {
+				return nil, fmt.Errorf("parsing selectText options: %w", err)
+			}
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return nil, lo.SelectText(popts) //nolint:wrapcheck
+			}), nil
+		},
+		"setInputFiles": func(files sobek.Value, opts sobek.Value) (*sobek.Promise, error) {
+			popts := common.NewFrameSetInputFilesOptions(lo.Timeout())
+			if err := popts.Parse(vu.Context(), opts); err != nil {
+				return nil, fmt.Errorf("parsing setInputFiles options: %w", err)
+			}
+			pfiles := &common.Files{}
+			if err := pfiles.Parse(vu.Context(), files); err != nil {
+				return nil, fmt.Errorf("parsing setInputFiles parameter: %w", err)
+			}
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return nil, lo.SetInputFiles(pfiles, popts) //nolint:wrapcheck
+			}), nil
+		},
 		"press": func(key string, opts sobek.Value) *sobek.Promise {
 			return k6ext.Promise(vu.Context(), func() (any, error) {
 				return nil, lo.Press(key, opts) //nolint:wrapcheck
@@ -159,6 +274,32 @@ func mapLocator(vu moduleVU, lo *common.Locator) mapping { //nolint:funlen
 				return nil, lo.Tap(copts) //nolint:wrapcheck
 			}), nil
 		},
+		"dragTo": func(target sobek.Value, opts sobek.Value) (*sobek.Promise, error) {
+			tl, err := exportLocator(target)
+			if err != nil {
+				return nil, fmt.Errorf("parsing dragTo target: %w", err)
+			}
+			popts := common.NewFrameDragAndDropOptions(lo.DefaultTimeout())
+			if err := popts.Parse(vu.Context(), opts); err != nil {
+				return nil, fmt.Errorf("parsing dragTo options: %w", err)
+			}
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return nil, lo.DragTo(tl, popts) //nolint:wrapcheck
+			}), nil
+		},
+		"dropFiles": func(files sobek.Value, opts sobek.Value) (*sobek.Promise, error) {
+			popts := common.NewElementHandleBaseOptions(lo.DefaultTimeout())
+			if err := popts.Parse(vu.Context(), opts); err != nil {
+				return nil, fmt.Errorf("parsing dropFiles options: %w", err)
+			}
+			pfiles := &common.Files{}
+			if err := pfiles.Parse(vu.Context(), files); err != nil {
+				return nil, fmt.Errorf("parsing dropFiles parameter: %w", err)
+			}
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return nil, lo.DropFiles(pfiles, popts) //nolint:wrapcheck
+			}), nil
+		},
 		"dispatchEvent": func(typ string, eventInit, opts sobek.Value) (*sobek.Promise, error) {
 			popts := common.NewFrameDispatchEventOptions(lo.DefaultTimeout())
 			if err := popts.Parse(vu.Context(), opts); err != nil {
@@ -175,3 +316,28 @@ func mapLocator(vu moduleVU, lo *common.Locator) mapping { //nolint:funlen
 		},
 	}
 }
+
+// locatorMappingRef is the key of the locator mapping
+// that refers back to the mapped locator.
+const locatorMappingRef = "__locator"
+
+// locatorRef refers to the locator of a locator mapping. It has
+// no exported fields or methods, so it is opaque to scripts.
+type locatorRef struct {
+	l *common.Locator
+}
+
+// exportLocator returns the locator of a locator mapping
+// that a script passes back to us, such as dragTo's target.
+func exportLocator(v sobek.Value) (*common.Locator, error) {
+	m, ok := exportArg(v).(mapping)
+	if !ok {
+		return nil, errors.New("expected a locator")
+	}
+	ref, ok := m[locatorMappingRef].(locatorRef)
+	if !ok || ref.l == nil {
+		return nil, errors.New("expected a locator")
+	}
+
+	return ref.l, nil
+}
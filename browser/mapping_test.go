@@ -30,6 +30,8 @@ func customMappings() map[string]string {
 		"frameAPI.queryAll":         "$$",
 		"elementHandleAPI.query":    "$",
 		"elementHandleAPI.queryAll": "$$",
+		// acronyms
+		"pageAPI.pDF": "pdf",
 		// getters
 		"pageAPI.getKeyboard":    "keyboard",
 		"pageAPI.getMouse":       "mouse",
@@ -116,6 +118,10 @@ func TestMappings(t *testing.T) {
 		}
 		// detect redundant mappings.
 		for m := range mapped {
+			// mapping refs are not methods.
+			if strings.HasPrefix(m, "__") {
+				continue
+			}
 			if !tested[m] {
 				t.Errorf("method %q is redundant", m)
 			}
@@ -187,6 +193,18 @@ func TestMappings(t *testing.T) {
 				return mapLocator(moduleVU{VU: vu}, &common.Locator{})
 			},
 		},
+		"mapFrameLocator": {
+			apiInterface: (*frameLocatorAPI)(nil),
+			mapp: func() mapping {
+				return mapFrameLocator(moduleVU{VU: vu}, &common.FrameLocator{})
+			},
+		},
+		"mapSelectors": {
+			apiInterface: (*selectorsAPI)(nil),
+			mapp: func() mapping {
+				return mapSelectors(moduleVU{VU: vu}, common.NewSelectors())
+			},
+		},
 		"mapConsoleMessage": {
 			apiInterface: (*consoleMessageAPI)(nil),
 			mapp: func() mapping {
@@ -203,6 +221,38 @@ func TestMappings(t *testing.T) {
 				})
 			},
 		},
+		"mapDialog": {
+			apiInterface: (*dialogAPI)(nil),
+			mapp: func() mapping {
+				return mapDialog(moduleVU{VU: vu}, common.PageOnEvent{
+					Dialog: &common.Dialog{},
+				})
+			},
+		},
+		"mapDownload": {
+			apiInterface: (*downloadAPI)(nil),
+			mapp: func() mapping {
+				return mapDownload(moduleVU{VU: vu}, &common.Download{})
+			},
+		},
+		"mapFileChooser": {
+			apiInterface: (*fileChooserAPI)(nil),
+			mapp: func() mapping {
+				return mapFileChooser(moduleVU{VU: vu}, &common.FileChooser{})
+			},
+		},
+		"mapVideo": {
+			apiInterface: (*videoAPI)(nil),
+			mapp: func() mapping {
+				return mapVideo(moduleVU{VU: vu}, &common.Video{})
+			},
+		},
+		"mapTracing": {
+			apiInterface: (*tracingAPI)(nil),
+			mapp: func() mapping {
+				return mapTracing(moduleVU{VU: vu}, &common.Tracing{})
+			},
+		},
 		"mapTouchscreen": {
 			apiInterface: (*touchscreenAPI)(nil),
 			mapp: func() mapping {
@@ -277,6 +327,7 @@ type browserAPI interface {
 	NewContext(opts *common.BrowserContextOptions) (*common.BrowserContext, error)
 	NewPage(opts *common.BrowserContextOptions) (*common.Page, error)
 	On(string) (bool, error)
+	Selectors() *common.Selectors
 	UserAgent() string
 	Version() string
 }
@@ -290,19 +341,26 @@ type browserContextAPI interface { //nolint:interfacebloat
 	ClearPermissions() error
 	Close() error
 	Cookies(urls ...string) ([]*common.Cookie, error)
+	ExposeBinding(name string, fn sobek.Value) error
+	ExposeFunction(name string, fn sobek.Value) error
 	GrantPermissions(permissions []string, opts sobek.Value) error
 	NewPage() (*common.Page, error)
+	On(event common.BrowserContextOnEventName, handler func(common.BrowserContextOnEvent) error) error
 	Pages() []*common.Page
 	SetDefaultNavigationTimeout(timeout int64)
 	SetDefaultTimeout(timeout int64)
 	SetGeolocation(geolocation *common.Geolocation) error
 	SetHTTPCredentials(httpCredentials common.Credentials) error
 	SetOffline(offline bool) error
+	Tracing() *common.Tracing
 	WaitForEvent(event string, optsOrPredicate sobek.Value) (any, error)
 }
 
 // pageAPI is the interface of a single browser tab.
 type pageAPI interface { //nolint:interfacebloat
+	AddInitScript(script sobek.Value, arg sobek.Value) error
+	AddScriptTag(opts sobek.Value) (*common.ElementHandle, error)
+	AddStyleTag(opts sobek.Value) (*common.ElementHandle, error)
 	BringToFront() error
 	Check(selector string, opts sobek.Value) error
 	Click(selector string, opts sobek.Value) error
@@ -311,12 +369,16 @@ type pageAPI interface { //nolint:interfacebloat
 	Context() *common.BrowserContext
 	Dblclick(selector string, opts sobek.Value) error
 	DispatchEvent(selector string, typ string, eventInit sobek.Value, opts sobek.Value)
+	DragAndDrop(source string, target string, opts sobek.Value) error
 	EmulateMedia(opts sobek.Value) error
 	EmulateVisionDeficiency(typ string) error
 	Evaluate(pageFunc sobek.Value, arg ...sobek.Value) (any, error)
 	EvaluateHandle(pageFunc sobek.Value, arg ...sobek.Value) (common.JSHandleAPI, error)
+	ExposeBinding(name string, fn sobek.Value) error
+	ExposeFunction(name string, fn sobek.Value) error
 	Fill(selector string, value string, opts sobek.Value) error
 	Focus(selector string, opts sobek.Value) error
+	FrameLocator(selector string) *common.FrameLocator
 	Frames() []*common.Frame
 	GetAttribute(selector string, name string, opts sobek.Value) (string, bool, error)
 	GetKeyboard() *common.Keyboard
@@ -336,13 +398,18 @@ type pageAPI interface { //nolint:interfacebloat
 	IsVisible(selector string, opts sobek.Value) (bool, error)
 	Locator(selector string, opts sobek.Value) *common.Locator
 	MainFrame() *common.Frame
+	Off(event common.PageOnEventName, handler sobek.Value)
 	On(event common.PageOnEventName, handler func(common.PageOnEvent) error) error
+	Once(event common.PageOnEventName, handler func(common.PageOnEvent) error) error
 	Opener() pageAPI
+	PDF(opts sobek.Value) (sobek.ArrayBuffer, error)
 	Press(selector string, key string, opts sobek.Value) error
 	Query(selector string) (*common.ElementHandle, error)
 	QueryAll(selector string) ([]*common.ElementHandle, error)
 	Reload(opts sobek.Value) *common.Response
+	RemoveAllListeners(event common.PageOnEventName)
 	Screenshot(opts sobek.Value) ([]byte, error)
+	ScrollUntil(until sobek.Value, opts sobek.Value) (int64, error)
 	SelectOption(selector string, values sobek.Value, opts sobek.Value) ([]string, error)
 	SetChecked(selector string, checked bool, opts sobek.Value) error
 	SetContent(html string, opts sobek.Value) error
@@ -359,7 +426,9 @@ type pageAPI interface { //nolint:interfacebloat
 	Type(selector string, text string, opts sobek.Value) error
 	Uncheck(selector string, opts sobek.Value) error
 	URL() (string, error)
+	Video() *common.Video
 	ViewportSize() map[string]float64
+	WaitForEvent(event string, optsOrPredicate sobek.Value) (any, error)
 	WaitForFunction(fn, opts sobek.Value, args ...sobek.Value) (any, error)
 	WaitForLoadState(state string, opts sobek.Value) error
 	WaitForNavigation(opts sobek.Value) (*common.Response, error)
@@ -376,6 +445,46 @@ type consoleMessageAPI interface {
 	Type() string
 }
 
+// dialogAPI is the interface of a JavaScript dialog.
+type dialogAPI interface {
+	Accept(promptText string) error
+	DefaultValue() string
+	Dismiss() error
+	Message() string
+	Type() string
+}
+
+// downloadAPI is the interface of a file download.
+type downloadAPI interface {
+	Cancel() error
+	Failure() (string, error)
+	Page() *common.Page
+	Path() (string, error)
+	SaveAs(path string) error
+	SuggestedFilename() string
+	URL() string
+}
+
+// fileChooserAPI is the interface of a file chooser.
+type fileChooserAPI interface {
+	Element() *common.ElementHandle
+	IsMultiple() bool
+	Page() *common.Page
+	SetFiles(files sobek.Value, opts sobek.Value) error
+}
+
+// videoAPI is the interface of the video of a page.
+type videoAPI interface {
+	Path() string
+	SaveAs(path string) error
+}
+
+// tracingAPI is the interface of the tracing of a browser context.
+type tracingAPI interface {
+	Start(opts sobek.Value) error
+	Stop(opts sobek.Value) error
+}
+
 // metricEventAPI is the interface of a metric event.
 type metricEventAPI interface {
 	Tag(matchesRegex common.K6BrowserCheckRegEx, patterns common.TagMatches) error
@@ -389,6 +498,7 @@ type frameAPI interface { //nolint:interfacebloat
 	Content() (string, error)
 	Dblclick(selector string, opts sobek.Value) error
 	DispatchEvent(selector string, typ string, eventInit sobek.Value, opts sobek.Value) error
+	DragAndDrop(source string, target string, opts sobek.Value) error
 	// EvaluateWithContext for internal use only
 	EvaluateWithContext(ctx context.Context, pageFunc sobek.Value, args ...sobek.Value) (any, error)
 	Evaluate(pageFunc sobek.Value, args ...sobek.Value) (any, error)
@@ -396,6 +506,7 @@ type frameAPI interface { //nolint:interfacebloat
 	Fill(selector string, value string, opts sobek.Value) error
 	Focus(selector string, opts sobek.Value) error
 	FrameElement() (*common.ElementHandle, error)
+	FrameLocator(selector string) *common.FrameLocator
 	GetAttribute(selector string, name string, opts sobek.Value) (string, bool, error)
 	Goto(url string, opts sobek.Value) (*common.Response, error)
 	Hover(selector string, opts sobek.Value) error
@@ -519,7 +630,19 @@ type responseAPI interface { //nolint:interfacebloat
 type locatorAPI interface { //nolint:interfacebloat
 	Clear(opts *common.FrameFillOptions) error
 	Click(opts sobek.Value) error
+	Blur(opts sobek.Value) error
+	BoundingBox(opts sobek.Value) (*common.Rect, error)
+	ContentFrame() *common.FrameLocator
+	Count() (int, error)
 	Dblclick(opts sobek.Value) error
+	Evaluate(pageFunc sobek.Value, args ...sobek.Value) (any, error)
+	EvaluateAll(pageFunc sobek.Value, args ...sobek.Value) (any, error)
+	EvaluateHandle(pageFunc sobek.Value, args ...sobek.Value) (common.JSHandleAPI, error)
+	Highlight() error
+	Screenshot(opts sobek.Value) (sobek.ArrayBuffer, error)
+	ScrollIntoViewIfNeeded(opts sobek.Value) error
+	SelectText(opts sobek.Value) error
+	SetInputFiles(files sobek.Value, opts sobek.Value) error
 	SetChecked(checked bool, opts sobek.Value) error
 	Check(opts sobek.Value) error
 	Uncheck(opts sobek.Value) error
@@ -542,9 +665,22 @@ type locatorAPI interface { //nolint:interfacebloat
 	Hover(opts sobek.Value) error
 	Tap(opts sobek.Value) error
 	DispatchEvent(typ string, eventInit, opts sobek.Value)
+	DragTo(target sobek.Value, opts sobek.Value) error
+	DropFiles(files sobek.Value, opts sobek.Value) error
 	WaitFor(opts sobek.Value) error
 }
 
+// frameLocatorAPI represents a way to find element(s) inside an iframe.
+type frameLocatorAPI interface {
+	FrameLocator(selector string) *common.FrameLocator
+	Locator(selector string, opts sobek.Value) *common.Locator
+}
+
+// selectorsAPI is the interface of the custom selector engine registry.
+type selectorsAPI interface {
+	Register(name string, script sobek.Value, opts sobek.Value) error
+}
+
 // keyboardAPI is the interface of a keyboard input device.
 type keyboardAPI interface {
 	Down(key string) error
@@ -566,6 +702,7 @@ type mouseAPI interface {
 	Down(opts sobek.Value) error
 	Up(opts sobek.Value) error
 	Move(x float64, y float64, opts sobek.Value) error
+	Wheel(deltaX float64, deltaY float64) error
 }
 
 // workerAPI is the interface of a web worker.
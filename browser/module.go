@@ -19,6 +19,7 @@ import (
 	"github.com/grafana/xk6-browser/common"
 	"github.com/grafana/xk6-browser/env"
 	"github.com/grafana/xk6-browser/k6ext"
+	"github.com/grafana/xk6-browser/storage"
 
 	k6modules "go.k6.io/k6/js/modules"
 )
@@ -38,13 +39,17 @@ type (
 		initOnce       *sync.Once
 		tracesMetadata map[string]string
 		filePersister  filePersister
+		pathTemplate   string
+		manifest       *storage.ArtifactManifest
 		testRunID      string
+		snapshots      snapshotsConfig
 		isSync         bool // remove later
 	}
 
 	// JSModule exposes the properties available to the JS script.
 	JSModule struct {
 		Browser         *sobek.Object
+		Expect          *sobek.Object `js:"expect"`
 		Devices         map[string]common.Device
 		NetworkProfiles map[string]common.NetworkProfile `js:"networkProfiles"`
 	}
@@ -98,26 +103,49 @@ func (m *RootModule) NewModuleInstance(vu k6modules.VU) k6modules.Instance {
 		mapper = syncMapBrowserToSobek
 	}
 
-	return &ModuleInstance{
-		mod: &JSModule{
-			Browser: mapper(moduleVU{
-				VU:          vu,
-				pidRegistry: m.PidRegistry,
-				browserRegistry: newBrowserRegistry(
-					context.Background(),
-					vu,
-					m.remoteRegistry,
-					m.PidRegistry,
-					m.tracesMetadata,
-				),
-				taskQueueRegistry: newTaskQueueRegistry(vu),
-				filePersister:     m.filePersister,
-				testRunID:         m.testRunID,
-			}),
-			Devices:         common.GetDevices(),
-			NetworkProfiles: common.GetNetworkProfiles(),
-		},
+	// the files of the VU are persisted at the paths expanded
+	// from the path template with the tags of their iteration.
+	fp := &artifactPersister{
+		filePersister: m.filePersister,
+		template:      m.pathTemplate,
+		manifest:      m.manifest,
+		tags:          storage.ArtifactTags{RunID: m.testRunID},
 	}
+	selectors := common.NewSelectors()
+	// the files are read from the file system of the init environment,
+	// since it's only available while the VU is initialized.
+	readFile := newFileReader(vu.InitEnv())
+	mvu := moduleVU{
+		VU:          vu,
+		pidRegistry: m.PidRegistry,
+		browserRegistry: newBrowserRegistry(
+			context.Background(),
+			vu,
+			m.remoteRegistry,
+			m.PidRegistry,
+			m.tracesMetadata,
+			fp,
+			selectors,
+			readFile,
+		),
+		taskQueueRegistry: newTaskQueueRegistry(vu),
+		filePersister:     fp,
+		testRunID:         m.testRunID,
+		selectors:         selectors,
+		readFile:          readFile,
+		snapshots:         m.snapshots,
+	}
+	mod := &JSModule{
+		Browser:         mapper(mvu),
+		Devices:         common.GetDevices(),
+		NetworkProfiles: common.GetNetworkProfiles(),
+	}
+	// the expect assertions are only available in the async JS API.
+	if !m.isSync {
+		mod.Expect = mapExpect(mvu)
+	}
+
+	return &ModuleInstance{mod: mod}
 }
 
 // Exports returns the exports of the JS module so that it can be used in test
@@ -151,6 +179,14 @@ func (m *RootModule) initialize(vu k6modules.VU) {
 	if e, ok := initEnv.LookupEnv(env.K6TestRunID); ok && e != "" {
 		m.testRunID = e
 	}
+	m.pathTemplate, _ = initEnv.LookupEnv(env.ArtifactsPathTemplate)
+	if m.manifest = newArtifactManifest(initEnv.LookupEnv, m.testRunID, m.filePersister); m.manifest != nil {
+		writeArtifactManifestOnExit(vu, m.manifest, initEnv.Logger)
+	}
+	m.snapshots, err = parseSnapshotsConfig(initEnv.LookupEnv)
+	if err != nil {
+		k6ext.Abort(vu.Context(), "parsing snapshots config: %v", err)
+	}
 }
 
 func startDebugServer() {
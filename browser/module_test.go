@@ -19,6 +19,7 @@ func TestModuleNew(t *testing.T) {
 	require.True(t, ok, "NewModuleInstance should return a ModuleInstance")
 	require.NotNil(t, m.mod, "Module should be set")
 	require.NotNil(t, m.mod.Browser, "Browser should be set")
+	require.NotNil(t, m.mod.Expect, "Expect should be set")
 	require.NotNil(t, m.mod.Devices, "Devices should be set")
 	require.NotNil(t, m.mod.NetworkProfiles, "Profiles should be set")
 }
@@ -24,6 +24,26 @@ type moduleVU struct {
 	filePersister
 
 	testRunID string
+
+	// selectors are the custom selector engines of the VU.
+	selectors *common.Selectors
+
+	// readFile reads the files that the scripts refer to.
+	readFile common.FileReader
+
+	snapshots snapshotsConfig
+}
+
+// iterationPersister returns the file persister with the tags of the
+// current iteration. It must be called on the event loop, since it
+// reads the VU state.
+func (vu moduleVU) iterationPersister() filePersister {
+	state := vu.State()
+	if state == nil {
+		return vu.filePersister
+	}
+
+	return withIterationTags(vu.filePersister, k6ext.GetScenarioName(vu.Context()), state.VUID, state.Iteration)
 }
 
 // browser returns the VU browser instance for the current iteration.
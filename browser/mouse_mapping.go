@@ -34,5 +34,10 @@ func mapMouse(vu moduleVU, m *common.Mouse) mapping {
 				return nil, m.Move(x, y, opts) //nolint:wrapcheck
 			})
 		},
+		"wheel": func(deltaX float64, deltaY float64) *sobek.Promise {
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return nil, m.Wheel(deltaX, deltaY) //nolint:wrapcheck
+			})
+		},
 	}
 }
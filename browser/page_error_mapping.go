@@ -0,0 +1,51 @@
+package browser
+
+import (
+	"context"
+
+	"github.com/mstoykov/k6-taskqueue-lib/taskqueue"
+
+	"github.com/grafana/xk6-browser/common"
+)
+
+// mapPageError to the JS module.
+//
+// The page error is mapped to properties rather than methods,
+// so that it can be used in the same way as a JavaScript error.
+func mapPageError(_ moduleVU, event common.PageOnEvent) mapping {
+	pe := event.PageError
+
+	return mapping{
+		"message": pe.Message,
+		"name":    pe.Name,
+		"stack":   pe.Stack,
+		"url":     pe.URL,
+	}
+}
+
+// failIterationOnPageError returns a function that makes the uncaught page
+// errors of the page fail the iteration, if its browser context is set to
+// fail on page errors. The errors are returned from a task queue, which is
+// closed once the page closes, or if the function is called with a nil page
+// because creating the page failed.
+//
+// It must be called on the event loop before the page is created, so
+// that the task queue is created on the event loop.
+func failIterationOnPageError(vu moduleVU) func(*common.Page) {
+	tq := taskqueue.New(vu.RegisterCallback)
+	go func(ctx context.Context) {
+		<-ctx.Done()
+
+		tq.Close()
+	}(vu.Context())
+
+	return func(p *common.Page) {
+		if p == nil {
+			tq.Close()
+			return
+		}
+		p.FailIterationOnPageError(func(err error) {
+			tq.Queue(func() error { return err })
+		}, tq.Close)
+	}
+}
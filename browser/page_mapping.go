@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/grafana/sobek"
+	"github.com/mstoykov/k6-taskqueue-lib/taskqueue"
 
 	"github.com/grafana/xk6-browser/common"
 	"github.com/grafana/xk6-browser/k6ext"
@@ -18,6 +19,45 @@ import (
 func mapPage(vu moduleVU, p *common.Page) mapping { //nolint:gocognit,cyclop
 	rt := vu.Runtime()
 	maps := mapping{
+		pageMappingRef: pageRef{p},
+		"addInitScript": func(script, arg sobek.Value) (*sobek.Promise, error) {
+			source, err := initScriptSource(rt, vu.readFile, script, arg)
+			if err != nil {
+				return nil, err
+			}
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				if source == "" {
+					return nil, nil
+				}
+				return nil, p.AddInitScript(source) //nolint:wrapcheck
+			}), nil
+		},
+		"addScriptTag": func(opts sobek.Value) (*sobek.Promise, error) {
+			popts := common.NewFrameAddScriptTagOptions()
+			if err := popts.Parse(vu.Context(), opts); err != nil {
+				return nil, err //nolint:wrapcheck
+			}
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				eh, err := p.AddScriptTag(popts)
+				if err != nil {
+					return nil, err //nolint:wrapcheck
+				}
+				return mapElementHandle(vu, eh), nil
+			}), nil
+		},
+		"addStyleTag": func(opts sobek.Value) (*sobek.Promise, error) {
+			popts := common.NewFrameAddStyleTagOptions()
+			if err := popts.Parse(vu.Context(), opts); err != nil {
+				return nil, err //nolint:wrapcheck
+			}
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				eh, err := p.AddStyleTag(popts)
+				if err != nil {
+					return nil, err //nolint:wrapcheck
+				}
+				return mapElementHandle(vu, eh), nil
+			}), nil
+		},
 		"bringToFront": func() *sobek.Promise {
 			return k6ext.Promise(vu.Context(), func() (any, error) {
 				return nil, p.BringToFront() //nolint:wrapcheck
@@ -41,10 +81,8 @@ func mapPage(vu moduleVU, p *common.Page) mapping { //nolint:gocognit,cyclop
 		},
 		"close": func(opts sobek.Value) *sobek.Promise {
 			return k6ext.Promise(vu.Context(), func() (any, error) {
-				// It's safe to close the taskqueue for this targetID (if one
-				// exists).
-				vu.taskQueueRegistry.close(p.TargetID())
-
+				// The taskqueue for this targetID (if one exists) is closed
+				// once the page.on('close') handlers are queued in it.
 				return nil, p.Close(opts) //nolint:wrapcheck
 			})
 		},
@@ -70,6 +108,15 @@ func mapPage(vu moduleVU, p *common.Page) mapping { //nolint:gocognit,cyclop
 				return nil, p.DispatchEvent(selector, typ, exportArg(eventInit), popts) //nolint:wrapcheck
 			}), nil
 		},
+		"dragAndDrop": func(source, target string, opts sobek.Value) (*sobek.Promise, error) {
+			popts := common.NewFrameDragAndDropOptions(p.Timeout())
+			if err := popts.Parse(vu.Context(), opts); err != nil {
+				return nil, fmt.Errorf("parsing page drag and drop options: %w", err)
+			}
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return nil, p.DragAndDrop(source, target, popts) //nolint:wrapcheck
+			}), nil
+		},
 		"emulateMedia": func(opts sobek.Value) *sobek.Promise {
 			return k6ext.Promise(vu.Context(), func() (any, error) {
 				return nil, p.EmulateMedia(opts) //nolint:wrapcheck
@@ -100,6 +147,8 @@ func mapPage(vu moduleVU, p *common.Page) mapping { //nolint:gocognit,cyclop
 				return mapJSHandle(vu, jsh), nil
 			}), nil
 		},
+		"exposeBinding":  mapExposeBinding(vu, p.ExposeBinding, p.OnClose, true),
+		"exposeFunction": mapExposeBinding(vu, p.ExposeBinding, p.OnClose, false),
 		"fill": func(selector string, value string, opts sobek.Value) *sobek.Promise {
 			return k6ext.Promise(vu.Context(), func() (any, error) {
 				return nil, p.Fill(selector, value, opts) //nolint:wrapcheck
@@ -110,6 +159,10 @@ func mapPage(vu moduleVU, p *common.Page) mapping { //nolint:gocognit,cyclop
 				return nil, p.Focus(selector, opts) //nolint:wrapcheck
 			})
 		},
+		"frameLocator": func(selector string) *sobek.Object {
+			mfl := mapFrameLocator(vu, p.FrameLocator(selector))
+			return rt.ToValue(mfl).ToObject(rt)
+		},
 		"frames": func() *sobek.Object {
 			var (
 				mfrs []mapping
@@ -210,12 +263,35 @@ func mapPage(vu moduleVU, p *common.Page) mapping { //nolint:gocognit,cyclop
 			return rt.ToValue(mf).ToObject(rt)
 		},
 		"mouse": mapMouse(vu, p.GetMouse()),
-		"on":    mapPageOn(vu, p),
+		"off": func(eventName common.PageOnEventName, handler sobek.Value) {
+			p.RemoveEventHandler(eventName, handler)
+			closeUnusedTaskQueue(vu, p)
+		},
+		"on":   mapPageOn(vu, p, false),
+		"once": mapPageOn(vu, p, true),
 		"opener": func() *sobek.Promise {
 			return k6ext.Promise(vu.Context(), func() (any, error) {
 				return p.Opener(), nil
 			})
 		},
+		"pdf": func(opts sobek.Value) (*sobek.Promise, error) {
+			popts := common.NewPagePDFOptions()
+			if err := popts.Parse(vu.Context(), opts); err != nil {
+				return nil, fmt.Errorf("parsing page pdf options: %w", err)
+			}
+
+			fp := vu.iterationPersister()
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				bb, err := p.PDF(popts, fp)
+				if err != nil {
+					return nil, err //nolint:wrapcheck
+				}
+
+				ab := rt.NewArrayBuffer(bb)
+
+				return &ab, nil
+			}), nil
+		},
 		"press": func(selector string, key string, opts sobek.Value) *sobek.Promise {
 			return k6ext.Promise(vu.Context(), func() (any, error) {
 				return nil, p.Press(selector, key, opts) //nolint:wrapcheck
@@ -237,14 +313,24 @@ func mapPage(vu moduleVU, p *common.Page) mapping { //nolint:gocognit,cyclop
 				return rt.ToValue(r).ToObject(rt), nil
 			})
 		},
+		"removeAllListeners": func(eventName common.PageOnEventName) {
+			p.RemoveAllEventHandlers(eventName)
+			closeUnusedTaskQueue(vu, p)
+		},
 		"screenshot": func(opts sobek.Value) (*sobek.Promise, error) {
-			popts := common.NewPageScreenshotOptions()
+			popts := common.NewPageScreenshotOptions(p.Timeout())
 			if err := popts.Parse(vu.Context(), opts); err != nil {
 				return nil, fmt.Errorf("parsing page screenshot options: %w", err)
 			}
+			mask, err := exportScreenshotMask(vu.Runtime(), opts)
+			if err != nil {
+				return nil, fmt.Errorf("parsing page screenshot options: %w", err)
+			}
+			popts.Mask = mask
 
+			fp := vu.iterationPersister()
 			return k6ext.Promise(vu.Context(), func() (any, error) {
-				bb, err := p.Screenshot(popts, vu.filePersister)
+				bb, err := p.Screenshot(popts, fp)
 				if err != nil {
 					return nil, err //nolint:wrapcheck
 				}
@@ -254,6 +340,19 @@ func mapPage(vu moduleVU, p *common.Page) mapping { //nolint:gocognit,cyclop
 				return &ab, nil
 			}), nil
 		},
+		"scrollUntil": func(until sobek.Value, opts sobek.Value) (*sobek.Promise, error) {
+			cond, err := parseScrollUntilCondition(p, until)
+			if err != nil {
+				return nil, fmt.Errorf("page scrollUntil: %w", err)
+			}
+			popts := common.NewPageScrollUntilOptions()
+			if err := popts.Parse(vu.Context(), opts); err != nil {
+				return nil, fmt.Errorf("parsing page scrollUntil options: %w", err)
+			}
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return p.ScrollUntil(cond, popts) //nolint:wrapcheck
+			}), nil
+		},
 		"selectOption": func(selector string, values sobek.Value, opts sobek.Value) *sobek.Promise {
 			return k6ext.Promise(vu.Context(), func() (any, error) {
 				return p.SelectOption(selector, values, opts) //nolint:wrapcheck
@@ -333,8 +432,16 @@ func mapPage(vu moduleVU, p *common.Page) mapping { //nolint:gocognit,cyclop
 				return nil, p.Uncheck(selector, opts) //nolint:wrapcheck
 			})
 		},
-		"url":          p.URL,
+		"url": p.URL,
+		"video": func() any {
+			v := p.Video()
+			if v == nil {
+				return nil
+			}
+			return mapVideo(vu, v)
+		},
 		"viewportSize": p.ViewportSize,
+		"waitForEvent": mapPageWaitForEvent(vu, p),
 		"waitForFunction": func(pageFunc, opts sobek.Value, args ...sobek.Value) (*sobek.Promise, error) {
 			js, popts, pargs, err := parseWaitForFunctionArgs(
 				vu.Context(), p.Timeout(), pageFunc, opts, args...,
@@ -425,9 +532,24 @@ func mapPage(vu moduleVU, p *common.Page) mapping { //nolint:gocognit,cyclop
 	return maps
 }
 
+// mapPageOnPage maps the page of a page.on event, such as page.on('load').
+func mapPageOnPage(vu moduleVU, event common.PageOnEvent) mapping {
+	return mapPage(vu, event.Page)
+}
+
+// mapPageOnFrame maps the frame of a page.on event, such as
+// page.on('framenavigated').
+func mapPageOnFrame(vu moduleVU, event common.PageOnEvent) mapping {
+	return mapFrame(vu, event.Frame)
+}
+
 // mapPageOn maps the requested page.on event to the Sobek runtime.
-// It generalizes the handling of page.on events.
-func mapPageOn(vu moduleVU, p *common.Page) func(common.PageOnEventName, sobek.Callable) error {
+// It generalizes the handling of page.on events. If once is true,
+// it maps page.once, which removes the handler after the first event.
+//
+// The handler function is the key of the handler in the page, so that
+// page.off can remove it.
+func mapPageOn(vu moduleVU, p *common.Page, once bool) func(common.PageOnEventName, sobek.Value) error {
 	rt := vu.Runtime()
 
 	pageOnEvents := map[common.PageOnEventName]struct {
@@ -444,13 +566,71 @@ func mapPageOn(vu moduleVU, p *common.Page) func(common.PageOnEventName, sobek.C
 			init: prepK6BrowserRegExChecker(rt),
 			wait: true,
 		},
+		common.EventPageDialogOpened: {
+			mapp: mapDialog,
+			wait: false,
+		},
+		common.EventPageDownloadStarted: {
+			mapp: func(vu moduleVU, event common.PageOnEvent) mapping {
+				return mapDownload(vu, event.Download)
+			},
+			wait: false,
+		},
+		common.EventPageFileChooserOpened: {
+			mapp: func(vu moduleVU, event common.PageOnEvent) mapping {
+				return mapFileChooser(vu, event.FileChooser)
+			},
+			wait: false,
+		},
+		common.EventPageErrorThrown: {
+			mapp: mapPageError,
+			wait: false,
+		},
+		common.EventPagePopupOpened: {
+			mapp: func(vu moduleVU, event common.PageOnEvent) mapping {
+				return mapPage(vu, event.Popup)
+			},
+			wait: false,
+		},
+		common.EventPageClosed: {
+			mapp: mapPageOnPage,
+			wait: false,
+		},
+		common.EventPageCrashed: {
+			mapp: mapPageOnPage,
+			wait: false,
+		},
+		common.EventPageLoaded: {
+			mapp: mapPageOnPage,
+			wait: false,
+		},
+		common.EventPageDOMContentLoaded: {
+			mapp: mapPageOnPage,
+			wait: false,
+		},
+		common.EventPageFrameWasAttached: {
+			mapp: mapPageOnFrame,
+			wait: false,
+		},
+		common.EventPageFrameWasDetached: {
+			mapp: mapPageOnFrame,
+			wait: false,
+		},
+		common.EventPageFrameWasNavigated: {
+			mapp: mapPageOnFrame,
+			wait: false,
+		},
 	}
 
-	return func(eventName common.PageOnEventName, handleEvent sobek.Callable) error {
+	return func(eventName common.PageOnEventName, handler sobek.Value) error {
 		pageOnEvent, ok := pageOnEvents[eventName]
 		if !ok {
 			return fmt.Errorf("unknown page on event: %q", eventName)
 		}
+		handleEvent, ok := sobek.AssertFunction(handler)
+		if !ok {
+			return fmt.Errorf("page.on('%s') handler must be a function", eventName)
+		}
 
 		// Initializes the environment for the event handler if necessary.
 		if pageOnEvent.init != nil {
@@ -463,13 +643,15 @@ func mapPageOn(vu moduleVU, p *common.Page) func(common.PageOnEventName, sobek.C
 
 		// Run the the event handler in the task queue to
 		// ensure that the handler is executed on the event loop.
-		tq := vu.taskQueueRegistry.get(ctx, p.TargetID())
+		// The task queue is retrieved for each event, as it's
+		// released when the page has no handlers left, and
+		// created again on the event loop below if it's needed.
 		eventHandler := func(event common.PageOnEvent) error {
 			mapping := pageOnEvent.mapp(vu, event)
 
 			done := make(chan struct{})
 
-			tq.Queue(func() error {
+			queued := vu.taskQueueRegistry.queue(p.TargetID(), func() error {
 				defer close(done)
 
 				_, err := handleEvent(
@@ -482,6 +664,11 @@ func mapPageOn(vu moduleVU, p *common.Page) func(common.PageOnEventName, sobek.C
 
 				return nil
 			})
+			if !queued {
+				// The page is closed and its handlers already ran,
+				// or the handler was removed in the meantime.
+				return nil
+			}
 
 			if pageOnEvent.wait {
 				select {
@@ -491,10 +678,124 @@ func mapPageOn(vu moduleVU, p *common.Page) func(common.PageOnEventName, sobek.C
 				}
 			}
 
+			if once {
+				// The page has already removed the handler.
+				closeUnusedTaskQueue(vu, p)
+			}
+
 			return nil
 		}
 
-		return p.On(eventName, eventHandler) //nolint:wrapcheck
+		// The task queue is created on the event loop before the handler is
+		// added, so that the first events are not missed, and again after,
+		// in case it was released as unused in between. It's closed once the
+		// page closes, after the page.on('close') handlers are queued in it.
+		vu.taskQueueRegistry.get(ctx, p)
+		if err := p.AddEventHandler(eventName, handler, eventHandler, once); err != nil {
+			closeUnusedTaskQueue(vu, p)
+			return err //nolint:wrapcheck
+		}
+		vu.taskQueueRegistry.get(ctx, p)
+
+		return nil
+	}
+}
+
+// closeUnusedTaskQueue closes the task queue of the page if the page has
+// no page.on handlers left, so that it doesn't keep the event loop busy.
+// The task queue is created again if it's needed later on.
+func closeUnusedTaskQueue(vu moduleVU, p *common.Page) {
+	vu.taskQueueRegistry.releaseUnused(p.TargetID(), func() bool {
+		return p.EventHandlerCount("") == 0
+	})
+}
+
+// mapPageWaitForEvent to the JS module. It maps the event data,
+// such as a download, and passes it to the predicate function.
+func mapPageWaitForEvent(vu moduleVU, p *common.Page) func(string, sobek.Value) (*sobek.Promise, error) {
+	pageWaitForEvents := map[string]func(vu moduleVU, data any) mapping{
+		common.EventPageDownload: func(vu moduleVU, data any) mapping {
+			return mapDownload(vu, data.(*common.Download)) //nolint:forcetypeassert
+		},
+		common.EventPageFilechooser: func(vu moduleVU, data any) mapping {
+			return mapFileChooser(vu, data.(*common.FileChooser)) //nolint:forcetypeassert
+		},
+		common.EventPagePopup: func(vu moduleVU, data any) mapping {
+			return mapPage(vu, data.(*common.Page)) //nolint:forcetypeassert
+		},
+	}
+
+	return func(event string, optsOrPredicate sobek.Value) (*sobek.Promise, error) {
+		mapp, ok := pageWaitForEvents[event]
+		if !ok {
+			return nil, fmt.Errorf("unknown page waitForEvent event: %q", event)
+		}
+		popts, err := parseWaitForEventOptions(vu.Runtime(), optsOrPredicate, p.Timeout())
+		if err != nil {
+			return nil, fmt.Errorf("parsing wait for event options: %w", err)
+		}
+
+		ctx := vu.Context()
+		var (
+			runInTaskQueue func(data any) (bool, error)
+			tq             *taskqueue.TaskQueue
+		)
+		if popts.PredicateFn != nil {
+			// The task queue must be created on the event loop. It's
+			// closed once waiting for the event is over.
+			tq = taskqueue.New(vu.RegisterCallback)
+			go func() {
+				<-ctx.Done()
+
+				tq.Close()
+			}()
+			runInTaskQueue = func(data any) (bool, error) {
+				var (
+					rtn bool
+					err error
+				)
+				// The predicate runs on the event loop, so we need
+				// to wait for it to complete before returning.
+				c := make(chan struct{})
+				tq.Queue(func() error {
+					defer close(c)
+					var resp sobek.Value
+					resp, err = popts.PredicateFn(vu.Runtime().ToValue(mapp(vu, data)))
+					rtn = err == nil && resp.ToBoolean()
+					return nil
+				})
+
+				select {
+				case <-c:
+				case <-ctx.Done():
+					err = errors.New("iteration ended before waitForEvent completed")
+				}
+
+				return rtn, err
+			}
+		}
+
+		// Start waiting right away, so that the event of an action
+		// that is awaited together with this promise is not missed.
+		wait, err := p.ExpectEvent(event, runInTaskQueue, popts.Timeout)
+		if err != nil {
+			if tq != nil {
+				tq.Close()
+			}
+			return nil, err //nolint:wrapcheck
+		}
+
+		return k6ext.Promise(ctx, func() (any, error) {
+			data, err := wait()
+			if tq != nil {
+				tq.Close()
+			}
+			if err != nil {
+				return nil, err //nolint:wrapcheck
+			}
+
+			return mapp(vu, data), nil
+		}), nil
 	}
 }
 
@@ -533,3 +834,41 @@ func parseWaitForFunctionArgs(
 
 	return js, popts, exportArgs(gargs), nil
 }
+
+// parseScrollUntilCondition returns the condition of page.scrollUntil.
+// The condition holds when the given locator is visible, or when the
+// given predicate, a function or an expression, returns a truthy value.
+func parseScrollUntilCondition(p *common.Page, until sobek.Value) (func() (bool, error), error) {
+	if lo, err := exportLocator(until); err == nil {
+		return lo.IsVisible, nil
+	}
+	if sobekEmptyString(until) {
+		return nil, errors.New("scrollUntil requires a locator or a predicate")
+	}
+
+	js := until.ToString().String()
+	if _, isCallable := sobek.AssertFunction(until); isCallable {
+		js = fmt.Sprintf("async () => !!(await (%s)())", js)
+	} else {
+		js = fmt.Sprintf("() => !!(%s)", js)
+	}
+
+	return func() (bool, error) {
+		v, err := p.Evaluate(js)
+		if err != nil {
+			return false, err //nolint:wrapcheck
+		}
+		ok, _ := v.(bool)
+		return ok, nil
+	}, nil
+}
+
+// pageMappingRef is the key of the page mapping
+// that refers back to the mapped page.
+const pageMappingRef = "__page"
+
+// pageRef refers to the page of a page mapping.
+// It is opaque to scripts.
+type pageRef struct {
+	p *common.Page
+}
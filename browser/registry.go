@@ -177,6 +177,15 @@ type browserRegistry struct {
 	trInit         sync.Once
 	tracesMetadata map[string]string
 
+	// filePersister persists the files of the browsers, such as videos.
+	filePersister filePersister
+
+	// selectors are the custom selector engines of the VU.
+	selectors *common.Selectors
+
+	// readFile reads the files that the scripts refer to.
+	readFile common.FileReader
+
 	mu sync.RWMutex
 	m  map[int64]*common.Browser
 
@@ -202,6 +211,9 @@ func newBrowserRegistry(
 	remote *remoteRegistry,
 	pids *pidRegistry,
 	tracesMetadata map[string]string,
+	fp filePersister,
+	selectors *common.Selectors,
+	readFile common.FileReader,
 ) *browserRegistry {
 	bt := chromium.NewBrowserType(vu)
 	builder := func(ctx, vuCtx context.Context) (*common.Browser, error) {
@@ -231,6 +243,9 @@ func newBrowserRegistry(
 	r := &browserRegistry{
 		vu:             vu,
 		tracesMetadata: tracesMetadata,
+		filePersister:  fp,
+		selectors:      selectors,
+		readFile:       readFile,
 		m:              make(map[int64]*common.Browser),
 		buildFn:        builder,
 	}
@@ -304,6 +319,16 @@ func (r *browserRegistry) handleIterEvents(
 			// All browser APIs should work with the vu context, and allow the
 			// k6 iteration control its lifecycle.
 			tracerCtx := common.WithTracer(r.vu.Context(), r.tr.tracer)
+			if r.filePersister != nil {
+				fp := withIterationTags(r.filePersister, data.ScenarioName, data.VUID, data.Iteration)
+				tracerCtx = common.WithFilePersister(tracerCtx, fp)
+			}
+			if r.selectors != nil {
+				tracerCtx = common.WithSelectors(tracerCtx, r.selectors)
+			}
+			if r.readFile != nil {
+				tracerCtx = common.WithFileReader(tracerCtx, r.readFile)
+			}
 			tracedCtx := r.tr.startIterationTrace(tracerCtx, data)
 
 			b, err := r.buildFn(ctx, tracedCtx)
@@ -522,46 +547,126 @@ type taskQueueRegistry struct {
 
 	tqMu sync.Mutex
 	tq   map[string]*taskqueue.TaskQueue
+	// watched are the targets whose taskqueues are closed once they close.
+	watched map[string]struct{}
 }
 
 func newTaskQueueRegistry(vu k6modules.VU) *taskQueueRegistry {
 	return &taskQueueRegistry{
-		vu:   vu,
-		tqMu: sync.Mutex{},
-		tq:   make(map[string]*taskqueue.TaskQueue),
+		vu:      vu,
+		tqMu:    sync.Mutex{},
+		tq:      make(map[string]*taskqueue.TaskQueue),
+		watched: make(map[string]struct{}),
 	}
 }
 
-// get will retrieve the taskqueue associated with the given targetID. If one
-// doesn't exist then a new taskqueue will be created.
+// taskQueueTarget is a target, such as a page, that has a taskqueue.
+type taskQueueTarget interface {
+	TargetID() string
+	IsClosed() bool
+	OnClose(fn func())
+}
+
+// get will retrieve the taskqueue associated with the given target. If one
+// doesn't exist then a new taskqueue will be created, unless the target is
+// closed, in which case it returns nil. The taskqueue is closed once the
+// target closes, after the target's close handlers are queued in it.
+//
+// get must be called on the event loop, since creating a taskqueue
+// registers a callback on the event loop.
 //
 // ctx must be the context from the VU, so that we can automatically close the
 // taskqueue when the iteration ends.
-func (t *taskQueueRegistry) get(ctx context.Context, targetID string) *taskqueue.TaskQueue {
-	t.tqMu.Lock()
-	defer t.tqMu.Unlock()
+func (t *taskQueueRegistry) get(ctx context.Context, target taskQueueTarget) *taskqueue.TaskQueue {
+	targetID := target.TargetID()
 
+	t.tqMu.Lock()
+	if target.IsClosed() {
+		t.tqMu.Unlock()
+		return nil
+	}
 	tq := t.tq[targetID]
 	if tq == nil {
 		tq = taskqueue.New(t.vu.RegisterCallback)
 		t.tq[targetID] = tq
 
 		// We want to ensure that the taskqueue is closed when the context is
-		// closed.
+		// closed, and that the target is forgotten.
 		go func(ctx context.Context) {
 			<-ctx.Done()
 
 			tq.Close()
+			t.forget(targetID, tq)
 		}(ctx)
 	}
+	_, watched := t.watched[targetID]
+	t.watched[targetID] = struct{}{}
+	t.tqMu.Unlock()
+
+	// The close handler is registered once per target, and without
+	// the lock, as it's called right away if the target is closed.
+	if !watched {
+		target.OnClose(func() { t.close(targetID) })
+	}
 
 	return tq
 }
 
+// queue queues the task in the taskqueue of the target. It returns false if
+// the target has no taskqueue, as the target is closed or its taskqueue was
+// released as unused.
+//
+// Retrieving the taskqueue and queuing the task are done at once, so that the
+// taskqueue is not released as unused in between. A taskqueue that is closed
+// after queuing the task still runs it.
+func (t *taskQueueRegistry) queue(targetID string, task func() error) bool {
+	t.tqMu.Lock()
+	defer t.tqMu.Unlock()
+
+	tq := t.tq[targetID]
+	if tq == nil {
+		return false
+	}
+	tq.Queue(task)
+
+	return true
+}
+
+// close closes the taskqueue of the closed target.
 func (t *taskQueueRegistry) close(targetID string) {
 	t.tqMu.Lock()
 	defer t.tqMu.Unlock()
 
+	t.release(targetID)
+	delete(t.watched, targetID)
+}
+
+// releaseUnused closes the taskqueue of the target if it has one and unused
+// returns true. unused is called with the lock held, so that the taskqueue
+// is not created again by get in between. The taskqueue is created again
+// if it's needed later on.
+func (t *taskQueueRegistry) releaseUnused(targetID string, unused func() bool) {
+	t.tqMu.Lock()
+	defer t.tqMu.Unlock()
+
+	if unused() {
+		t.release(targetID)
+	}
+}
+
+// forget removes the target if tq is still its taskqueue,
+// or if the target has no taskqueue left.
+func (t *taskQueueRegistry) forget(targetID string, tq *taskqueue.TaskQueue) {
+	t.tqMu.Lock()
+	defer t.tqMu.Unlock()
+
+	if cur := t.tq[targetID]; cur == tq || cur == nil {
+		delete(t.tq, targetID)
+		delete(t.watched, targetID)
+	}
+}
+
+func (t *taskQueueRegistry) release(targetID string) {
 	tq := t.tq[targetID]
 	if tq != nil {
 		tq.Close()
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -205,7 +206,7 @@ func TestBrowserRegistry(t *testing.T) {
 
 		var (
 			vu              = k6test.NewVU(t)
-			browserRegistry = newBrowserRegistry(context.Background(), vu, remoteRegistry, &pidRegistry{}, nil)
+			browserRegistry = newBrowserRegistry(context.Background(), vu, remoteRegistry, &pidRegistry{}, nil, nil, nil, nil)
 		)
 
 		vu.ActivateVU()
@@ -238,7 +239,7 @@ func TestBrowserRegistry(t *testing.T) {
 
 		var (
 			vu              = k6test.NewVU(t)
-			browserRegistry = newBrowserRegistry(context.Background(), vu, remoteRegistry, &pidRegistry{}, nil)
+			browserRegistry = newBrowserRegistry(context.Background(), vu, remoteRegistry, &pidRegistry{}, nil, nil, nil, nil)
 		)
 
 		vu.ActivateVU()
@@ -268,7 +269,7 @@ func TestBrowserRegistry(t *testing.T) {
 
 		var (
 			vu              = k6test.NewVU(t)
-			browserRegistry = newBrowserRegistry(context.Background(), vu, remoteRegistry, &pidRegistry{}, nil)
+			browserRegistry = newBrowserRegistry(context.Background(), vu, remoteRegistry, &pidRegistry{}, nil, nil, nil, nil)
 		)
 
 		vu.ActivateVU()
@@ -290,7 +291,7 @@ func TestBrowserRegistry(t *testing.T) {
 		vu := k6test.NewVU(t)
 		var cancel context.CancelFunc
 		vu.CtxField, cancel = context.WithCancel(vu.CtxField) //nolint:fatcontext
-		browserRegistry := newBrowserRegistry(context.Background(), vu, remoteRegistry, &pidRegistry{}, nil)
+		browserRegistry := newBrowserRegistry(context.Background(), vu, remoteRegistry, &pidRegistry{}, nil, nil, nil, nil)
 
 		vu.ActivateVU()
 
@@ -319,6 +320,98 @@ func TestBrowserRegistry(t *testing.T) {
 	})
 }
 
+// testTarget is a target of a task queue for testing.
+type testTarget struct {
+	id       string
+	closed   bool
+	closeFns []func()
+}
+
+func (t *testTarget) TargetID() string { return t.id }
+func (t *testTarget) IsClosed() bool   { return t.closed }
+
+func (t *testTarget) OnClose(fn func()) {
+	if t.closed {
+		fn()
+		return
+	}
+	t.closeFns = append(t.closeFns, fn)
+}
+
+func (t *testTarget) close() {
+	t.closed = true
+	for _, fn := range t.closeFns {
+		fn()
+	}
+}
+
+func TestTaskQueueRegistry(t *testing.T) {
+	t.Parallel()
+
+	vu := k6test.NewVU(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r := newTaskQueueRegistry(vu)
+
+	unused := &testTarget{id: "unused"}
+	tq := r.get(ctx, unused)
+	require.NotNil(t, tq)
+	assert.Same(t, tq, r.get(ctx, unused))
+	// The close handler is registered once per target.
+	assert.Len(t, unused.closeFns, 1)
+	// A used task queue is not released.
+	r.releaseUnused("unused", func() bool { return false })
+	assert.Same(t, tq, r.get(ctx, unused))
+	// An unused task queue is created again, but only on get.
+	r.releaseUnused("unused", func() bool { return true })
+	assert.False(t, r.queue("unused", func() error { return nil }))
+	assert.NotSame(t, tq, r.get(ctx, unused))
+	assert.Len(t, unused.closeFns, 1)
+
+	// The task queue of a closed target is not created again,
+	// and the closed target is forgotten.
+	closed := &testTarget{id: "closed"}
+	require.NotNil(t, r.get(ctx, closed))
+	closed.close()
+	assert.Nil(t, r.get(ctx, closed))
+	assert.False(t, r.queue("closed", func() error { return nil }))
+	assert.NotContains(t, r.tq, "closed")
+	assert.NotContains(t, r.watched, "closed")
+
+	// The targets are forgotten when the iteration ends.
+	cancel()
+	assert.Eventually(t, func() bool {
+		r.tqMu.Lock()
+		defer r.tqMu.Unlock()
+		return len(r.tq) == 0 && len(r.watched) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestTaskQueueRegistryQueue(t *testing.T) {
+	t.Parallel()
+
+	vu := k6test.NewVU(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r := newTaskQueueRegistry(vu)
+
+	// A task that is queued before the task queue is closed
+	// as unused still runs on the event loop.
+	var ran bool
+	vu.SetVar(t, "queueAndRelease", func() {
+		require.NotNil(t, r.get(ctx, &testTarget{id: "target"}))
+		require.True(t, r.queue("target", func() error {
+			ran = true
+			return nil
+		}))
+		r.releaseUnused("target", func() bool { return true })
+	})
+
+	_, err := vu.RunOnEventLoop(t, "queueAndRelease()")
+	require.NoError(t, err)
+	assert.True(t, ran)
+}
+
 func TestParseTracesMetadata(t *testing.T) {
 	t.Parallel()
 	testCases := []struct {
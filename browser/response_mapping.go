@@ -13,6 +13,7 @@ func mapResponse(vu moduleVU, r *common.Response) mapping {
 		return nil
 	}
 	maps := mapping{
+		responseMappingRef: responseRef{r},
 		"allHeaders": func() *sobek.Promise {
 			return k6ext.Promise(vu.Context(), func() (any, error) {
 				return r.AllHeaders(), nil
@@ -87,3 +88,13 @@ func mapResponse(vu moduleVU, r *common.Response) mapping {
 
 	return maps
 }
+
+// responseMappingRef is the key of the response mapping
+// that refers back to the mapped response.
+const responseMappingRef = "__response"
+
+// responseRef refers to the response of a response mapping.
+// It is opaque to scripts.
+type responseRef struct {
+	r *common.Response
+}
@@ -0,0 +1,53 @@
+package browser
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/grafana/sobek"
+
+	"github.com/grafana/xk6-browser/common"
+	"github.com/grafana/xk6-browser/k6ext"
+)
+
+// mapSelectors to the JS module.
+func mapSelectors(vu moduleVU, s *common.Selectors) mapping {
+	return mapping{
+		"register": func(name string, script sobek.Value, opts sobek.Value) (*sobek.Promise, error) {
+			rt := vu.Runtime()
+			source, err := parseSelectorEngineScript(rt, script)
+			if err != nil {
+				return nil, fmt.Errorf("registering selector engine %q: %w", name, err)
+			}
+			popts := &common.SelectorEngineOptions{}
+			if err := mergeWith(rt, popts, opts); err != nil {
+				return nil, fmt.Errorf("parsing selector engine options: %w", err)
+			}
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return nil, s.Register(name, source, popts) //nolint:wrapcheck
+			}), nil
+		},
+	}
+}
+
+// parseSelectorEngineScript returns the JS source of a selector engine.
+// The script can be a function that returns the engine, a string that
+// evaluates to the engine, or an object with the source in its content
+// property.
+func parseSelectorEngineScript(rt *sobek.Runtime, script sobek.Value) (string, error) {
+	if !sobekValueExists(script) {
+		return "", errors.New("script is required")
+	}
+	if _, isFn := sobek.AssertFunction(script); isFn {
+		return fmt.Sprintf("(%s)()", script.String()), nil
+	}
+	if script.ExportType().Kind().String() == "string" {
+		return script.String(), nil
+	}
+	content := script.ToObject(rt).Get("content")
+	if !sobekValueExists(content) {
+		return "", errors.New("script must be a function, a string, or an object with content")
+	}
+
+	return content.String(), nil
+}
@@ -5,6 +5,7 @@ import (
 	"reflect"
 
 	"github.com/grafana/sobek"
+	"github.com/mstoykov/k6-taskqueue-lib/taskqueue"
 
 	"github.com/grafana/xk6-browser/common"
 	"github.com/grafana/xk6-browser/k6error"
@@ -67,12 +68,21 @@ func syncMapBrowserContext(vu moduleVU, bc *common.BrowserContext) mapping { //n
 			}
 
 			ctx := vu.Context()
+			// The task queue of the predicate must be created on the event
+			// loop. It's closed once waiting for the event is over.
+			var tq *taskqueue.TaskQueue
+			if popts.PredicateFn != nil {
+				tq = taskqueue.New(vu.RegisterCallback)
+				go func() {
+					<-ctx.Done()
+
+					tq.Close()
+				}()
+			}
 			return k6ext.Promise(ctx, func() (result any, reason error) {
 				var runInTaskQueue func(p *common.Page) (bool, error)
 				if popts.PredicateFn != nil {
 					runInTaskQueue = func(p *common.Page) (bool, error) {
-						tq := vu.taskQueueRegistry.get(vu.Context(), p.TargetID())
-
 						var rtn bool
 						var err error
 						// The function on the taskqueue runs in its own goroutine
@@ -93,6 +103,9 @@ func syncMapBrowserContext(vu moduleVU, bc *common.BrowserContext) mapping { //n
 				}
 
 				resp, err := bc.WaitForEvent(event, runInTaskQueue, popts.Timeout)
+				if tq != nil {
+					tq.Close()
+				}
 				panicIfFatalError(ctx, err)
 				if err != nil {
 					return nil, err //nolint:wrapcheck
@@ -121,7 +134,9 @@ func syncMapBrowserContext(vu moduleVU, bc *common.BrowserContext) mapping { //n
 			return rt.ToValue(mpages).ToObject(rt)
 		},
 		"newPage": func() (mapping, error) {
+			failOnPageError := failIterationOnPageError(vu)
 			page, err := bc.NewPage()
+			failOnPageError(page)
 			if err != nil {
 				return nil, err //nolint:wrapcheck
 			}
@@ -78,7 +78,9 @@ func syncMapBrowser(vu moduleVU) mapping { //nolint:funlen
 			if err != nil {
 				return nil, err
 			}
+			failOnPageError := failIterationOnPageError(vu)
 			page, err := b.NewPage(popts)
+			failOnPageError(page)
 			if err != nil {
 				return nil, err //nolint:wrapcheck
 			}
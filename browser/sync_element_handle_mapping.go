@@ -77,7 +77,7 @@ func syncMapElementHandle(vu moduleVU, eh *common.ElementHandle) mapping { //nol
 				return nil, fmt.Errorf("parsing frame screenshot options: %w", err)
 			}
 
-			bb, err := eh.Screenshot(popts, vu.filePersister)
+			bb, err := eh.Screenshot(popts, vu.iterationPersister())
 			if err != nil {
 				return nil, err //nolint:wrapcheck
 			}
@@ -1,6 +1,7 @@
 package browser
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/grafana/sobek"
@@ -114,7 +115,10 @@ func syncMapPage(vu moduleVU, p *common.Page) mapping { //nolint:gocognit,cyclop
 		},
 		"mouse": rt.ToValue(p.GetMouse()).ToObject(rt),
 		"on": func(event common.PageOnEventName, handler sobek.Callable) error {
-			tq := vu.taskQueueRegistry.get(vu.Context(), p.TargetID())
+			tq := vu.taskQueueRegistry.get(vu.Context(), p)
+			if tq == nil {
+				return errors.New("page is closed")
+			}
 
 			mapMsgAndHandleEvent := func(m *common.ConsoleMessage) error {
 				mapping := syncMapConsoleMessage(vu, m)
@@ -153,12 +157,12 @@ func syncMapPage(vu moduleVU, p *common.Page) mapping { //nolint:gocognit,cyclop
 		"screenshot": func(opts sobek.Value) (*sobek.ArrayBuffer, error) {
 			ctx := vu.Context()
 
-			popts := common.NewPageScreenshotOptions()
+			popts := common.NewPageScreenshotOptions(p.Timeout())
 			if err := popts.Parse(ctx, opts); err != nil {
 				return nil, fmt.Errorf("parsing page screenshot options: %w", err)
 			}
 
-			bb, err := p.Screenshot(popts, vu.filePersister)
+			bb, err := p.Screenshot(popts, vu.iterationPersister())
 			if err != nil {
 				return nil, err //nolint:wrapcheck
 			}
@@ -0,0 +1,60 @@
+package browser
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/grafana/sobek"
+
+	"github.com/grafana/xk6-browser/common"
+	"github.com/grafana/xk6-browser/k6ext"
+)
+
+// mapTracing to the JS module.
+func mapTracing(vu moduleVU, t *common.Tracing) mapping {
+	rt := vu.Runtime()
+	return mapping{
+		"start": func(opts sobek.Value) (*sobek.Promise, error) {
+			popts, err := exportTo[common.TracingStartOptions](rt, opts)
+			if err != nil {
+				return nil, fmt.Errorf("parsing tracing start options: %w", err)
+			}
+			var sources []string
+			if popts.Sources {
+				sources = scriptSources(rt)
+			}
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return nil, t.Start(&popts, sources) //nolint:wrapcheck
+			}), nil
+		},
+		"stop": func(opts sobek.Value) (*sobek.Promise, error) {
+			popts, err := exportTo[common.TracingStopOptions](rt, opts)
+			if err != nil {
+				return nil, fmt.Errorf("parsing tracing stop options: %w", err)
+			}
+			fp := vu.iterationPersister()
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return nil, t.Stop(&popts, fp) //nolint:wrapcheck
+			}), nil
+		},
+	}
+}
+
+// scriptSources returns the paths of the local script files
+// in the current call stack.
+func scriptSources(rt *sobek.Runtime) []string {
+	var (
+		paths []string
+		seen  = make(map[string]bool)
+	)
+	for _, f := range rt.CaptureCallStack(0, nil) {
+		u, err := url.Parse(f.SrcName())
+		if err != nil || u.Scheme != "file" || seen[u.Path] {
+			continue
+		}
+		seen[u.Path] = true
+		paths = append(paths, u.Path)
+	}
+
+	return paths
+}
@@ -0,0 +1,21 @@
+package browser
+
+import (
+	"github.com/grafana/sobek"
+
+	"github.com/grafana/xk6-browser/common"
+	"github.com/grafana/xk6-browser/k6ext"
+)
+
+// mapVideo to the JS module.
+func mapVideo(vu moduleVU, v *common.Video) mapping {
+	return mapping{
+		"path": v.Path,
+		"saveAs": func(path string) *sobek.Promise {
+			fp := vu.iterationPersister()
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return nil, v.SaveAs(path, fp) //nolint:wrapcheck
+			})
+		},
+	}
+}
@@ -0,0 +1,234 @@
+package common
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// aviHeaderSize is the size of the headers that precede the
+// frames of an AVI file that aviWriter writes. The headers are
+// written with placeholder values first, and rewritten with the
+// final values when the writer is closed.
+const aviHeaderSize = 224
+
+// aviMoviOffset is the offset of the movi list type in an AVI file
+// that aviWriter writes. The offsets of the index are relative to it.
+const aviMoviOffset = 220
+
+// AVI header flags.
+const (
+	aviHasIndex   = 0x10 // AVIF_HASINDEX
+	aviIsKeyframe = 0x10 // AVIIF_KEYFRAME
+)
+
+var (
+	errAVIWriterClosed = errors.New("avi writer is closed")
+	errAVINoFrame      = errors.New("avi writer has no frame to repeat")
+)
+
+// aviWriter writes Motion JPEG frames into an AVI file, which is
+// playable by most video players without any other dependencies.
+// The frames are written as they come, and the headers and the
+// index of the file are written when the writer is closed.
+type aviWriter struct {
+	w   io.WriteSeeker
+	fps int
+
+	width, height int
+	maxFrameSize  int
+	// offsets and sizes of the frames for the index.
+	offsets []uint32
+	sizes   []uint32
+	// pos is the offset of the next frame.
+	pos    uint32
+	closed bool
+}
+
+// newAVIWriter returns a new aviWriter that writes frames of the given
+// size to w at the given frame rate.
+func newAVIWriter(w io.WriteSeeker, width, height, fps int) (*aviWriter, error) {
+	aw := &aviWriter{
+		w:      w,
+		fps:    fps,
+		width:  width,
+		height: height,
+		pos:    aviHeaderSize,
+	}
+	if _, err := w.Write(make([]byte, aviHeaderSize)); err != nil {
+		return nil, fmt.Errorf("writing avi header: %w", err)
+	}
+
+	return aw, nil
+}
+
+// WriteFrame writes a JPEG image as the next frame of the video.
+func (aw *aviWriter) WriteFrame(jpeg []byte) error {
+	if aw.closed {
+		return errAVIWriterClosed
+	}
+
+	// Chunks are padded to an even size.
+	size := len(jpeg)
+	chunk := make([]byte, 8, 8+size+size%2)
+	copy(chunk, "00dc")
+	binary.LittleEndian.PutUint32(chunk[4:], uint32(size))
+	chunk = append(chunk, jpeg...)
+	if size%2 == 1 {
+		chunk = append(chunk, 0)
+	}
+	if _, err := aw.w.Write(chunk); err != nil {
+		return fmt.Errorf("writing avi frame: %w", err)
+	}
+
+	aw.offsets = append(aw.offsets, aw.pos-aviMoviOffset)
+	aw.sizes = append(aw.sizes, uint32(size))
+	aw.pos += uint32(len(chunk))
+	aw.maxFrameSize = max(aw.maxFrameSize, size)
+
+	return nil
+}
+
+// RepeatFrame repeats the last frame as the next frame of the video.
+// The frame is not written again, as the index of the file refers to
+// the chunk of the last frame for the repeated frame.
+func (aw *aviWriter) RepeatFrame() error {
+	if aw.closed {
+		return errAVIWriterClosed
+	}
+	n := len(aw.sizes)
+	if n == 0 {
+		return errAVINoFrame
+	}
+	aw.offsets = append(aw.offsets, aw.offsets[n-1])
+	aw.sizes = append(aw.sizes, aw.sizes[n-1])
+
+	return nil
+}
+
+// Frames returns the number of frames written so far.
+func (aw *aviWriter) Frames() int {
+	return len(aw.sizes)
+}
+
+// Close writes the index and the headers of the file.
+// It doesn't close the underlying writer.
+func (aw *aviWriter) Close() error {
+	if aw.closed {
+		return nil
+	}
+	aw.closed = true
+
+	var idx bytes.Buffer
+	idx.WriteString("idx1")
+	le32(&idx, uint32(16*len(aw.sizes)))
+	for i := range aw.sizes {
+		idx.WriteString("00dc")
+		le32(&idx, aviIsKeyframe)
+		le32(&idx, aw.offsets[i])
+		le32(&idx, aw.sizes[i])
+	}
+	if _, err := aw.w.Write(idx.Bytes()); err != nil {
+		return fmt.Errorf("writing avi index: %w", err)
+	}
+	fileSize := aw.pos + uint32(idx.Len())
+
+	if _, err := aw.w.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("writing avi header: %w", err)
+	}
+	if _, err := aw.w.Write(aw.header(fileSize)); err != nil {
+		return fmt.Errorf("writing avi header: %w", err)
+	}
+	if _, err := aw.w.Seek(0, io.SeekEnd); err != nil {
+		return fmt.Errorf("writing avi header: %w", err)
+	}
+
+	return nil
+}
+
+// header returns the RIFF headers of the file, which are:
+//
+//	RIFF AVI
+//	  LIST hdrl
+//	    avih
+//	    LIST strl
+//	      strh
+//	      strf
+//	  LIST movi
+func (aw *aviWriter) header(fileSize uint32) []byte {
+	var (
+		frames = uint32(len(aw.sizes))
+		width  = uint32(aw.width)
+		height = uint32(aw.height)
+		buf    = uint32(aw.maxFrameSize + 8)
+		h      bytes.Buffer
+	)
+
+	h.WriteString("RIFF")
+	le32(&h, fileSize-8)
+	h.WriteString("AVI ")
+
+	h.WriteString("LIST")
+	le32(&h, 192)
+	h.WriteString("hdrl")
+
+	// The main AVI header.
+	h.WriteString("avih")
+	le32(&h, 56)
+	le32(&h, uint32(1_000_000/aw.fps)) // microseconds per frame
+	le32(&h, buf*uint32(aw.fps))       // max bytes per second
+	le32(&h, 0)                        // padding granularity
+	le32(&h, aviHasIndex)              // flags
+	le32(&h, frames)                   // total frames
+	le32(&h, 0)                        // initial frames
+	le32(&h, 1)                        // streams
+	le32(&h, buf)                      // suggested buffer size
+	le32(&h, width)
+	le32(&h, height)
+	h.Write(make([]byte, 16)) // reserved
+
+	h.WriteString("LIST")
+	le32(&h, 116)
+	h.WriteString("strl")
+
+	// The video stream header.
+	h.WriteString("strh")
+	le32(&h, 56)
+	h.WriteString("vids")
+	h.WriteString("MJPG")
+	le32(&h, 0)                // flags
+	le32(&h, 0)                // priority and language
+	le32(&h, 0)                // initial frames
+	le32(&h, 1)                // scale
+	le32(&h, uint32(aw.fps))   // rate, the frame rate is rate/scale
+	le32(&h, 0)                // start
+	le32(&h, frames)           // length
+	le32(&h, buf)              // suggested buffer size
+	le32(&h, 0xFFFFFFFF)       // quality, -1 is the default quality
+	le32(&h, 0)                // sample size
+	le32(&h, 0)                // frame left and top
+	le32(&h, width|height<<16) // frame right and bottom
+
+	// The video stream format, which is a BITMAPINFOHEADER.
+	h.WriteString("strf")
+	le32(&h, 40)
+	le32(&h, 40) // size
+	le32(&h, width)
+	le32(&h, height)
+	le32(&h, 1|24<<16) // planes and bits per pixel
+	h.WriteString("MJPG")
+	le32(&h, width*height*3)  // image size
+	h.Write(make([]byte, 16)) // resolution and colors
+
+	h.WriteString("LIST")
+	le32(&h, aw.pos-aviMoviOffset)
+	h.WriteString("movi")
+
+	return h.Bytes()
+}
+
+func le32(b *bytes.Buffer, v uint32) {
+	_ = binary.Write(b, binary.LittleEndian, v)
+}
@@ -0,0 +1,80 @@
+package common
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAVIWriter(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "video.avi"))
+	require.NoError(t, err)
+	defer f.Close() //nolint:errcheck
+
+	aw, err := newAVIWriter(f, 640, 480, 25)
+	require.NoError(t, err)
+
+	// Frames of an odd size are padded.
+	frames := [][]byte{[]byte("frame-1"), []byte("frame-02"), []byte("frame-003")}
+	for _, frame := range frames {
+		require.NoError(t, aw.WriteFrame(frame))
+	}
+	// The repeated frame refers to the chunk of the last frame.
+	require.NoError(t, aw.RepeatFrame())
+	frames = append(frames, frames[2])
+	require.NoError(t, aw.Close())
+	assert.Equal(t, 4, aw.Frames())
+	assert.ErrorIs(t, aw.RepeatFrame(), errAVIWriterClosed)
+	assert.ErrorIs(t, aw.WriteFrame(frames[0]), errAVIWriterClosed)
+
+	b, err := os.ReadFile(f.Name())
+	require.NoError(t, err)
+	u32 := func(off int) uint32 { return binary.LittleEndian.Uint32(b[off:]) }
+
+	assert.Equal(t, "RIFF", string(b[0:4]))
+	assert.Equal(t, uint32(len(b)-8), u32(4))
+	assert.Equal(t, "AVI ", string(b[8:12]))
+	assert.Equal(t, "avih", string(b[24:28]))
+	assert.Equal(t, uint32(40_000), u32(32), "microseconds per frame")
+	assert.Equal(t, uint32(4), u32(48), "total frames")
+	assert.Equal(t, uint32(640), u32(64), "width")
+	assert.Equal(t, uint32(480), u32(68), "height")
+	assert.Equal(t, "strh", string(b[100:104]))
+	assert.Equal(t, "vidsMJPG", string(b[108:116]))
+	assert.Equal(t, uint32(25), u32(132), "rate")
+	assert.Equal(t, uint32(4), u32(140), "length")
+	assert.Equal(t, "strf", string(b[164:168]))
+	assert.Equal(t, "movi", string(b[aviMoviOffset:aviMoviOffset+4]))
+
+	// The index points to the frames in the movi list.
+	moviEnd := aviMoviOffset + int(u32(aviMoviOffset-4))
+	assert.Equal(t, "idx1", string(b[moviEnd:moviEnd+4]))
+	assert.Equal(t, uint32(16*len(frames)), u32(moviEnd+4))
+	assert.Equal(t, u32(moviEnd+8+16*2+8), u32(moviEnd+8+16*3+8), "offset of the repeated frame")
+	for i, frame := range frames {
+		entry := moviEnd + 8 + 16*i
+		assert.Equal(t, "00dc", string(b[entry:entry+4]))
+		off := aviMoviOffset + int(u32(entry+8))
+		size := int(u32(entry + 12))
+		assert.Equal(t, "00dc", string(b[off:off+4]))
+		assert.Equal(t, frame, b[off+8:off+8+size])
+	}
+}
+
+func TestAVIWriterRepeatFrame(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "video.avi"))
+	require.NoError(t, err)
+	defer f.Close() //nolint:errcheck
+
+	aw, err := newAVIWriter(f, 640, 480, 25)
+	require.NoError(t, err)
+	assert.ErrorIs(t, aw.RepeatFrame(), errAVINoFrame)
+}
@@ -0,0 +1,178 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	cdpruntime "github.com/chromedp/cdproto/runtime"
+
+	"github.com/grafana/xk6-browser/common/js"
+)
+
+// exposedBinding is the name of the binding that the functions, which are
+// exposed to pages with ExposeBinding, use to call their binding functions.
+const exposedBinding = "k6browserExposedBinding"
+
+// deliverBindingResultScript settles the promise of an exposed binding call.
+const deliverBindingResultScript = `(name, seq, result, error) => {
+	globalThis.__k6browserBindings.get(name).deliver(seq, result, error);
+}`
+
+// ErrBindingExists is returned when a binding is exposed with
+// the name of an already exposed binding.
+var ErrBindingExists = errors.New("binding has already been exposed")
+
+// BindingSource is the source of a call to an exposed binding.
+type BindingSource struct {
+	// Page is the page that called the binding.
+	Page *Page
+	// Frame is the frame that called the binding.
+	Frame *Frame
+}
+
+// BindingFunc is the function of an exposed binding. It's called with the
+// source of the call and the arguments that the page passed to the binding,
+// and its result, or its error, is returned to the page. The arguments and
+// the result are serialized to JSON.
+type BindingFunc func(source BindingSource, args []any) (any, error)
+
+// bindings are the exposed bindings of a page or a browser context.
+type bindings struct {
+	mu sync.RWMutex
+	m  map[string]BindingFunc
+}
+
+func (b *bindings) get(name string) (BindingFunc, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	fn, ok := b.m[name]
+	return fn, ok
+}
+
+func (b *bindings) add(name string, fn BindingFunc) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.m[name]; ok {
+		return ErrBindingExists
+	}
+	if b.m == nil {
+		b.m = make(map[string]BindingFunc)
+	}
+	b.m[name] = fn
+
+	return nil
+}
+
+// ExposeBinding exposes a function of the given name to all the frames of
+// the page, including the frames of the future navigations. Calling the
+// function from the page calls the binding function, and returns a promise
+// that is settled with the result of the binding function.
+func (p *Page) ExposeBinding(name string, fn BindingFunc) error {
+	p.logger.Debugf("Page:ExposeBinding", "sid:%v name:%s", p.sessionID(), name)
+
+	if _, ok := p.browserCtx.bindings.get(name); ok {
+		return fmt.Errorf("exposing binding %q: %w", name, ErrBindingExists)
+	}
+	if err := p.bindings.add(name, fn); err != nil {
+		return fmt.Errorf("exposing binding %q: %w", name, err)
+	}
+	if err := p.evaluateOnNewDocument(bindingSource(name)); err != nil {
+		return fmt.Errorf("exposing binding %q: %w", name, err)
+	}
+	p.installBinding(name)
+
+	return nil
+}
+
+// ExposeBinding exposes a function of the given name to all the pages of
+// the browser context in the same way as Page.ExposeBinding does.
+func (b *BrowserContext) ExposeBinding(name string, fn BindingFunc) error {
+	b.logger.Debugf("BrowserContext:ExposeBinding", "bctxid:%v name:%s", b.id, name)
+
+	pages := b.browser.getPages()
+	for _, p := range pages {
+		if _, ok := p.bindings.get(name); ok {
+			return fmt.Errorf("exposing binding %q: %w", name, ErrBindingExists)
+		}
+	}
+	if err := b.bindings.add(name, fn); err != nil {
+		return fmt.Errorf("exposing binding %q: %w", name, err)
+	}
+	if err := b.AddInitScript(bindingSource(name)); err != nil {
+		return fmt.Errorf("exposing binding %q: %w", name, err)
+	}
+	for _, p := range pages {
+		p.installBinding(name)
+	}
+
+	return nil
+}
+
+// bindingSource returns the source of the init script that
+// exposes the binding of the given name.
+func bindingSource(name string) string {
+	args, _ := json.Marshal([]string{exposedBinding, name}) //nolint:errchkjson
+	return fmt.Sprintf("(%s)(...%s);", js.ExposedBindingScript, args)
+}
+
+// installBinding exposes the binding of the given name to the current
+// documents of the page's frames. The init script of the binding exposes
+// it to the future documents.
+func (p *Page) installBinding(name string) {
+	for _, f := range p.frameManager.Frames() {
+		if _, err := f.EvaluateWithContext(p.ctx, js.ExposedBindingScript, exposedBinding, name); err != nil {
+			p.logger.Debugf("Page:installBinding", "fid:%s name:%s err:%v", f.ID(), name, err)
+		}
+	}
+}
+
+// onBindingCalled calls the binding function of an exposed binding call,
+// and delivers its result to the page.
+func (p *Page) onBindingCalled(event *cdpruntime.EventBindingCalled) {
+	var bc struct {
+		Name string `json:"name"`
+		Seq  int64  `json:"seq"`
+		Args []any  `json:"args"`
+	}
+	if err := json.Unmarshal([]byte(event.Payload), &bc); err != nil {
+		p.logger.Errorf("Page:onBindingCalled", "parsing binding call: %v", err)
+		return
+	}
+	ec, err := p.executionContextForID(event.ExecutionContextID)
+	if err != nil {
+		p.logger.Debugf("Page:onBindingCalled", "name:%s err:%v", bc.Name, err)
+		return
+	}
+
+	fn, ok := p.bindings.get(bc.Name)
+	if !ok {
+		fn, ok = p.browserCtx.bindings.get(bc.Name)
+	}
+	var result any
+	if ok {
+		result, err = fn(BindingSource{Page: p, Frame: ec.frame}, bc.Args)
+	} else {
+		err = fmt.Errorf("binding %q is not exposed", bc.Name)
+	}
+	if err == nil {
+		if _, merr := json.Marshal(result); merr != nil {
+			err = fmt.Errorf("serializing result of binding %q: %w", bc.Name, merr)
+		}
+	}
+
+	var errMsg any
+	if err != nil {
+		result, errMsg = nil, err.Error()
+	}
+	opts := evalOptions{
+		forceCallable: true,
+		returnByValue: true,
+	}
+	if _, err := ec.eval(p.ctx, opts, deliverBindingResultScript, bc.Name, bc.Seq, result, errMsg); err != nil {
+		p.logger.Debugf("Page:onBindingCalled", "delivering result of binding %q: %v", bc.Name, err)
+	}
+}
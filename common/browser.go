@@ -61,6 +61,10 @@ type Browser struct {
 	pagesMu sync.RWMutex
 	pages   map[target.ID]*Page
 
+	// downloads are the downloads in progress by their GUID.
+	downloadsMu sync.Mutex
+	downloads   map[string]*Download
+
 	sessionIDtoTargetIDMu sync.RWMutex
 	sessionIDtoTargetID   map[target.SessionID]target.ID
 
@@ -70,6 +74,9 @@ type Browser struct {
 	// version caches the browser version information.
 	version browserVersion
 
+	// selectors are the custom selector engines of the VU.
+	selectors *Selectors
+
 	logger *log.Logger
 }
 
@@ -130,7 +137,9 @@ func newBrowser(
 		browserProc:         browserProc,
 		browserOpts:         browserOpts,
 		pages:               make(map[target.ID]*Page),
+		downloads:           make(map[string]*Download),
 		sessionIDtoTargetID: make(map[target.SessionID]target.ID),
+		selectors:           GetSelectors(vuCtx),
 		logger:              logger,
 	}
 }
@@ -210,6 +219,8 @@ func (b *Browser) initEvents() error {
 	b.conn.on(b.browserCtx, []string{
 		cdproto.EventTargetAttachedToTarget,
 		cdproto.EventTargetDetachedFromTarget,
+		cdproto.EventBrowserDownloadWillBegin,
+		cdproto.EventBrowserDownloadProgress,
 		EventConnectionClose,
 	}, chHandler)
 
@@ -237,6 +248,10 @@ func (b *Browser) initEvents() error {
 				} else if ev, ok := event.data.(*target.EventDetachedFromTarget); ok {
 					b.logger.Debugf("Browser:initEvents:onDetachedFromTarget", "sid:%v", ev.SessionID)
 					b.onDetachedFromTarget(ev)
+				} else if ev, ok := event.data.(*cdpbrowser.EventDownloadWillBegin); ok {
+					b.onDownloadWillBegin(ev)
+				} else if ev, ok := event.data.(*cdpbrowser.EventDownloadProgress); ok {
+					b.onDownloadProgress(ev)
 				} else if event.typ == EventConnectionClose {
 					b.logger.Debugf("Browser:initEvents:EventConnectionClose", "")
 					return
@@ -327,7 +342,7 @@ func (b *Browser) onAttachedToTarget(ev *target.EventAttachedToTarget) error {
 	// Emit the page event only for pages, not for background pages.
 	// Background pages are created by extensions.
 	if isPage {
-		browserCtx.emit(EventBrowserContextPage, p)
+		b.onNewPage(p, targetPage.URL)
 	}
 
 	return nil
@@ -663,6 +678,11 @@ func (b *Browser) On(event string) (bool, error) {
 	}
 }
 
+// Selectors returns the registry of custom selector engines of the VU.
+func (b *Browser) Selectors() *Selectors {
+	return b.selectors
+}
+
 // UserAgent returns the controlled browser's user agent string.
 func (b *Browser) UserAgent() string {
 	return b.version.userAgent
@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	cdpbrowser "github.com/chromedp/cdproto/browser"
@@ -26,6 +27,24 @@ import (
 // with the browserContext.waitForEvent API.
 type waitForEventType string
 
+// BrowserContextOnEventName represents the name of the browserContext.on event.
+type BrowserContextOnEventName string
+
+const (
+	// EventBrowserContextPageCreated represents the browserContext.on('page') event.
+	EventBrowserContextPageCreated BrowserContextOnEventName = "page"
+)
+
+// BrowserContextOnEvent represents a generic browser context event.
+// Use one of the fields to get the specific event data.
+type BrowserContextOnEvent struct {
+	// Page is the new page event.
+	Page *Page
+}
+
+// BrowserContextOnHandler is the handler of a browserContext.on event.
+type BrowserContextOnHandler func(BrowserContextOnEvent) error
+
 // Cookie represents a browser cookie.
 //
 // https://datatracker.ietf.org/doc/html/rfc6265.
@@ -85,6 +104,17 @@ type BrowserContext struct {
 
 	evaluateOnNewDocumentSources []string
 
+	eventHandlers   map[BrowserContextOnEventName][]BrowserContextOnHandler
+	eventHandlersMu sync.RWMutex
+
+	bindings bindings
+	tracing  *Tracing
+
+	// closeFns are called when the browser context closes.
+	closeMu  sync.Mutex
+	closed   bool
+	closeFns []func()
+
 	// DownloadsPath is the path where downloads will be stored.
 	DownloadsPath string
 }
@@ -133,6 +163,14 @@ func NewBrowserContext(
 		logger:           logger,
 		vu:               k6ext.GetVU(ctx),
 		timeoutSettings:  NewTimeoutSettings(nil),
+		eventHandlers:    make(map[BrowserContextOnEventName][]BrowserContextOnHandler),
+	}
+	b.tracing = NewTracing(&b)
+
+	if opts.Humanize != nil {
+		if err := opts.Humanize.Validate(); err != nil {
+			return nil, fmt.Errorf("validating browser context options: %w", err)
+		}
 	}
 
 	if len(opts.Permissions) > 0 {
@@ -151,6 +189,11 @@ func NewBrowserContext(
 	if err := b.setDownloadsPath(opts.DownloadsPath); err != nil {
 		return nil, fmt.Errorf("setting downloads path: %w", err)
 	}
+	if opts.AcceptDownloads {
+		if err := b.enableDownloads(); err != nil {
+			return nil, err
+		}
+	}
 
 	return &b, nil
 }
@@ -207,9 +250,33 @@ func (b *BrowserContext) Close() error {
 	if err := b.browser.disposeContext(b.id); err != nil {
 		return fmt.Errorf("disposing browser context: %w", err)
 	}
+
+	b.closeMu.Lock()
+	b.closed = true
+	closeFns := b.closeFns
+	b.closeFns = nil
+	b.closeMu.Unlock()
+	for _, fn := range closeFns {
+		fn()
+	}
+
 	return nil
 }
 
+// OnClose registers fn to be called once the browser context closes.
+// fn is called right away if the browser context is already closed.
+func (b *BrowserContext) OnClose(fn func()) {
+	b.closeMu.Lock()
+	if !b.closed {
+		b.closeFns = append(b.closeFns, fn)
+		b.closeMu.Unlock()
+		return
+	}
+	b.closeMu.Unlock()
+
+	fn()
+}
+
 // GrantPermissions enables the specified permissions, all others will be disabled.
 func (b *BrowserContext) GrantPermissions(permissions []string, opts GrantPermissionsOptions) error {
 	b.logger.Debugf("BrowserContext:GrantPermissions", "bctxid:%v", b.id)
@@ -277,6 +344,47 @@ func (b *BrowserContext) NewPage() (*Page, error) {
 	return p, nil
 }
 
+// On subscribes to a browser context event for which the given handler
+// will be executed passing in the data associated with the event, such
+// as the new Page of the 'page' event.
+func (b *BrowserContext) On(event BrowserContextOnEventName, handler BrowserContextOnHandler) error {
+	if event != EventBrowserContextPageCreated {
+		return fmt.Errorf("unknown browser context event: %q", event)
+	}
+
+	b.eventHandlersMu.Lock()
+	defer b.eventHandlersMu.Unlock()
+
+	b.eventHandlers[event] = append(b.eventHandlers[event], handler)
+
+	return nil
+}
+
+// hasOnHandler returns true if the browser context has handlers
+// for the browserContext.on event.
+func (b *BrowserContext) hasOnHandler(event BrowserContextOnEventName) bool {
+	b.eventHandlersMu.RLock()
+	defer b.eventHandlersMu.RUnlock()
+
+	return len(b.eventHandlers[event]) > 0
+}
+
+// onPage calls the browserContext.on('page') handlers
+// with the new page of the browser context.
+func (b *BrowserContext) onPage(p *Page) {
+	b.eventHandlersMu.RLock()
+	defer b.eventHandlersMu.RUnlock()
+	for _, h := range b.eventHandlers[EventBrowserContextPageCreated] {
+		err := h(BrowserContextOnEvent{
+			Page: p,
+		})
+		if err != nil {
+			b.logger.Debugf("BrowserContext:onPage", "handler returned an error: %v", err)
+			return
+		}
+	}
+}
+
 // Pages returns a list of pages inside this browser context.
 func (b *BrowserContext) Pages() []*Page {
 	return append([]*Page{}, b.browser.getPages()...)
@@ -10,9 +10,11 @@ type BrowserContextOptions struct {
 	ColorScheme       ColorScheme       `js:"colorScheme"`
 	DeviceScaleFactor float64           `js:"deviceScaleFactor"`
 	ExtraHTTPHeaders  map[string]string `js:"extraHTTPHeaders"`
+	FailOnPageError   bool              `js:"failOnPageError"`
 	Geolocation       *Geolocation      `js:"geolocation"`
 	HasTouch          bool              `js:"hasTouch"`
 	HTTPCredentials   Credentials       `js:"httpCredentials"`
+	Humanize          *Humanize         `js:"humanize"`
 	IgnoreHTTPSErrors bool              `js:"ignoreHTTPSErrors"`
 	IsMobile          bool              `js:"isMobile"`
 	JavaScriptEnabled bool              `js:"javaScriptEnabled"`
@@ -21,6 +23,7 @@ type BrowserContextOptions struct {
 	Permissions       []string          `js:"permissions"`
 	ReducedMotion     ReducedMotion     `js:"reducedMotion"`
 	Screen            Screen            `js:"screen"`
+	StrictSelectors   bool              `js:"strictSelectors"`
 	TimezoneID        string            `js:"timezoneID"`
 	UserAgent         string            `js:"userAgent"`
 	VideosPath        string            `js:"videosPath"`
@@ -67,3 +70,24 @@ func (g *Geolocation) Validate() error {
 type GrantPermissionsOptions struct {
 	Origin string
 }
+
+// TracingStartOptions is used by Tracing.Start.
+type TracingStartOptions struct {
+	// Name is the name of the trace, used as the title of the trace.
+	Name string `js:"name"`
+	// Screenshots records the screencast frames of the pages.
+	Screenshots bool `js:"screenshots"`
+	// Snapshots records DOM snapshots before and after the API calls.
+	Snapshots bool `js:"snapshots"`
+	// Sources records the sources of the test script.
+	Sources bool `js:"sources"`
+	// Title is the title of the trace in the trace viewer.
+	Title string `js:"title"`
+}
+
+// TracingStopOptions is used by Tracing.Stop.
+type TracingStopOptions struct {
+	// Path is the path at which the trace archive is persisted.
+	// The trace is discarded if it's empty.
+	Path string `js:"path"`
+}
@@ -2,14 +2,20 @@ package common
 
 import (
 	"context"
+	"fmt"
+
+	"github.com/grafana/xk6-browser/storage"
 )
 
 type ctxKey int
 
 const (
 	ctxKeyBrowserOptions ctxKey = iota
+	ctxKeyFilePersister
+	ctxKeyFileReader
 	ctxKeyHooks
 	ctxKeyIterationID
+	ctxKeySelectors
 	ctxKeyTracer
 )
 
@@ -70,6 +76,57 @@ func GetTracer(ctx context.Context) Tracer {
 	return nil
 }
 
+// WithSelectors adds the registry of the custom selector
+// engines of the VU to the context.
+func WithSelectors(ctx context.Context, s *Selectors) context.Context {
+	return context.WithValue(ctx, ctxKeySelectors, s)
+}
+
+// GetSelectors returns the registry of the custom selector engines
+// attached to the context, or a new empty registry if not found.
+func GetSelectors(ctx context.Context) *Selectors {
+	if s, ok := ctx.Value(ctxKeySelectors).(*Selectors); ok && s != nil {
+		return s
+	}
+	return NewSelectors()
+}
+
+// WithFilePersister adds the file persister that persists
+// the files of the browser, such as videos, to the context.
+func WithFilePersister(ctx context.Context, fp ScreenshotPersister) context.Context {
+	return context.WithValue(ctx, ctxKeyFilePersister, fp)
+}
+
+// GetFilePersister returns the file persister attached to the context,
+// or a persister that persists files to the local disk if not found.
+func GetFilePersister(ctx context.Context) ScreenshotPersister {
+	if fp, ok := ctx.Value(ctxKeyFilePersister).(ScreenshotPersister); ok && fp != nil {
+		return fp
+	}
+	return &storage.LocalFilePersister{}
+}
+
+// FileReader reads the file at the path, which is relative to the
+// test script, the way that k6's open function reads the files.
+type FileReader func(path string) ([]byte, error)
+
+// WithFileReader adds the file reader that reads the files
+// that the scripts refer to, such as the script tag files.
+func WithFileReader(ctx context.Context, r FileReader) context.Context {
+	return context.WithValue(ctx, ctxKeyFileReader, r)
+}
+
+// GetFileReader returns the file reader attached to the context,
+// or a reader that can't read any files if not found.
+func GetFileReader(ctx context.Context) FileReader {
+	if r, ok := ctx.Value(ctxKeyFileReader).(FileReader); ok && r != nil {
+		return r
+	}
+	return func(path string) ([]byte, error) {
+		return nil, fmt.Errorf("reading %q: no file system to read it from", path)
+	}
+}
+
 // contextWithDoneChan returns a new context that is canceled either
 // when the done channel is closed or ctx is canceled.
 func contextWithDoneChan(ctx context.Context, done chan struct{}) context.Context {
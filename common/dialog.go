@@ -0,0 +1,96 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/chromedp/cdproto/cdp"
+	cdppage "github.com/chromedp/cdproto/page"
+)
+
+// ErrDialogHandled is returned when a dialog is accepted or
+// dismissed more than once.
+var ErrDialogHandled = errors.New("dialog has already been handled")
+
+// Dialog represents a JavaScript dialog, such as an alert, confirm,
+// prompt or beforeunload dialog, that is opened by a page.
+// The dialog blocks the page until it is accepted or dismissed.
+type Dialog struct {
+	ctx     context.Context
+	session session
+
+	typ          string
+	message      string
+	defaultValue string
+
+	handledMu sync.Mutex
+	handled   bool
+}
+
+// NewDialog returns a new dialog of the given type.
+func NewDialog(ctx context.Context, s session, typ, message, defaultValue string) *Dialog {
+	return &Dialog{
+		ctx:          ctx,
+		session:      s,
+		typ:          typ,
+		message:      message,
+		defaultValue: defaultValue,
+	}
+}
+
+// Type returns the type of the dialog. It can be one of
+// alert, beforeunload, confirm or prompt.
+func (d *Dialog) Type() string {
+	return d.typ
+}
+
+// Message returns the message of the dialog.
+func (d *Dialog) Message() string {
+	return d.message
+}
+
+// DefaultValue returns the default value of a prompt dialog,
+// or an empty string for other dialogs.
+func (d *Dialog) DefaultValue() string {
+	return d.defaultValue
+}
+
+// Accept accepts the dialog. The prompt text is entered into
+// a prompt dialog and is ignored by other dialogs.
+func (d *Dialog) Accept(promptText string) error {
+	if err := d.handle(true, promptText); err != nil {
+		return fmt.Errorf("accepting %s dialog: %w", d.typ, err)
+	}
+
+	return nil
+}
+
+// Dismiss dismisses the dialog.
+func (d *Dialog) Dismiss() error {
+	if err := d.handle(false, ""); err != nil {
+		return fmt.Errorf("dismissing %s dialog: %w", d.typ, err)
+	}
+
+	return nil
+}
+
+func (d *Dialog) handle(accept bool, promptText string) error {
+	d.handledMu.Lock()
+	defer d.handledMu.Unlock()
+
+	if d.handled {
+		return ErrDialogHandled
+	}
+	action := cdppage.HandleJavaScriptDialog(accept)
+	if promptText != "" {
+		action = action.WithPromptText(promptText)
+	}
+	if err := action.Do(cdp.WithExecutor(d.ctx, d.session)); err != nil {
+		return err //nolint:wrapcheck
+	}
+	d.handled = true
+
+	return nil
+}
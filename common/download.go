@@ -0,0 +1,252 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	cdpbrowser "github.com/chromedp/cdproto/browser"
+	"github.com/chromedp/cdproto/cdp"
+	k6metrics "go.k6.io/k6/metrics"
+
+	"github.com/grafana/xk6-browser/k6ext"
+)
+
+// Download failures.
+const (
+	// DownloadFailureCanceled is the failure of a canceled download.
+	DownloadFailureCanceled = "canceled"
+)
+
+// Download represents a file download that is started by a page.
+// The downloaded file is stored in the downloads path of the browser
+// context with a unique name, and can be saved elsewhere with SaveAs.
+type Download struct {
+	ctx        context.Context
+	page       *Page
+	browserCtx *BrowserContext
+
+	guid              string
+	url               string
+	suggestedFilename string
+	path              string
+	started           time.Time
+
+	done       chan struct{}
+	doneOnce   sync.Once
+	mu         sync.RWMutex
+	failure    string
+	totalBytes int64
+}
+
+// NewDownload returns a new download that is started by the page.
+// The downloaded file is stored at the path of the given GUID in the
+// downloads path of the page's browser context.
+func NewDownload(ctx context.Context, p *Page, guid, url, suggestedFilename string) *Download {
+	return &Download{
+		ctx:               ctx,
+		page:              p,
+		browserCtx:        p.browserCtx,
+		guid:              guid,
+		url:               url,
+		suggestedFilename: suggestedFilename,
+		path:              filepath.Join(p.browserCtx.DownloadsPath, guid),
+		started:           time.Now(),
+		done:              make(chan struct{}),
+	}
+}
+
+// Page returns the page that started the download.
+func (d *Download) Page() *Page {
+	return d.page
+}
+
+// URL returns the URL of the download.
+func (d *Download) URL() string {
+	return d.url
+}
+
+// SuggestedFilename returns the file name that the browser suggests for
+// the download, which is usually based on the Content-Disposition header
+// of the response or on the URL.
+func (d *Download) SuggestedFilename() string {
+	return d.suggestedFilename
+}
+
+// Path waits for the download to finish and returns the path of the
+// downloaded file. It returns an error if the download failed.
+func (d *Download) Path() (string, error) {
+	failure, err := d.Failure()
+	if err != nil {
+		return "", err
+	}
+	if failure != "" {
+		return "", fmt.Errorf("download of %q failed: %s", d.url, failure)
+	}
+
+	return d.path, nil
+}
+
+// SaveAs waits for the download to finish and persists the downloaded
+// file at the given path with the file persister.
+func (d *Download) SaveAs(path string, fp ScreenshotPersister) error {
+	src, err := d.Path()
+	if err != nil {
+		return fmt.Errorf("saving download: %w", err)
+	}
+	f, err := os.Open(src) //nolint:forbidigo
+	if err != nil {
+		return fmt.Errorf("saving download: %w", err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	if err := fp.Persist(d.ctx, path, f); err != nil {
+		return fmt.Errorf("saving download to %q: %w", path, err)
+	}
+
+	return nil
+}
+
+// Failure waits for the download to finish and returns its failure,
+// or an empty string if it succeeded.
+func (d *Download) Failure() (string, error) {
+	select {
+	case <-d.done:
+	case <-d.ctx.Done():
+		return "", fmt.Errorf("waiting for download of %q: %w", d.url, d.ctx.Err())
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.failure, nil
+}
+
+// Cancel cancels the download. It does nothing if the download has
+// already finished.
+func (d *Download) Cancel() error {
+	select {
+	case <-d.done:
+		return nil
+	default:
+	}
+
+	action := cdpbrowser.CancelDownload(d.guid)
+	if d.browserCtx.id != "" {
+		action = action.WithBrowserContextID(d.browserCtx.id)
+	}
+	if err := action.Do(cdp.WithExecutor(d.ctx, d.browserCtx.browser.conn)); err != nil {
+		return fmt.Errorf("canceling download of %q: %w", d.url, err)
+	}
+
+	return nil
+}
+
+// onProgress updates the download with a download progress event,
+// and finishes it when it completes or is canceled.
+func (d *Download) onProgress(ev *cdpbrowser.EventDownloadProgress) {
+	var failure string
+	switch ev.State {
+	case cdpbrowser.DownloadProgressStateCompleted:
+	case cdpbrowser.DownloadProgressStateCanceled:
+		failure = DownloadFailureCanceled
+	default:
+		return
+	}
+
+	d.doneOnce.Do(func() {
+		d.mu.Lock()
+		d.failure = failure
+		d.totalBytes = int64(ev.ReceivedBytes)
+		d.mu.Unlock()
+		close(d.done)
+
+		if failure == "" {
+			d.emitMetrics()
+		}
+	})
+}
+
+// emitMetrics emits the size and the duration of a finished download.
+func (d *Download) emitMetrics() {
+	pushCustomMetrics(d.ctx, d.page, d.url, func(cm *k6ext.CustomMetrics) []k6metrics.Sample {
+		return []k6metrics.Sample{
+			{
+				TimeSeries: k6metrics.TimeSeries{Metric: cm.BrowserDownloadDuration},
+				Value:      k6metrics.D(time.Since(d.started)),
+			},
+			{
+				TimeSeries: k6metrics.TimeSeries{Metric: cm.BrowserDownloadSize},
+				Value:      float64(d.totalBytes),
+			},
+		}
+	})
+}
+
+// enableDownloads lets the pages of the browser context download files
+// into its downloads path and report the downloads.
+func (b *BrowserContext) enableDownloads() error {
+	path, err := filepath.Abs(b.DownloadsPath)
+	if err != nil {
+		return fmt.Errorf("resolving downloads path: %w", err)
+	}
+	if err := os.MkdirAll(path, 0o755); err != nil { //nolint:forbidigo,gosec
+		return fmt.Errorf("creating downloads path: %w", err)
+	}
+	b.DownloadsPath = path
+
+	action := cdpbrowser.
+		SetDownloadBehavior(cdpbrowser.SetDownloadBehaviorBehaviorAllowAndName).
+		WithDownloadPath(path).
+		WithEventsEnabled(true)
+	if b.id != "" {
+		action = action.WithBrowserContextID(b.id)
+	}
+	if err := action.Do(cdp.WithExecutor(b.ctx, b.browser.conn)); err != nil {
+		return fmt.Errorf("enabling downloads: %w", err)
+	}
+
+	return nil
+}
+
+// onDownloadWillBegin starts a download for the page that owns
+// the frame of the event.
+func (b *Browser) onDownloadWillBegin(ev *cdpbrowser.EventDownloadWillBegin) {
+	var page *Page
+	for _, p := range b.getPages() {
+		if _, ok := p.frameManager.getFrameByID(ev.FrameID); ok {
+			page = p
+			break
+		}
+	}
+	if page == nil {
+		b.logger.Debugf("Browser:onDownloadWillBegin", "no page for frame:%v guid:%v", ev.FrameID, ev.GUID)
+		return
+	}
+
+	d := NewDownload(page.ctx, page, ev.GUID, ev.URL, ev.SuggestedFilename)
+	b.downloadsMu.Lock()
+	b.downloads[ev.GUID] = d
+	b.downloadsMu.Unlock()
+
+	page.onDownload(d)
+}
+
+// onDownloadProgress updates the download of the event.
+func (b *Browser) onDownloadProgress(ev *cdpbrowser.EventDownloadProgress) {
+	b.downloadsMu.Lock()
+	d, ok := b.downloads[ev.GUID]
+	if ok && ev.State != cdpbrowser.DownloadProgressStateInProgress {
+		delete(b.downloads, ev.GUID)
+	}
+	b.downloadsMu.Unlock()
+	if !ok {
+		b.logger.Debugf("Browser:onDownloadProgress", "unknown download guid:%v", ev.GUID)
+		return
+	}
+
+	d.onProgress(ev)
+}
@@ -287,6 +287,31 @@ func (h *ElementHandle) fill(_ context.Context, value string) error {
 	return nil
 }
 
+func (h *ElementHandle) blur(apiCtx context.Context) error {
+	fn := `
+		(node, injected) => {
+			return injected.blurNode(node);
+		}
+	`
+	opts := evalOptions{
+		forceCallable: true,
+		returnByValue: true,
+	}
+	result, err := h.evalWithScript(apiCtx, opts, fn)
+	if err != nil {
+		return err
+	}
+	s, ok := result.(string)
+	if !ok {
+		return fmt.Errorf("unexpected type %T", result)
+	}
+	if s != resultDone {
+		return errorFromDOMError(s)
+	}
+
+	return nil
+}
+
 func (h *ElementHandle) focus(apiCtx context.Context, resetSelectionIfNotFocused bool) error {
 	fn := `
 		(node, injected, resetSelectionIfNotFocused) => {
@@ -672,18 +697,7 @@ func (h *ElementHandle) waitAndScrollIntoViewIfNeeded(
 	_ context.Context, force, noWaitAfter bool, timeout time.Duration,
 ) error {
 	fn := func(apiCtx context.Context, _ *ElementHandle) (any, error) {
-		fn := `
-			(element) => {
-				element.scrollIntoViewIfNeeded(true);
-				return [window.scrollX, window.scrollY];
-			}
-		`
-		opts := evalOptions{
-			forceCallable: true,
-			returnByValue: true,
-		}
-
-		return h.eval(apiCtx, opts, fn)
+		return h.scrollIntoViewIfNeeded(apiCtx)
 	}
 	actFn := h.newAction([]string{"visible", "stable"}, fn, force, noWaitAfter, timeout)
 	_, err := call(h.ctx, actFn, timeout)
@@ -691,6 +705,21 @@ func (h *ElementHandle) waitAndScrollIntoViewIfNeeded(
 	return err
 }
 
+func (h *ElementHandle) scrollIntoViewIfNeeded(apiCtx context.Context) (any, error) {
+	fn := `
+		(element) => {
+			element.scrollIntoViewIfNeeded(true);
+			return [window.scrollX, window.scrollY];
+		}
+	`
+	opts := evalOptions{
+		forceCallable: true,
+		returnByValue: true,
+	}
+
+	return h.eval(apiCtx, opts, fn)
+}
+
 func (h *ElementHandle) waitForElementState(
 	apiCtx context.Context, states []string, timeout time.Duration,
 ) (bool, error) {
@@ -724,7 +753,7 @@ func (h *ElementHandle) waitForElementState(
 func (h *ElementHandle) waitForSelector(
 	apiCtx context.Context, selector string, opts *FrameWaitForSelectorOptions,
 ) (*ElementHandle, error) {
-	parsedSelector, err := NewSelector(selector)
+	parsedSelector, err := h.frame.parseSelector(selector)
 	if err != nil {
 		return nil, err
 	}
@@ -740,7 +769,7 @@ func (h *ElementHandle) waitForSelector(
 	result, err := h.evalWithScript(
 		apiCtx,
 		eopts, fn, parsedSelector,
-		opts.Strict, opts.State.String(), opts.Timeout.Milliseconds(),
+		h.frame.isStrict(opts.Strict, opts.strictSet), opts.State.String(), opts.Timeout.Milliseconds(),
 	)
 	if err != nil {
 		return nil, err
@@ -1149,7 +1178,7 @@ func (h *ElementHandle) Press(key string, opts sobek.Value) error {
 // Query runs "element.querySelector" within the page. If no element matches the selector,
 // the return value resolves to "null".
 func (h *ElementHandle) Query(selector string, strict bool) (_ *ElementHandle, rerr error) {
-	parsedSelector, err := NewSelector(selector)
+	parsedSelector, err := h.frame.parseSelector(selector)
 	if err != nil {
 		return nil, fmt.Errorf("parsing selector %q: %w", selector, err)
 	}
@@ -1199,7 +1228,7 @@ func (h *ElementHandle) QueryAll(selector string) ([]*ElementHandle, error) {
 }
 
 func (h *ElementHandle) queryAll(selector string, eval evalFunc) (_ []*ElementHandle, rerr error) {
-	parsedSelector, err := NewSelector(selector)
+	parsedSelector, err := h.frame.parseSelector(selector)
 	if err != nil {
 		return nil, fmt.Errorf("parsing selector %q: %w", selector, err)
 	}
@@ -1308,16 +1337,23 @@ func (h *ElementHandle) Screenshot(
 	opts *ElementHandleScreenshotOptions,
 	sp ScreenshotPersister,
 ) ([]byte, error) {
-	spanCtx, span := TraceAPICall(
+	spanCtx, span := tracePageAPICall(
 		h.ctx,
-		h.frame.page.targetID.String(),
+		h.frame.page,
 		"elementHandle.screenshot",
 	)
 	defer span.End()
 
 	span.SetAttributes(attribute.String("screenshot.path", opts.Path))
 
-	s := newScreenshotter(spanCtx, sp, h.logger)
+	ctx := spanCtx
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(spanCtx, opts.Timeout)
+		defer cancel()
+	}
+
+	s := newScreenshotter(ctx, sp, h.logger)
 	buf, err := s.screenshotElement(h, opts)
 	if err != nil {
 		err := fmt.Errorf("taking screenshot of elementHandle: %w", err)
@@ -1442,6 +1478,32 @@ func (h *ElementHandle) setInputFiles(apiCtx context.Context, payload []*File) e
 	return nil
 }
 
+// dropFiles dispatches the drag events of dropping the files onto the element.
+func (h *ElementHandle) dropFiles(apiCtx context.Context, payload []*File) error {
+	fn := `
+		(node, injected, payload) => {
+			return injected.dropFiles(node, payload);
+		}
+	`
+	evalOpts := evalOptions{
+		forceCallable: true,
+		returnByValue: true,
+	}
+	result, err := h.evalWithScript(apiCtx, evalOpts, fn, payload)
+	if err != nil {
+		return err
+	}
+	v, ok := result.(string)
+	if !ok {
+		return fmt.Errorf("unexpected type %T", result)
+	}
+	if v != "done" {
+		return errorFromDOMError(v)
+	}
+
+	return nil
+}
+
 // Tap scrolls element into view and taps in the center of the element.
 func (h *ElementHandle) Tap(opts *ElementHandleTapOptions) error {
 	tap := func(apiCtx context.Context, handle *ElementHandle, p *Position) (any, error) {
@@ -1763,6 +1825,9 @@ func errorFromDOMError(v any) error {
 	if s := "error:expectednode:"; strings.HasPrefix(serr, s) {
 		return fmt.Errorf("expected node but got %s", strings.TrimPrefix(serr, s))
 	}
+	if s := "error:strictmodeviolation: "; strings.HasPrefix(serr, s) {
+		return fmt.Errorf("strict mode violation, %s", strings.TrimPrefix(serr, s))
+	}
 	errs := map[string]string{
 		"error:notconnected":           "element is not attached to the DOM",
 		"error:notelement":             "node is not an element",
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"reflect"
-	"strings"
 	"time"
 
 	"github.com/grafana/sobek"
@@ -107,6 +106,7 @@ type ElementHandlePressOptions struct {
 }
 
 type ElementHandleScreenshotOptions struct {
+	ScreenshotBaseOptions
 	Path           string        `json:"path"`
 	Format         ImageFormat   `json:"format"`
 	OmitBackground bool          `json:"omitBackground"`
@@ -425,11 +425,12 @@ func (o *ElementHandlePressOptions) ToBaseOptions() *ElementHandleBaseOptions {
 
 func NewElementHandleScreenshotOptions(defaultTimeout time.Duration) *ElementHandleScreenshotOptions {
 	return &ElementHandleScreenshotOptions{
-		Path:           "",
-		Format:         ImageFormatPNG,
-		OmitBackground: false,
-		Quality:        100,
-		Timeout:        defaultTimeout,
+		ScreenshotBaseOptions: *NewScreenshotBaseOptions(),
+		Path:                  "",
+		Format:                ImageFormatPNG,
+		OmitBackground:        false,
+		Quality:               100,
+		Timeout:               defaultTimeout,
 	}
 }
 
@@ -438,6 +439,9 @@ func (o *ElementHandleScreenshotOptions) Parse(ctx context.Context, opts sobek.V
 	if !sobekValueExists(opts) {
 		return nil
 	}
+	if err := o.ScreenshotBaseOptions.Parse(ctx, opts); err != nil {
+		return err
+	}
 
 	rt := k6ext.Runtime(ctx)
 	formatSpecified := false
@@ -461,10 +465,8 @@ func (o *ElementHandleScreenshotOptions) Parse(ctx context.Context, opts sobek.V
 	}
 
 	// Infer file format by path if format not explicitly specified (default is PNG)
-	if o.Path != "" && !formatSpecified {
-		if strings.HasSuffix(o.Path, ".jpg") || strings.HasSuffix(o.Path, ".jpeg") {
-			o.Format = ImageFormatJPEG
-		}
+	if f, ok := imageFormatFromPath(o.Path); ok && !formatSpecified {
+		o.Format = f
 	}
 
 	return nil
@@ -22,6 +22,10 @@ func TestErrorFromDOMError(t *testing.T) {
 		{in: "timed out", want: ErrTimedOut, sentinel: true},
 		{in: "error:notconnected", want: errors.New("element is not attached to the DOM")},
 		{in: "error:expectednode:anything", want: errors.New("expected node but got anything")},
+		{
+			in:   "error:strictmodeviolation: selector \"div\" resolved to 2 elements:",
+			want: errors.New("strict mode violation, selector \"div\" resolved to 2 elements:"),
+		},
 		{in: "nonexistent error", want: errors.New("nonexistent error")},
 	} {
 		got := errorFromDOMError(tc.in)
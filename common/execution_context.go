@@ -268,8 +268,11 @@ func (e *ExecutionContext) getInjectedScript(apiCtx context.Context) (JSHandleAP
 	e.isMutex.RUnlock()
 
 	var (
-		suffix                  = `//# sourceURL=` + evaluationScriptURL
-		source                  = fmt.Sprintf(`(() => {%s; return new InjectedScript();})()`, injectedScriptSource)
+		suffix = `//# sourceURL=` + evaluationScriptURL
+		source = fmt.Sprintf(
+			`(() => {%s; return new InjectedScript(%s);})()`,
+			injectedScriptSource, e.frame.selectors().injectedScriptEngines(),
+		)
 		expression              = source
 		expressionWithSourceURL = expression
 	)
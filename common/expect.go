@@ -0,0 +1,473 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/grafana/sobek"
+	k6metrics "go.k6.io/k6/metrics"
+
+	"github.com/grafana/xk6-browser/k6ext"
+)
+
+// DefaultExpectTimeout is the default time the expect assertions
+// retry until their condition is met.
+const DefaultExpectTimeout = 5 * time.Second
+
+// expectPollIntervals are the intervals between the attempts of an
+// assertion. The last interval repeats until the assertion times out.
+var expectPollIntervals = []time.Duration{ //nolint:gochecknoglobals
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	1000 * time.Millisecond,
+}
+
+// ErrExpectationFailed is returned when an expect assertion fails.
+var ErrExpectationFailed = errors.New("expectation failed")
+
+// ExpectOptions are options for the expect assertions.
+type ExpectOptions struct {
+	// Message names the assertion in its check and error message.
+	Message string `json:"message"`
+	// Timeout is the time to retry the assertion until its
+	// condition is met.
+	Timeout time.Duration `json:"timeout"`
+	// Soft makes a failed assertion record a failed check and
+	// let the iteration continue, instead of returning an error.
+	Soft bool `json:"soft"`
+	// Not negates the assertion.
+	Not bool `json:"not"`
+}
+
+// NewExpectOptions returns a new ExpectOptions.
+func NewExpectOptions() *ExpectOptions {
+	return &ExpectOptions{
+		Timeout: DefaultExpectTimeout,
+	}
+}
+
+// Parse parses the expect options. The options can be a message
+// string, or an object with the message, timeout and soft fields.
+func (o *ExpectOptions) Parse(ctx context.Context, opts sobek.Value) error {
+	if !sobekValueExists(opts) {
+		return nil
+	}
+	if _, ok := opts.Export().(string); ok {
+		o.Message = opts.String()
+		return nil
+	}
+
+	rt := k6ext.Runtime(ctx)
+	obj := opts.ToObject(rt)
+	for _, k := range obj.Keys() {
+		switch k {
+		case "message":
+			o.Message = obj.Get(k).String()
+		case "timeout":
+			o.Timeout = time.Duration(obj.Get(k).ToInteger()) * time.Millisecond
+		case "soft":
+			o.Soft = obj.Get(k).ToBoolean()
+		}
+	}
+
+	return nil
+}
+
+// WithMatcherOptions returns a copy of the options that applies the
+// options of a single matcher, such as its timeout.
+func (o *ExpectOptions) WithMatcherOptions(ctx context.Context, opts sobek.Value) *ExpectOptions {
+	c := *o
+	if !sobekValueExists(opts) {
+		return &c
+	}
+	rt := k6ext.Runtime(ctx)
+	obj := opts.ToObject(rt)
+	for _, k := range obj.Keys() {
+		if k == "timeout" {
+			c.Timeout = time.Duration(obj.Get(k).ToInteger()) * time.Millisecond
+		}
+	}
+
+	return &c
+}
+
+// StringMatcher matches a string exactly or with a regular expression.
+type StringMatcher struct {
+	value   string
+	pattern *regexp.Regexp
+}
+
+// NewStringMatcher returns a StringMatcher that matches the given
+// string exactly.
+func NewStringMatcher(s string) *StringMatcher {
+	return &StringMatcher{value: s}
+}
+
+// NewRegExpMatcher returns a StringMatcher that matches the JavaScript
+// regular expression with the given source and flags. The i, m and s
+// flags are supported, and the rest of the flags are ignored.
+func NewRegExpMatcher(source, flags string) (*StringMatcher, error) {
+	var goFlags string
+	for _, f := range flags {
+		if strings.ContainsRune("ims", f) {
+			goFlags += string(f)
+		}
+	}
+	if goFlags != "" {
+		source = "(?" + goFlags + ")" + source
+	}
+	re, err := regexp.Compile(source)
+	if err != nil {
+		return nil, fmt.Errorf("compiling regular expression: %w", err)
+	}
+
+	return &StringMatcher{pattern: re}, nil
+}
+
+// ParseStringMatcher parses a JavaScript string or RegExp into a StringMatcher.
+func ParseStringMatcher(v sobek.Value) (*StringMatcher, error) {
+	if !sobekValueExists(v) {
+		return nil, errors.New("expected a string or a regular expression")
+	}
+	if obj, ok := v.(*sobek.Object); ok && obj.ClassName() == "RegExp" {
+		return NewRegExpMatcher(obj.Get("source").String(), obj.Get("flags").String())
+	}
+
+	return NewStringMatcher(v.String()), nil
+}
+
+// Match reports whether the string matches.
+func (m *StringMatcher) Match(s string) bool {
+	if m.pattern != nil {
+		return m.pattern.MatchString(s)
+	}
+	return m.value == s
+}
+
+// String returns the matched string or pattern for error messages.
+func (m *StringMatcher) String() string {
+	if m.pattern != nil {
+		return "/" + m.pattern.String() + "/"
+	}
+	return fmt.Sprintf("%q", m.value)
+}
+
+// normalized returns a matcher that matches the string with its
+// whitespace normalized. Patterns are returned as is.
+func (m *StringMatcher) normalized() *StringMatcher {
+	if m.pattern != nil {
+		return m
+	}
+	return &StringMatcher{value: normalizeWhiteSpace(m.value)}
+}
+
+func normalizeWhiteSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// assertion retries a condition until it is met or times out, and
+// reports the result as a k6 check with the tags that were current
+// when the assertion was created.
+type assertion struct {
+	ctx    context.Context
+	target string
+	tags   k6metrics.TagsAndMeta
+}
+
+func newAssertion(ctx context.Context, target string) assertion {
+	a := assertion{
+		ctx:    ctx,
+		target: target,
+	}
+	if vu := k6ext.GetVU(ctx); vu != nil && vu.State() != nil {
+		a.tags = vu.State().Tags.GetCurrentValues()
+	}
+
+	return a
+}
+
+// probeFunc checks the condition of an assertion once. It returns
+// whether the condition is met and the actual value for error messages.
+// An error is retried like an unmet condition, and is reported if it's
+// still returned when the assertion times out, unless it's a
+// [finalProbeError]. The timeout is the
+// remaining time of the assertion.
+type probeFunc func(timeout time.Duration) (ok bool, actual any, err error)
+
+// check runs the assertion with the given matcher name and expected
+// value. It returns an error if the assertion fails, unless it's soft.
+func (a assertion) check(opts *ExpectOptions, matcher string, expected any, probe probeFunc) error {
+	name := a.name(opts, matcher, expected)
+	actual, err := a.poll(opts, probe)
+	a.record(name, err == nil)
+	if err == nil {
+		return nil
+	}
+
+	err = fmt.Errorf("%w: %s: %w", ErrExpectationFailed, name, err)
+	if actual != nil {
+		err = fmt.Errorf("%w, got %v", err, actual)
+	}
+	if opts.Soft {
+		if vu := k6ext.GetVU(a.ctx); vu != nil && vu.State() != nil {
+			vu.State().Logger.Warn(err.Error())
+		}
+		return nil
+	}
+
+	return err
+}
+
+func (a assertion) poll(opts *ExpectOptions, probe probeFunc) (any, error) {
+	deadline := time.Now().Add(opts.Timeout)
+	for i := 0; ; i++ {
+		ok, actual, err := probe(max(time.Until(deadline), time.Millisecond))
+		if err == nil && ok != opts.Not {
+			return nil, nil
+		}
+		var ferr *finalProbeError
+		if errors.As(err, &ferr) {
+			return actual, ferr.err
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return actual, a.failure(opts, err)
+		}
+		interval := expectPollIntervals[min(i, len(expectPollIntervals)-1)]
+		if err := sleep(a.ctx, min(interval, remaining)); err != nil {
+			return actual, err
+		}
+	}
+}
+
+// finalProbeError is a probe error that stops retrying the assertion,
+// as retrying it can't change the outcome.
+type finalProbeError struct {
+	err error
+}
+
+func (e *finalProbeError) Error() string { return e.err.Error() }
+
+func (e *finalProbeError) Unwrap() error { return e.err }
+
+// failure returns the error of an assertion whose condition was not met
+// in time. The err is the error of the last probe, if any.
+func (a assertion) failure(opts *ExpectOptions, err error) error {
+	msg := "condition not met"
+	if opts.Timeout > 0 {
+		msg = fmt.Sprintf("timed out after %s", opts.Timeout)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return errors.New(msg)
+}
+
+// name returns the name of the assertion's check.
+func (a assertion) name(opts *ExpectOptions, matcher string, expected any) string {
+	if opts.Message != "" {
+		return opts.Message
+	}
+	not := ""
+	if opts.Not {
+		not = ".not"
+	}
+	args := ""
+	if expected != nil {
+		args = fmt.Sprint(expected)
+	}
+
+	return fmt.Sprintf("expect(%s)%s.%s(%s)", a.target, not, matcher, args)
+}
+
+func (a assertion) record(name string, pass bool) {
+	vu := k6ext.GetVU(a.ctx)
+	if vu == nil || vu.State() == nil {
+		return
+	}
+	state := vu.State()
+	tags := a.tags.Tags
+	if state.Options.SystemTags.Has(k6metrics.TagCheck) {
+		tags = tags.With("check", name)
+	}
+	sample := k6metrics.Sample{
+		TimeSeries: k6metrics.TimeSeries{
+			Metric: state.BuiltinMetrics.Checks,
+			Tags:   tags,
+		},
+		Time:     time.Now(),
+		Metadata: a.tags.Metadata,
+	}
+	if pass {
+		sample.Value = 1
+	}
+	k6metrics.PushIfNotDone(a.ctx, state.Samples, sample)
+}
+
+// LocatorAssertions are the expect assertions about a locator.
+type LocatorAssertions struct {
+	assertion
+	locator *Locator
+}
+
+// NewLocatorAssertions returns the expect assertions about the locator.
+func NewLocatorAssertions(ctx context.Context, l *Locator) *LocatorAssertions {
+	return &LocatorAssertions{
+		assertion: newAssertion(ctx, fmt.Sprintf("locator(%q)", l.selector)),
+		locator:   l,
+	}
+}
+
+// ToBeVisible asserts that the locator's element is visible.
+func (a *LocatorAssertions) ToBeVisible(opts *ExpectOptions) error {
+	return a.check(opts, "toBeVisible", nil, func(time.Duration) (bool, any, error) {
+		v, err := a.locator.IsVisible()
+		return v, nil, err
+	})
+}
+
+// ToBeHidden asserts that the locator's element is hidden or missing.
+func (a *LocatorAssertions) ToBeHidden(opts *ExpectOptions) error {
+	return a.check(opts, "toBeHidden", nil, func(time.Duration) (bool, any, error) {
+		v, err := a.locator.IsHidden()
+		return v, nil, err
+	})
+}
+
+// ToHaveCount asserts that the locator matches the given number of elements.
+func (a *LocatorAssertions) ToHaveCount(count int, opts *ExpectOptions) error {
+	return a.check(opts, "toHaveCount", count, func(time.Duration) (bool, any, error) {
+		n, err := a.locator.count()
+		return n == count, n, err
+	})
+}
+
+// ToHaveText asserts that the text content of the locator's element
+// matches. The whitespace of the text is normalized before matching.
+func (a *LocatorAssertions) ToHaveText(m *StringMatcher, opts *ExpectOptions) error {
+	nm := m.normalized()
+	return a.check(opts, "toHaveText", m, func(timeout time.Duration) (bool, any, error) {
+		found, err := a.exists()
+		if err != nil || !found {
+			return false, nil, err
+		}
+		topts := NewFrameTextContentOptions(timeout)
+		s, _, err := a.locator.textContent(topts)
+		if err != nil {
+			return false, nil, err
+		}
+		s = normalizeWhiteSpace(s)
+		return nm.Match(s), fmt.Sprintf("%q", s), nil
+	})
+}
+
+// ToHaveValue asserts that the input value of the locator's element matches.
+func (a *LocatorAssertions) ToHaveValue(m *StringMatcher, opts *ExpectOptions) error {
+	return a.check(opts, "toHaveValue", m, func(timeout time.Duration) (bool, any, error) {
+		found, err := a.exists()
+		if err != nil || !found {
+			return false, nil, err
+		}
+		s, err := a.locator.inputValue(NewFrameInputValueOptions(timeout))
+		if err != nil {
+			return false, nil, err
+		}
+		return m.Match(s), fmt.Sprintf("%q", s), nil
+	})
+}
+
+// ToHaveAttribute asserts that the locator's element has the attribute.
+// If the matcher is not nil, the attribute's value must also match.
+func (a *LocatorAssertions) ToHaveAttribute(name string, m *StringMatcher, opts *ExpectOptions) error {
+	var expected any = fmt.Sprintf("%q", name)
+	if m != nil {
+		expected = fmt.Sprintf("%q, %s", name, m)
+	}
+	return a.check(opts, "toHaveAttribute", expected, func(timeout time.Duration) (bool, any, error) {
+		found, err := a.exists()
+		if err != nil || !found {
+			return false, nil, err
+		}
+		s, ok, err := a.locator.getAttribute(name, NewFrameBaseOptions(timeout))
+		if err != nil || !ok {
+			return false, nil, err
+		}
+		if m == nil {
+			return true, nil, nil
+		}
+		return m.Match(s), fmt.Sprintf("%q", s), nil
+	})
+}
+
+// exists reports whether the locator matches any elements, without
+// waiting for them, so that missing elements are retried.
+func (a *LocatorAssertions) exists() (bool, error) {
+	n, err := a.locator.count()
+	return n > 0, err
+}
+
+// PageAssertions are the expect assertions about a page.
+type PageAssertions struct {
+	assertion
+	page *Page
+}
+
+// NewPageAssertions returns the expect assertions about the page.
+func NewPageAssertions(ctx context.Context, p *Page) *PageAssertions {
+	return &PageAssertions{
+		assertion: newAssertion(ctx, "page"),
+		page:      p,
+	}
+}
+
+// ToHaveURL asserts that the page's URL matches.
+func (a *PageAssertions) ToHaveURL(m *StringMatcher, opts *ExpectOptions) error {
+	return a.check(opts, "toHaveURL", m, func(time.Duration) (bool, any, error) {
+		s, err := a.page.URL()
+		if err != nil {
+			return false, nil, err
+		}
+		return m.Match(s), fmt.Sprintf("%q", s), nil
+	})
+}
+
+// ToHaveTitle asserts that the page's title matches.
+func (a *PageAssertions) ToHaveTitle(m *StringMatcher, opts *ExpectOptions) error {
+	return a.check(opts, "toHaveTitle", m, func(time.Duration) (bool, any, error) {
+		s, err := a.page.Title()
+		if err != nil {
+			return false, nil, err
+		}
+		return m.Match(s), fmt.Sprintf("%q", s), nil
+	})
+}
+
+// ResponseAssertions are the expect assertions about a response.
+type ResponseAssertions struct {
+	assertion
+	response *Response
+}
+
+// NewResponseAssertions returns the expect assertions about the response.
+func NewResponseAssertions(ctx context.Context, r *Response) *ResponseAssertions {
+	return &ResponseAssertions{
+		assertion: newAssertion(ctx, fmt.Sprintf("response(%q)", r.URL())),
+		response:  r,
+	}
+}
+
+// ToBeOK asserts that the response's status is in the 200-299 range.
+// A response doesn't change, so the assertion doesn't retry.
+func (a *ResponseAssertions) ToBeOK(opts *ExpectOptions) error {
+	o := *opts
+	o.Timeout = 0
+	return a.check(&o, "toBeOK", nil, func(time.Duration) (bool, any, error) {
+		return a.response.Ok(), a.response.Status(), nil
+	})
+}
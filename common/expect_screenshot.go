@@ -0,0 +1,344 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/grafana/sobek"
+
+	"github.com/grafana/xk6-browser/k6ext"
+	"github.com/grafana/xk6-browser/storage"
+)
+
+// DefaultSnapshotsDir is the default directory of the baseline
+// screenshots of the toHaveScreenshot assertions.
+const DefaultSnapshotsDir = "__screenshots__"
+
+// ScreenshotAssertionOptions are the options of the toHaveScreenshot
+// assertions. The screenshots are deterministic by default: animations
+// are disabled, the caret is hidden, and they are in CSS pixels.
+type ScreenshotAssertionOptions struct {
+	ScreenshotBaseOptions
+	FullPage       bool `json:"fullPage"`
+	OmitBackground bool `json:"omitBackground"`
+	// MaxDiffPixels is the number of pixels that can differ.
+	MaxDiffPixels int64 `json:"maxDiffPixels"`
+	// MaxDiffPixelRatio is the ratio of the pixels that can differ,
+	// between 0 and 1.
+	MaxDiffPixelRatio float64 `json:"maxDiffPixelRatio"`
+	// Threshold is the perceived color difference between 0 and 1
+	// above which two pixels are different.
+	Threshold float64 `json:"threshold"`
+
+	// Dir is the directory of the baseline screenshots.
+	Dir string `json:"-"`
+	// Update writes the screenshots as the new baselines
+	// instead of comparing them with the baselines.
+	Update bool `json:"-"`
+}
+
+// NewScreenshotAssertionOptions returns the default screenshot assertion options.
+func NewScreenshotAssertionOptions() *ScreenshotAssertionOptions {
+	base := NewScreenshotBaseOptions()
+	base.Animations = ScreenshotAnimationsDisabled
+	base.Scale = ScreenshotScaleCSS
+
+	return &ScreenshotAssertionOptions{
+		ScreenshotBaseOptions: *base,
+		Threshold:             0.2,
+		Dir:                   DefaultSnapshotsDir,
+	}
+}
+
+// Parse parses the screenshot assertion options.
+func (o *ScreenshotAssertionOptions) Parse(ctx context.Context, opts sobek.Value) error {
+	if !sobekValueExists(opts) {
+		return nil
+	}
+	if err := o.ScreenshotBaseOptions.Parse(ctx, opts); err != nil {
+		return err
+	}
+
+	rt := k6ext.Runtime(ctx)
+	obj := opts.ToObject(rt)
+	for _, k := range obj.Keys() {
+		v := obj.Get(k)
+		switch k {
+		case "fullPage":
+			o.FullPage = v.ToBoolean()
+		case "maxDiffPixels":
+			if o.MaxDiffPixels = v.ToInteger(); o.MaxDiffPixels < 0 {
+				return fmt.Errorf("maxDiffPixels must be positive, got %d", o.MaxDiffPixels)
+			}
+		case "maxDiffPixelRatio":
+			if o.MaxDiffPixelRatio = v.ToFloat(); o.MaxDiffPixelRatio < 0 || o.MaxDiffPixelRatio > 1 {
+				return fmt.Errorf("maxDiffPixelRatio must be between 0 and 1, got %v", o.MaxDiffPixelRatio)
+			}
+		case "omitBackground":
+			o.OmitBackground = v.ToBoolean()
+		case "threshold":
+			if o.Threshold = v.ToFloat(); o.Threshold < 0 || o.Threshold > 1 {
+				return fmt.Errorf("threshold must be between 0 and 1, got %v", o.Threshold)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ToHaveScreenshot asserts that a screenshot of the page matches
+// the baseline screenshot with the given name.
+func (a *PageAssertions) ToHaveScreenshot(
+	name string, sopts *ScreenshotAssertionOptions, sp ScreenshotPersister, opts *ExpectOptions,
+) error {
+	return a.toHaveScreenshot(name, sopts, sp, opts, func(timeout time.Duration) ([]byte, error) {
+		popts := NewPageScreenshotOptions(timeout)
+		popts.ScreenshotBaseOptions = sopts.ScreenshotBaseOptions
+		popts.FullPage = sopts.FullPage
+		popts.OmitBackground = sopts.OmitBackground
+		return a.page.Screenshot(popts, sp)
+	})
+}
+
+// ToHaveScreenshot asserts that a screenshot of the locator's element
+// matches the baseline screenshot with the given name.
+func (a *LocatorAssertions) ToHaveScreenshot(
+	name string, sopts *ScreenshotAssertionOptions, sp ScreenshotPersister, opts *ExpectOptions,
+) error {
+	return a.toHaveScreenshot(name, sopts, sp, opts, func(timeout time.Duration) ([]byte, error) {
+		eopts := NewElementHandleScreenshotOptions(timeout)
+		eopts.ScreenshotBaseOptions = sopts.ScreenshotBaseOptions
+		eopts.OmitBackground = sopts.OmitBackground
+		return a.locator.Screenshot(eopts, sp)
+	})
+}
+
+// baselinePersister writes the baseline screenshots in the update mode.
+// They are always on the local disk, since they are read from there.
+var baselinePersister = &storage.LocalFilePersister{} //nolint:gochecknoglobals
+
+// screenshotComparison is the result of comparing a screenshot with its baseline.
+type screenshotComparison struct {
+	actual     []byte
+	diff       image.Image
+	diffPixels int
+	message    string
+}
+
+// toHaveScreenshot compares the screenshots that capture takes with the
+// baseline until they match or the assertion times out. The last screenshot
+// and the image of its differences are persisted with sp next to the
+// baseline if they don't match. A missing baseline is written from the
+// screenshot, and fails the assertion. Only one VU writes it, and the
+// others compare their screenshots with it. In the update mode, the
+// screenshot is written as the baseline and the assertion passes. The
+// screenshots are in the PNG format, so the name must have the .png
+// extension, or none.
+func (a assertion) toHaveScreenshot(
+	name string, sopts *ScreenshotAssertionOptions, sp ScreenshotPersister, opts *ExpectOptions,
+	capture func(timeout time.Duration) ([]byte, error),
+) error {
+	if opts.Not {
+		return errors.New("toHaveScreenshot can't be negated")
+	}
+	if name == "" {
+		return errors.New("toHaveScreenshot requires the name of the screenshot")
+	}
+	switch ext := filepath.Ext(name); {
+	case ext == "":
+		name += ".png"
+	case !strings.EqualFold(ext, ".png"):
+		return fmt.Errorf("toHaveScreenshot only supports PNG screenshots, got %q", name)
+	}
+	path := filepath.Join(sopts.Dir, name)
+
+	baseline, err := os.ReadFile(path) //nolint:forbidigo
+	missing := errors.Is(err, fs.ErrNotExist)
+	if err != nil && !missing {
+		return fmt.Errorf("reading baseline screenshot: %w", err)
+	}
+
+	var last *screenshotComparison
+	err = a.check(opts, "toHaveScreenshot", fmt.Sprintf("%q", name), func(timeout time.Duration) (bool, any, error) {
+		actual, err := capture(timeout)
+		if err != nil {
+			return false, nil, err
+		}
+		if sopts.Update {
+			if err := baselinePersister.Persist(a.ctx, path, bytes.NewReader(actual)); err != nil {
+				return false, nil, &finalProbeError{fmt.Errorf("writing baseline screenshot to %q: %w", path, err)}
+			}
+			return true, nil, nil
+		}
+		if missing {
+			created, err := createBaseline(path, actual)
+			if err != nil {
+				return false, nil, &finalProbeError{fmt.Errorf("writing baseline screenshot to %q: %w", path, err)}
+			}
+			if created {
+				return false, nil, &finalProbeError{fmt.Errorf("baseline %q is missing, wrote the screenshot as the baseline", path)}
+			}
+			// Another VU wrote the baseline in the meantime.
+			if baseline, err = os.ReadFile(path); err != nil { //nolint:forbidigo
+				return false, nil, &finalProbeError{fmt.Errorf("reading baseline screenshot: %w", err)}
+			}
+			missing = false
+		}
+		last, err = compareScreenshot(baseline, actual, sopts)
+		if err != nil {
+			return false, nil, err
+		}
+		return last.message == "", last.message, nil
+	})
+	if last == nil || last.message == "" {
+		return err
+	}
+
+	// The screenshot didn't match its baseline.
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+	if perr := sp.Persist(a.ctx, base+"-actual"+ext, bytes.NewReader(last.actual)); perr != nil {
+		return errors.Join(err, fmt.Errorf("writing actual screenshot: %w", perr))
+	}
+	if last.diff != nil {
+		var buf bytes.Buffer
+		if perr := png.Encode(&buf, last.diff); perr != nil {
+			return errors.Join(err, fmt.Errorf("encoding screenshot diff: %w", perr))
+		}
+		if perr := sp.Persist(a.ctx, base+"-diff"+ext, &buf); perr != nil {
+			return errors.Join(err, fmt.Errorf("writing screenshot diff: %w", perr))
+		}
+	}
+
+	return err
+}
+
+// createBaseline writes the baseline screenshot at path if it doesn't exist.
+// It returns false if the baseline already exists, so that only the first
+// of the VUs that miss it writes it.
+func createBaseline(path string, data []byte) (_ bool, err error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { //nolint:forbidigo,gosec
+		return false, fmt.Errorf("creating a local directory %q: %w", filepath.Dir(path), err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600) //nolint:forbidigo
+	if errors.Is(err, fs.ErrExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("creating a local file %q: %w", path, err)
+	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("closing the local file %q: %w", path, cerr)
+		}
+	}()
+	if _, err := f.Write(data); err != nil {
+		return false, fmt.Errorf("writing the local file %q: %w", path, err)
+	}
+
+	return true, nil
+}
+
+// compareScreenshot compares the actual screenshot with the baseline.
+// The message of the result describes the differences if there are
+// more than the options allow.
+func compareScreenshot(baseline, actual []byte, opts *ScreenshotAssertionOptions) (*screenshotComparison, error) {
+	want, _, err := image.Decode(bytes.NewReader(baseline))
+	if err != nil {
+		return nil, fmt.Errorf("decoding baseline screenshot: %w", err)
+	}
+	got, _, err := image.Decode(bytes.NewReader(actual))
+	if err != nil {
+		return nil, fmt.Errorf("decoding screenshot: %w", err)
+	}
+
+	c := &screenshotComparison{actual: actual}
+	wb, gb := want.Bounds(), got.Bounds()
+	if wb.Dx() != gb.Dx() || wb.Dy() != gb.Dy() {
+		c.message = fmt.Sprintf("expected an image of %dx%d pixels, got %dx%d pixels",
+			wb.Dx(), wb.Dy(), gb.Dx(), gb.Dy())
+		return c, nil
+	}
+
+	c.diff, c.diffPixels = diffImages(want, got, opts.Threshold)
+	total := wb.Dx() * wb.Dy()
+	allowed := max(float64(opts.MaxDiffPixels), opts.MaxDiffPixelRatio*float64(total))
+	if float64(c.diffPixels) > allowed {
+		c.message = fmt.Sprintf("%d pixels (ratio %.2f of all image pixels) are different",
+			c.diffPixels, float64(c.diffPixels)/float64(total))
+	}
+
+	return c, nil
+}
+
+// maxYIQDelta is the largest perceived difference between two colors.
+const maxYIQDelta = 35215
+
+// diffImages compares the images of the same size pixel by pixel, and
+// returns an image of their differences and the number of different pixels.
+// Two pixels are different if their perceived color difference in the YIQ
+// color space is above the threshold. The different pixels are red in the
+// image of the differences, and the rest is a faded copy of the first image.
+func diffImages(a, b image.Image, threshold float64) (*image.RGBA, int) {
+	var (
+		ab, bb = a.Bounds(), b.Bounds()
+		diff   = image.NewRGBA(image.Rect(0, 0, ab.Dx(), ab.Dy()))
+		limit  = maxYIQDelta * threshold * threshold
+		n      int
+	)
+	for y := 0; y < ab.Dy(); y++ {
+		for x := 0; x < ab.Dx(); x++ {
+			ca := a.At(ab.Min.X+x, ab.Min.Y+y)
+			cb := b.At(bb.Min.X+x, bb.Min.Y+y)
+			if colorDelta(ca, cb) > limit {
+				diff.Set(x, y, color.RGBA{R: 255, A: 255})
+				n++
+				continue
+			}
+			// Faded grayscale of the pixel.
+			r, g, bl := blendWhite(ca)
+			gray := uint8(255 + (rgbToY(r, g, bl)-255)*0.1)
+			diff.Set(x, y, color.RGBA{R: gray, G: gray, B: gray, A: 255})
+		}
+	}
+
+	return diff, n
+}
+
+// colorDelta returns the perceived difference of the colors.
+func colorDelta(a, b color.Color) float64 {
+	r1, g1, b1 := blendWhite(a)
+	r2, g2, b2 := blendWhite(b)
+	if r1 == r2 && g1 == g2 && b1 == b2 {
+		return 0
+	}
+	y := rgbToY(r1, g1, b1) - rgbToY(r2, g2, b2)
+	i := rgbToI(r1, g1, b1) - rgbToI(r2, g2, b2)
+	q := rgbToQ(r1, g1, b1) - rgbToQ(r2, g2, b2)
+
+	return 0.5053*y*y + 0.299*i*i + 0.1957*q*q
+}
+
+// blendWhite blends the color with a white background, and returns
+// its red, green and blue components between 0 and 255.
+func blendWhite(c color.Color) (float64, float64, float64) {
+	r, g, b, a := c.RGBA() // alpha-premultiplied, between 0 and 0xffff
+	white := float64(0xffff - a)
+	scale := func(v uint32) float64 { return (float64(v) + white) / 0xffff * 255 }
+
+	return scale(r), scale(g), scale(b)
+}
+
+func rgbToY(r, g, b float64) float64 { return r*0.29889531 + g*0.58662247 + b*0.11448223 }
+func rgbToI(r, g, b float64) float64 { return r*0.59597799 - g*0.27417610 - b*0.32180189 }
+func rgbToQ(r, g, b float64) float64 { return r*0.21147017 - g*0.52261711 + b*0.31114694 }
@@ -0,0 +1,207 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/xk6-browser/storage"
+)
+
+// dirPersister persists the files under its directory.
+type dirPersister struct {
+	dir string
+}
+
+func (p *dirPersister) Persist(ctx context.Context, path string, data io.Reader) error {
+	return (&storage.LocalFilePersister{}).Persist(ctx, filepath.Join(p.dir, path), data) //nolint:wrapcheck
+}
+
+// testImage returns a white PNG image of the given size,
+// with the given number of red pixels on its first row.
+func testImage(t *testing.T, width, height, red int) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.White)
+		}
+	}
+	for x := 0; x < red; x++ {
+		img.Set(x, 0, color.RGBA{R: 255, A: 255})
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+
+	return buf.Bytes()
+}
+
+func TestCompareScreenshot(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		actual  []byte
+		opts    func(*ScreenshotAssertionOptions)
+		wantMsg string
+		wantN   int
+	}{
+		{
+			name:   "same",
+			actual: testImage(t, 10, 10, 0),
+		},
+		{
+			name:    "different",
+			actual:  testImage(t, 10, 10, 5),
+			wantMsg: "5 pixels (ratio 0.05 of all image pixels) are different",
+			wantN:   5,
+		},
+		{
+			name:   "max_diff_pixels",
+			actual: testImage(t, 10, 10, 5),
+			opts:   func(o *ScreenshotAssertionOptions) { o.MaxDiffPixels = 5 },
+			wantN:  5,
+		},
+		{
+			name:   "max_diff_pixel_ratio",
+			actual: testImage(t, 10, 10, 5),
+			opts:   func(o *ScreenshotAssertionOptions) { o.MaxDiffPixelRatio = 0.1 },
+			wantN:  5,
+		},
+		{
+			name:   "threshold",
+			actual: testImage(t, 10, 10, 5),
+			opts:   func(o *ScreenshotAssertionOptions) { o.Threshold = 1 },
+		},
+		{
+			name:    "size",
+			actual:  testImage(t, 10, 12, 0),
+			wantMsg: "expected an image of 10x10 pixels, got 10x12 pixels",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := NewScreenshotAssertionOptions()
+			if tt.opts != nil {
+				tt.opts(opts)
+			}
+			c, err := compareScreenshot(testImage(t, 10, 10, 0), tt.actual, opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMsg, c.message)
+			assert.Equal(t, tt.wantN, c.diffPixels)
+		})
+	}
+}
+
+func TestAssertionToHaveScreenshot(t *testing.T) {
+	t.Parallel()
+
+	capture := func(img []byte) func(time.Duration) ([]byte, error) {
+		return func(time.Duration) ([]byte, error) { return img, nil }
+	}
+	newOpts := func(dir string) *ScreenshotAssertionOptions {
+		opts := NewScreenshotAssertionOptions()
+		opts.Dir = dir
+		return opts
+	}
+	eopts := &ExpectOptions{Timeout: 0}
+	a := newAssertion(context.Background(), "page")
+	fp := &storage.LocalFilePersister{}
+
+	t.Run("missing_baseline", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		err := a.toHaveScreenshot("home", newOpts(dir), fp, eopts, capture(testImage(t, 10, 10, 0)))
+		require.ErrorIs(t, err, ErrExpectationFailed)
+		require.ErrorContains(t, err, "is missing")
+		assert.FileExists(t, filepath.Join(dir, "home.png"))
+
+		// The written baseline is used by the next assertion.
+		err = a.toHaveScreenshot("home", newOpts(dir), fp, eopts, capture(testImage(t, 10, 10, 0)))
+		require.NoError(t, err)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		dir, out := t.TempDir(), t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "home.png"), testImage(t, 10, 10, 0), 0o600))
+		sp := &dirPersister{dir: out}
+		err := a.toHaveScreenshot("home.png", newOpts(dir), sp, eopts, capture(testImage(t, 10, 10, 3)))
+		require.ErrorIs(t, err, ErrExpectationFailed)
+		require.ErrorContains(t, err, "3 pixels")
+		assert.NoFileExists(t, filepath.Join(dir, "home-actual.png"))
+		assert.FileExists(t, filepath.Join(out, dir, "home-actual.png"))
+
+		b, err := os.ReadFile(filepath.Join(out, dir, "home-diff.png"))
+		require.NoError(t, err)
+		diff, err := png.Decode(bytes.NewReader(b))
+		require.NoError(t, err)
+		assert.Equal(t, color.RGBA{R: 255, A: 255}, color.RGBAModel.Convert(diff.At(0, 0)))
+		assert.NotEqual(t, color.RGBA{R: 255, A: 255}, color.RGBAModel.Convert(diff.At(5, 5)))
+	})
+
+	t.Run("missing_baseline_written_concurrently", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		baseline := testImage(t, 10, 10, 0)
+		created, err := createBaseline(filepath.Join(dir, "home.png"), baseline)
+		require.NoError(t, err)
+		require.True(t, created)
+
+		// The baseline of the first writer is kept.
+		created, err = createBaseline(filepath.Join(dir, "home.png"), testImage(t, 10, 10, 3))
+		require.NoError(t, err)
+		require.False(t, created)
+		b, err := os.ReadFile(filepath.Join(dir, "home.png"))
+		require.NoError(t, err)
+		assert.Equal(t, baseline, b)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "home.png"), testImage(t, 10, 10, 0), 0o600))
+		opts := newOpts(dir)
+		opts.Update = true
+		updated := testImage(t, 10, 10, 3)
+		require.NoError(t, a.toHaveScreenshot("home", opts, fp, eopts, capture(updated)))
+
+		b, err := os.ReadFile(filepath.Join(dir, "home.png"))
+		require.NoError(t, err)
+		assert.Equal(t, updated, b)
+	})
+
+	t.Run("not_png", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		err := a.toHaveScreenshot("home.jpeg", newOpts(dir), fp, eopts, capture(testImage(t, 10, 10, 0)))
+		require.ErrorContains(t, err, "only supports PNG")
+		assert.NoFileExists(t, filepath.Join(dir, "home.jpeg"))
+	})
+
+	t.Run("not", func(t *testing.T) {
+		t.Parallel()
+
+		err := a.toHaveScreenshot("home", newOpts(t.TempDir()), fp, &ExpectOptions{Not: true}, capture(nil))
+		require.ErrorContains(t, err, "can't be negated")
+	})
+}
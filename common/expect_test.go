@@ -0,0 +1,139 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringMatcher(t *testing.T) {
+	t.Parallel()
+
+	m := NewStringMatcher("Hello  world")
+	assert.True(t, m.Match("Hello  world"))
+	assert.False(t, m.Match("Hello world"))
+	assert.True(t, m.normalized().Match("Hello world"))
+	assert.Equal(t, `"Hello  world"`, m.String())
+
+	re, err := NewRegExpMatcher(`^hello\s+\w+$`, "gi")
+	require.NoError(t, err)
+	assert.True(t, re.Match("Hello World"))
+	assert.False(t, re.Match("Goodbye World"))
+	assert.Same(t, re, re.normalized())
+	assert.Equal(t, `/(?i)^hello\s+\w+$/`, re.String())
+
+	_, err = NewRegExpMatcher(`(`, "")
+	require.ErrorContains(t, err, "compiling regular expression")
+}
+
+func TestAssertionCheck(t *testing.T) {
+	t.Parallel()
+
+	// probe returns a probe that is met after the given
+	// number of attempts.
+	probe := func(attempts int) probeFunc {
+		n := 0
+		return func(time.Duration) (bool, any, error) {
+			n++
+			return n >= attempts, n, nil
+		}
+	}
+	opts := func(timeout time.Duration, not, soft bool) *ExpectOptions {
+		return &ExpectOptions{Timeout: timeout, Not: not, Soft: soft}
+	}
+
+	tests := []struct {
+		name    string
+		opts    *ExpectOptions
+		probe   probeFunc
+		wantErr string
+	}{
+		{
+			name:  "pass",
+			opts:  opts(time.Second, false, false),
+			probe: probe(1),
+		},
+		{
+			name:  "pass_after_retry",
+			opts:  opts(time.Second, false, false),
+			probe: probe(3),
+		},
+		{
+			name:    "timeout",
+			opts:    opts(200*time.Millisecond, false, false),
+			probe:   probe(100),
+			wantErr: "expectation failed: expect(target).toMatch(42): timed out after 200ms, got 3",
+		},
+		{
+			name:  "not",
+			opts:  opts(time.Second, true, false),
+			probe: func(time.Duration) (bool, any, error) { return false, nil, nil },
+		},
+		{
+			name:    "not_timeout",
+			opts:    opts(0, true, false),
+			probe:   probe(1),
+			wantErr: "expect(target).not.toMatch(42): condition not met, got 1",
+		},
+		{
+			name:  "soft",
+			opts:  opts(0, false, true),
+			probe: probe(100),
+		},
+		{
+			name: "probe_error",
+			opts: opts(time.Second, false, false),
+			probe: func(time.Duration) (bool, any, error) {
+				return false, nil, errors.New("detached")
+			},
+			wantErr: "expect(target).toMatch(42): timed out after 1s: detached",
+		},
+		{
+			name: "probe_error_retry",
+			opts: opts(time.Second, false, false),
+			probe: func() probeFunc {
+				n := 0
+				return func(time.Duration) (bool, any, error) {
+					n++
+					if n < 3 {
+						return false, nil, errors.New("detached")
+					}
+					return true, n, nil
+				}
+			}(),
+		},
+		{
+			name: "final_probe_error",
+			opts: opts(time.Minute, false, false),
+			probe: func(time.Duration) (bool, any, error) {
+				return false, nil, &finalProbeError{errors.New("missing")}
+			},
+			wantErr: "expect(target).toMatch(42): missing",
+		},
+		{
+			name:    "message",
+			opts:    &ExpectOptions{Message: "has the answer"},
+			probe:   probe(2),
+			wantErr: "expectation failed: has the answer: condition not met, got 1",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a := newAssertion(context.Background(), "target")
+			err := a.check(tt.opts, "toMatch", 42, tt.probe)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrExpectationFailed)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
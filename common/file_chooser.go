@@ -0,0 +1,106 @@
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chromedp/cdproto/cdp"
+	cdppage "github.com/chromedp/cdproto/page"
+)
+
+// FileChooser represents a file chooser that is opened by a file input
+// element. While a page intercepts file choosers, they don't open a
+// native dialog, and the files are set with SetFiles instead.
+type FileChooser struct {
+	page     *Page
+	element  *ElementHandle
+	multiple bool
+}
+
+// NewFileChooser returns a new file chooser of the file input element.
+func NewFileChooser(p *Page, element *ElementHandle, multiple bool) *FileChooser {
+	return &FileChooser{
+		page:     p,
+		element:  element,
+		multiple: multiple,
+	}
+}
+
+// Element returns the file input element that opened the file chooser.
+func (fc *FileChooser) Element() *ElementHandle {
+	return fc.element
+}
+
+// IsMultiple returns true if the file chooser accepts multiple files.
+func (fc *FileChooser) IsMultiple() bool {
+	return fc.multiple
+}
+
+// Page returns the page that the file chooser belongs to.
+func (fc *FileChooser) Page() *Page {
+	return fc.page
+}
+
+// SetFiles sets the files of the file input element that opened the
+// file chooser.
+func (fc *FileChooser) SetFiles(files *Files, opts *ElementHandleSetInputFilesOptions) error {
+	h := fc.element
+	setInputFiles := func(apiCtx context.Context, handle *ElementHandle) (any, error) {
+		return nil, handle.setInputFiles(apiCtx, files.Payload)
+	}
+	act := h.newAction([]string{}, setInputFiles, opts.Force, opts.NoWaitAfter, opts.Timeout)
+	if _, err := call(h.ctx, act, opts.Timeout); err != nil {
+		return fmt.Errorf("setting file chooser files: %w", err)
+	}
+
+	applySlowMo(h.ctx)
+
+	return nil
+}
+
+// interceptFileChooser makes the page intercept file choosers and
+// report them with file chooser events instead of opening them.
+func (p *Page) interceptFileChooser() error {
+	if p.interceptsFileChooser.Swap(true) {
+		return nil
+	}
+
+	p.frameSessionsMu.RLock()
+	sessions := make([]*FrameSession, 0, len(p.frameSessions))
+	for _, fs := range p.frameSessions {
+		sessions = append(sessions, fs)
+	}
+	p.frameSessionsMu.RUnlock()
+
+	for _, fs := range sessions {
+		action := cdppage.SetInterceptFileChooserDialog(true)
+		if err := action.Do(cdp.WithExecutor(p.ctx, fs.session)); err != nil {
+			return fmt.Errorf("intercepting file chooser: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// onFileChooserOpened emits the file chooser of the file input element
+// to the page.waitForEvent('filechooser') waiters, and calls the
+// page.on('filechooser') handlers with it.
+func (p *Page) onFileChooserOpened(ev *cdppage.EventFileChooserOpened) {
+	frame, ok := p.frameManager.getFrameByID(ev.FrameID)
+	if !ok {
+		p.logger.Debugf("Page:onFileChooserOpened", "frame not found fid:%v", ev.FrameID)
+		return
+	}
+	element, err := frame.adoptBackendNodeID(mainWorld, ev.BackendNodeID)
+	if err != nil {
+		p.logger.Errorf("Page:onFileChooserOpened", "adopting file input element: %v", err)
+		return
+	}
+	fc := NewFileChooser(p, element, ev.Mode == cdppage.FileChooserOpenedModeSelectMultiple)
+
+	p.emit(EventPageFilechooser, fc)
+
+	_ = p.callPageOnHandlers(EventPageFileChooserOpened, PageOnEvent{
+		FileChooser: fc,
+	})
+}
@@ -310,11 +310,13 @@ func (f *Frame) navigated(name string, url string, loaderID string) {
 	f.log.Debugf("Frame:navigated", "fid:%s furl:%q lid:%s name:%q url:%q", f.ID(), f.URL(), loaderID, name, url)
 
 	f.propertiesMu.Lock()
-	defer f.propertiesMu.Unlock()
 	f.name = name
 	f.url = url
 	f.loaderID = loaderID
+	f.propertiesMu.Unlock()
+
 	f.page.emit(EventPageFrameNavigated, f)
+	_ = f.page.callPageOnHandlers(EventPageFrameWasNavigated, PageOnEvent{Frame: f})
 }
 
 func (f *Frame) nullContext(execCtxID runtime.ExecutionContextID) {
@@ -549,6 +551,97 @@ func (f *Frame) waitFor(
 	return handle, err
 }
 
+// AddScriptTag adds a script tag with the given URL, file or content
+// to the frame's document, and returns the added script element. If the
+// script is loaded from a URL, it waits for the script to load.
+func (f *Frame) AddScriptTag(popts *FrameAddScriptTagOptions) (*ElementHandle, error) {
+	f.log.Debugf("Frame:AddScriptTag", "fid:%s furl:%q", f.ID(), f.URL())
+
+	content := popts.Content
+	if popts.Path != "" {
+		b, err := GetFileReader(f.ctx)(popts.Path)
+		if err != nil {
+			return nil, fmt.Errorf("adding script tag: %w", err)
+		}
+		content = string(b) + "\n//# sourceURL=" + popts.Path
+	}
+
+	js := `async (url, content, type) => {
+		const script = document.createElement('script');
+		if (type) {
+			script.type = type;
+		}
+		if (!url) {
+			script.text = content;
+			document.head.appendChild(script);
+			return script;
+		}
+		script.src = url;
+		const loaded = new Promise((resolve, reject) => {
+			script.onload = resolve;
+			script.onerror = () => reject(new Error('loading script from ' + url));
+		});
+		document.head.appendChild(script);
+		await loaded;
+		return script;
+	}`
+
+	return f.addTag("script", js, popts.URL, content, popts.Type)
+}
+
+// AddStyleTag adds a link tag with the given URL, or a style tag with
+// the given file or content to the frame's document, and returns the
+// added element. If the stylesheet is loaded from a URL, it waits for
+// the stylesheet to load.
+func (f *Frame) AddStyleTag(popts *FrameAddStyleTagOptions) (*ElementHandle, error) {
+	f.log.Debugf("Frame:AddStyleTag", "fid:%s furl:%q", f.ID(), f.URL())
+
+	content := popts.Content
+	if popts.Path != "" {
+		b, err := GetFileReader(f.ctx)(popts.Path)
+		if err != nil {
+			return nil, fmt.Errorf("adding style tag: %w", err)
+		}
+		content = string(b) + "\n/*# sourceURL=" + popts.Path + "*/"
+	}
+
+	js := `async (url, content) => {
+		if (!url) {
+			const style = document.createElement('style');
+			style.appendChild(document.createTextNode(content));
+			(document.head || document.documentElement).appendChild(style);
+			return style;
+		}
+		const link = document.createElement('link');
+		link.rel = 'stylesheet';
+		link.href = url;
+		const loaded = new Promise((resolve, reject) => {
+			link.onload = resolve;
+			link.onerror = () => reject(new Error('loading stylesheet from ' + url));
+		});
+		document.head.appendChild(link);
+		await loaded;
+		return link;
+	}`
+
+	return f.addTag("style", js, popts.URL, content)
+}
+
+// addTag evaluates the page function that adds a tag to the frame's
+// document, and returns the element of the added tag.
+func (f *Frame) addTag(tag, pageFunc string, args ...any) (*ElementHandle, error) {
+	handle, err := f.EvaluateHandle(pageFunc, args...)
+	if err != nil {
+		return nil, fmt.Errorf("adding %s tag: %w", tag, err)
+	}
+	element := handle.AsElement()
+	if element == nil {
+		return nil, fmt.Errorf("adding %s tag: added tag is not an element", tag)
+	}
+
+	return element, nil
+}
+
 // ChildFrames returns a list of child frames.
 func (f *Frame) ChildFrames() []*Frame {
 	f.childFramesMu.RLock()
@@ -579,7 +672,7 @@ func (f *Frame) click(selector string, opts *FrameClickOptions) error {
 		return nil, handle.click(p, opts.ToMouseClickOptions())
 	}
 	act := f.newPointerAction(
-		selector, DOMElementStateAttached, opts.Strict, click, &opts.ElementHandleBasePointerOptions,
+		selector, DOMElementStateAttached, f.isStrict(opts.Strict, opts.strictSet), click, &opts.ElementHandleBasePointerOptions,
 	)
 	if _, err := call(f.ctx, act, opts.Timeout); err != nil {
 		return errorFromDOMError(err)
@@ -610,7 +703,7 @@ func (f *Frame) check(selector string, opts *FrameCheckOptions) error {
 		return nil, handle.setChecked(apiCtx, true, p)
 	}
 	act := f.newPointerAction(
-		selector, DOMElementStateAttached, opts.Strict, check, &opts.ElementHandleBasePointerOptions,
+		selector, DOMElementStateAttached, f.isStrict(opts.Strict, opts.strictSet), check, &opts.ElementHandleBasePointerOptions,
 	)
 	if _, err := call(f.ctx, act, opts.Timeout); err != nil {
 		return errorFromDOMError(err)
@@ -624,7 +717,7 @@ func (f *Frame) setChecked(selector string, checked bool, opts *FrameCheckOption
 		return nil, handle.setChecked(apiCtx, checked, p)
 	}
 	act := f.newPointerAction(
-		selector, DOMElementStateAttached, opts.Strict, setChecked, &opts.ElementHandleBasePointerOptions,
+		selector, DOMElementStateAttached, f.isStrict(opts.Strict, opts.strictSet), setChecked, &opts.ElementHandleBasePointerOptions,
 	)
 	if _, err := call(f.ctx, act, opts.Timeout); err != nil {
 		return errorFromDOMError(err)
@@ -672,7 +765,7 @@ func (f *Frame) uncheck(selector string, opts *FrameUncheckOptions) error {
 		return nil, handle.setChecked(apiCtx, false, p)
 	}
 	act := f.newPointerAction(
-		selector, DOMElementStateAttached, opts.Strict, uncheck, &opts.ElementHandleBasePointerOptions,
+		selector, DOMElementStateAttached, f.isStrict(opts.Strict, opts.strictSet), uncheck, &opts.ElementHandleBasePointerOptions,
 	)
 	if _, err := call(f.ctx, act, opts.Timeout); err != nil {
 		return errorFromDOMError(err)
@@ -707,7 +800,7 @@ func (f *Frame) isChecked(selector string, opts *FrameIsCheckedOptions) (bool, e
 		return v, err
 	}
 	act := f.newAction(
-		selector, DOMElementStateAttached, opts.Strict, isChecked, []string{}, false, true, opts.Timeout,
+		selector, DOMElementStateAttached, f.isStrict(opts.Strict, opts.strictSet), isChecked, []string{}, false, true, opts.Timeout,
 	)
 	v, err := call(f.ctx, act, opts.Timeout)
 	if err != nil {
@@ -773,7 +866,7 @@ func (f *Frame) dblclick(selector string, opts *FrameDblclickOptions) error {
 		return nil, eh.dblclick(p, opts.ToMouseClickOptions())
 	}
 	act := f.newPointerAction(
-		selector, DOMElementStateAttached, opts.Strict, dblclick, &opts.ElementHandleBasePointerOptions,
+		selector, DOMElementStateAttached, f.isStrict(opts.Strict, opts.strictSet), dblclick, &opts.ElementHandleBasePointerOptions,
 	)
 	if _, err := call(f.ctx, act, opts.Timeout); err != nil {
 		return errorFromDOMError(err)
@@ -805,7 +898,7 @@ func (f *Frame) dispatchEvent(selector, typ string, eventInit any, opts *FrameDi
 		noWaitAfter = false
 	)
 	act := f.newAction(
-		selector, DOMElementStateAttached, opts.Strict, dispatchEvent, []string{},
+		selector, DOMElementStateAttached, f.isStrict(opts.Strict, opts.strictSet), dispatchEvent, []string{},
 		force, noWaitAfter, opts.Timeout,
 	)
 	if _, err := call(f.ctx, act, opts.Timeout); err != nil {
@@ -815,6 +908,84 @@ func (f *Frame) dispatchEvent(selector, typ string, eventInit any, opts *FrameDi
 	return nil
 }
 
+// DragAndDrop drags the source element onto the target element.
+func (f *Frame) DragAndDrop(source, target string, opts *FrameDragAndDropOptions) error {
+	f.log.Debugf("Frame:DragAndDrop", "fid:%s furl:%q src:%q dst:%q", f.ID(), f.URL(), source, target)
+
+	if err := f.dragAndDrop(source, target, opts); err != nil {
+		return fmt.Errorf("dragging %q to %q: %w", source, target, err)
+	}
+
+	applySlowMo(f.ctx)
+
+	return nil
+}
+
+func (f *Frame) dragAndDrop(source, target string, opts *FrameDragAndDropOptions) error {
+	if err := f.dragFrom(source, f.isStrict(opts.Strict, opts.strictSet), opts.pointerOptions(opts.SourcePosition)); err != nil {
+		return err
+	}
+
+	if err := f.dropAt(target, f.isStrict(opts.Strict, opts.strictSet), opts.pointerOptions(opts.TargetPosition)); err != nil {
+		return errors.Join(err, f.page.Mouse.cancelDrag())
+	}
+
+	return nil
+}
+
+// dragFrom moves the mouse pointer over the element matching the selector
+// and presses the left mouse button to start dragging it.
+func (f *Frame) dragFrom(selector string, strict bool, opts *ElementHandleBasePointerOptions) error {
+	mouse := f.page.Mouse
+	moveAndDown := func(apiCtx context.Context, handle *ElementHandle, p *Position) (any, error) {
+		if err := mouse.move(p.X, p.Y, NewMouseMoveOptions()); err != nil {
+			return nil, err
+		}
+		return nil, mouse.down(NewMouseDownUpOptions())
+	}
+	act := f.newPointerAction(selector, DOMElementStateAttached, strict, moveAndDown, opts)
+	if _, err := call(f.ctx, act, opts.Timeout); err != nil {
+		return errorFromDOMError(err)
+	}
+
+	return nil
+}
+
+// dropAt moves the mouse pointer over the element matching the selector
+// and releases the left mouse button to drop what is being dragged.
+// Native drags are dispatched as drag events by the mouse.
+func (f *Frame) dropAt(selector string, strict bool, opts *ElementHandleBasePointerOptions) error {
+	mouse := f.page.Mouse
+	moveAndUp := func(apiCtx context.Context, handle *ElementHandle, p *Position) (any, error) {
+		if err := mouse.move(p.X, p.Y, NewMouseMoveOptions()); err != nil {
+			return nil, err
+		}
+		return nil, mouse.up(NewMouseDownUpOptions())
+	}
+	act := f.newPointerAction(selector, DOMElementStateAttached, strict, moveAndUp, opts)
+	if _, err := call(f.ctx, act, opts.Timeout); err != nil {
+		return errorFromDOMError(err)
+	}
+
+	return nil
+}
+
+// dropFiles drops the files onto the element matching the selector.
+func (f *Frame) dropFiles(selector string, strict bool, files *Files, opts *ElementHandleBaseOptions) error {
+	dropFiles := func(apiCtx context.Context, handle *ElementHandle) (any, error) {
+		return nil, handle.dropFiles(apiCtx, files.Payload)
+	}
+	act := f.newAction(
+		selector, DOMElementStateAttached, strict, dropFiles,
+		[]string{"visible"}, opts.Force, opts.NoWaitAfter, opts.Timeout,
+	)
+	if _, err := call(f.ctx, act, opts.Timeout); err != nil {
+		return errorFromDOMError(err)
+	}
+
+	return nil
+}
+
 // EvaluateWithContext will evaluate provided page function within an execution context.
 // The passed in context will be used instead of the frame's context. The context must
 // be a derivative of one that contains the sobek runtime.
@@ -910,7 +1081,7 @@ func (f *Frame) fill(selector, value string, opts *FrameFillOptions) error {
 		return nil, handle.fill(apiCtx, value)
 	}
 	act := f.newAction(
-		selector, DOMElementStateAttached, opts.Strict,
+		selector, DOMElementStateAttached, f.isStrict(opts.Strict, opts.strictSet),
 		fill, []string{"visible", "enabled", "editable"},
 		opts.Force, opts.NoWaitAfter, opts.Timeout,
 	)
@@ -938,12 +1109,85 @@ func (f *Frame) Focus(selector string, opts sobek.Value) error {
 	return nil
 }
 
+func (f *Frame) blur(selector string, opts *FrameBaseOptions) error {
+	blur := func(apiCtx context.Context, handle *ElementHandle) (any, error) {
+		return nil, handle.blur(apiCtx)
+	}
+	act := f.newAction(
+		selector, DOMElementStateAttached, f.isStrict(opts.Strict, opts.strictSet), blur,
+		[]string{}, false, true, opts.Timeout,
+	)
+	if _, err := call(f.ctx, act, opts.Timeout); err != nil {
+		return errorFromDOMError(err)
+	}
+
+	return nil
+}
+
+func (f *Frame) boundingBox(selector string, opts *FrameBaseOptions) (*Rect, error) {
+	boundingBox := func(apiCtx context.Context, handle *ElementHandle) (any, error) {
+		// An element without a layout, such as a hidden one,
+		// doesn't have a bounding box.
+		return handle.BoundingBox(), nil
+	}
+	act := f.newAction(
+		selector, DOMElementStateAttached, f.isStrict(opts.Strict, opts.strictSet), boundingBox,
+		[]string{}, false, true, opts.Timeout,
+	)
+	v, err := call(f.ctx, act, opts.Timeout)
+	if err != nil {
+		return nil, errorFromDOMError(err)
+	}
+	if v == nil {
+		return nil, nil
+	}
+	rect, ok := v.(*Rect)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type %T", v)
+	}
+
+	return rect, nil
+}
+
+func (f *Frame) highlight(selector string) error {
+	document, err := f.document()
+	if err != nil {
+		return fmt.Errorf("getting document: %w", err)
+	}
+	parsedSelector, err := f.parseSelector(selector)
+	if err != nil {
+		return fmt.Errorf("parsing selector: %w", err)
+	}
+	fn := `
+		(node, injected, selector) => {
+			const elements = injected.querySelectorAll(selector, node);
+			if (typeof elements === "string") {
+				return elements;
+			}
+			return injected.highlight(elements);
+		}
+	`
+	opts := evalOptions{
+		forceCallable: true,
+		returnByValue: true,
+	}
+	result, err := document.evalWithScript(f.ctx, opts, fn, parsedSelector)
+	if err != nil {
+		return err
+	}
+	if s, ok := result.(string); ok && s != resultDone {
+		return errorFromDOMError(s)
+	}
+
+	return nil
+}
+
 func (f *Frame) focus(selector string, opts *FrameBaseOptions) error {
 	focus := func(apiCtx context.Context, handle *ElementHandle) (any, error) {
 		return nil, handle.focus(apiCtx, true)
 	}
 	act := f.newAction(
-		selector, DOMElementStateAttached, opts.Strict, focus,
+		selector, DOMElementStateAttached, f.isStrict(opts.Strict, opts.strictSet), focus,
 		[]string{}, false, true, opts.Timeout,
 	)
 	if _, err := call(f.ctx, act, opts.Timeout); err != nil {
@@ -964,6 +1208,14 @@ func (f *Frame) FrameElement() (*ElementHandle, error) {
 	return element, nil
 }
 
+// FrameLocator creates and returns a new frame locator for the iframe that
+// matches the given selector in this frame.
+func (f *Frame) FrameLocator(selector string) *FrameLocator {
+	f.log.Debugf("Frame:FrameLocator", "fid:%s furl:%q selector:%q", f.ID(), f.URL(), selector)
+
+	return NewFrameLocator(f.ctx, selector, f, f.log)
+}
+
 // GetAttribute of the first element found that matches the selector.
 // The second return value is true if the attribute exists, and false otherwise.
 func (f *Frame) GetAttribute(selector, name string, opts sobek.Value) (string, bool, error) {
@@ -986,7 +1238,7 @@ func (f *Frame) getAttribute(selector, name string, opts *FrameBaseOptions) (str
 		return handle.getAttribute(apiCtx, name)
 	}
 	act := f.newAction(
-		selector, DOMElementStateAttached, opts.Strict, getAttribute,
+		selector, DOMElementStateAttached, f.isStrict(opts.Strict, opts.strictSet), getAttribute,
 		[]string{}, false, true, opts.Timeout,
 	)
 	v, err := call(f.ctx, act, opts.Timeout)
@@ -1057,7 +1309,7 @@ func (f *Frame) hover(selector string, opts *FrameHoverOptions) error {
 		return nil, handle.hover(apiCtx, p)
 	}
 	act := f.newPointerAction(
-		selector, DOMElementStateAttached, opts.Strict, hover, &opts.ElementHandleBasePointerOptions,
+		selector, DOMElementStateAttached, f.isStrict(opts.Strict, opts.strictSet), hover, &opts.ElementHandleBasePointerOptions,
 	)
 	if _, err := call(f.ctx, act, opts.Timeout); err != nil {
 		return errorFromDOMError(err)
@@ -1088,7 +1340,7 @@ func (f *Frame) innerHTML(selector string, opts *FrameInnerHTMLOptions) (string,
 		return handle.innerHTML(apiCtx)
 	}
 	act := f.newAction(
-		selector, DOMElementStateAttached, opts.Strict, innerHTML,
+		selector, DOMElementStateAttached, f.isStrict(opts.Strict, opts.strictSet), innerHTML,
 		[]string{}, false, true, opts.Timeout,
 	)
 	v, err := call(f.ctx, act, opts.Timeout)
@@ -1128,7 +1380,7 @@ func (f *Frame) innerText(selector string, opts *FrameInnerTextOptions) (string,
 		return handle.innerText(apiCtx)
 	}
 	act := f.newAction(
-		selector, DOMElementStateAttached, opts.Strict, innerText,
+		selector, DOMElementStateAttached, f.isStrict(opts.Strict, opts.strictSet), innerText,
 		[]string{}, false, true, opts.Timeout,
 	)
 	v, err := call(f.ctx, act, opts.Timeout)
@@ -1167,7 +1419,7 @@ func (f *Frame) inputValue(selector string, opts *FrameInputValueOptions) (strin
 		return handle.inputValue(apiCtx)
 	}
 	act := f.newAction(
-		selector, DOMElementStateAttached, opts.Strict, inputValue,
+		selector, DOMElementStateAttached, f.isStrict(opts.Strict, opts.strictSet), inputValue,
 		[]string{}, false, true, opts.Timeout,
 	)
 	v, err := call(f.ctx, act, opts.Timeout)
@@ -1224,7 +1476,7 @@ func (f *Frame) isEditable(selector string, opts *FrameIsEditableOptions) (bool,
 		return v, err
 	}
 	act := f.newAction(
-		selector, DOMElementStateAttached, opts.Strict, isEditable, []string{}, false, true, opts.Timeout,
+		selector, DOMElementStateAttached, f.isStrict(opts.Strict, opts.strictSet), isEditable, []string{}, false, true, opts.Timeout,
 	)
 	v, err := call(f.ctx, act, opts.Timeout)
 	if err != nil {
@@ -1265,7 +1517,7 @@ func (f *Frame) isEnabled(selector string, opts *FrameIsEnabledOptions) (bool, e
 		return v, err
 	}
 	act := f.newAction(
-		selector, DOMElementStateAttached, opts.Strict, isEnabled, []string{}, false, true, opts.Timeout,
+		selector, DOMElementStateAttached, f.isStrict(opts.Strict, opts.strictSet), isEnabled, []string{}, false, true, opts.Timeout,
 	)
 	v, err := call(f.ctx, act, opts.Timeout)
 	if err != nil {
@@ -1306,7 +1558,7 @@ func (f *Frame) isDisabled(selector string, opts *FrameIsDisabledOptions) (bool,
 		return v, err
 	}
 	act := f.newAction(
-		selector, DOMElementStateAttached, opts.Strict, isDisabled, []string{}, false, true, opts.Timeout,
+		selector, DOMElementStateAttached, f.isStrict(opts.Strict, opts.strictSet), isDisabled, []string{}, false, true, opts.Timeout,
 	)
 	v, err := call(f.ctx, act, opts.Timeout)
 	if err != nil {
@@ -1346,7 +1598,7 @@ func (f *Frame) isHidden(selector string, opts *FrameIsHiddenOptions) (bool, err
 		}
 		return v, err
 	}
-	v, err := f.runActionOnSelector(f.ctx, selector, opts.Strict, isHidden, func() bool { return true })
+	v, err := f.runActionOnSelector(f.ctx, selector, f.isStrict(opts.Strict, opts.strictSet), isHidden, func() bool { return true })
 	if err != nil {
 		return false, fmt.Errorf("checking is %q hidden: %w", selector, err)
 	}
@@ -1379,7 +1631,7 @@ func (f *Frame) isVisible(selector string, opts *FrameIsVisibleOptions) (bool, e
 		}
 		return v, err
 	}
-	v, err := f.runActionOnSelector(f.ctx, selector, opts.Strict, isVisible, func() bool { return false })
+	v, err := f.runActionOnSelector(f.ctx, selector, f.isStrict(opts.Strict, opts.strictSet), isVisible, func() bool { return false })
 	if err != nil {
 		return false, fmt.Errorf("checking is %q visible: %w", selector, err)
 	}
@@ -1399,7 +1651,20 @@ func (f *Frame) ID() string {
 func (f *Frame) Locator(selector string, opts sobek.Value) *Locator {
 	f.log.Debugf("Frame:Locator", "fid:%s furl:%q selector:%q opts:%+v", f.ID(), f.URL(), selector, opts)
 
-	return NewLocator(f.ctx, selector, f, f.log)
+	return f.newLocator(selector, opts)
+}
+
+// newLocator creates a new locator for the selector in this frame
+// with the given locator options.
+func (f *Frame) newLocator(selector string, opts sobek.Value) *Locator {
+	popts := NewLocatorOptions()
+	if err := popts.Parse(f.ctx, opts); err != nil {
+		k6ext.Panic(f.ctx, "parsing locator options: %w", err)
+	}
+	l := NewLocator(f.ctx, selector, f, f.log)
+	l.strict = popts.Strict
+
+	return l
 }
 
 // LoaderID returns the ID of the frame that loaded this frame.
@@ -1419,10 +1684,15 @@ func (f *Frame) Name() string {
 }
 
 // Query runs a selector query against the document tree, returning the first matching element or
-// "null" if no match is found.
+// "null" if no match is found. The query is strict if strict is true or the strictSelectors
+// option of the browser context is on.
 func (f *Frame) Query(selector string, strict bool) (*ElementHandle, error) {
 	f.log.Debugf("Frame:Query", "fid:%s furl:%q sel:%q", f.ID(), f.URL(), selector)
 
+	return f.query(selector, f.isStrict(strict, false))
+}
+
+func (f *Frame) query(selector string, strict bool) (*ElementHandle, error) {
 	document, err := f.document()
 	if err != nil {
 		return nil, fmt.Errorf("getting document: %w", err)
@@ -1430,6 +1700,44 @@ func (f *Frame) Query(selector string, strict bool) (*ElementHandle, error) {
 	return document.Query(selector, strict)
 }
 
+// selectors returns the registry of the custom selector engines that
+// the frame's selectors can use.
+func (f *Frame) selectors() *Selectors {
+	if f == nil || f.page == nil || f.page.browserCtx == nil || f.page.browserCtx.browser == nil {
+		return nil
+	}
+
+	return f.page.browserCtx.browser.selectors
+}
+
+// parseSelector parses a selector that can use the builtin selector
+// engines and the custom selector engines of the frame.
+func (f *Frame) parseSelector(selector string) (*Selector, error) {
+	return newSelector(selector, f.selectors())
+}
+
+// isStrict returns true if an operation on a selector with the strict option
+// is strict. The strict option overrides the strictSelectors option of the
+// browser context if it's set explicitly, and is strict if either is on
+// otherwise.
+func (f *Frame) isStrict(strict, set bool) bool {
+	if set {
+		return strict
+	}
+
+	return strict || f.strictSelectors()
+}
+
+// strictSelectors returns true if the browser context of the frame's page
+// requires all the selectors to match at most one element.
+func (f *Frame) strictSelectors() bool {
+	if f == nil || f.page == nil || f.page.browserCtx == nil || f.page.browserCtx.opts == nil {
+		return false
+	}
+
+	return f.page.browserCtx.opts.StrictSelectors
+}
+
 // QueryAll runs a selector query against the document tree, returning all matching elements.
 func (f *Frame) QueryAll(selector string) ([]*ElementHandle, error) {
 	f.log.Debugf("Frame:QueryAll", "fid:%s furl:%q sel:%q", f.ID(), f.URL(), selector)
@@ -1473,7 +1781,7 @@ func (f *Frame) press(selector, key string, opts *FramePressOptions) error {
 		return nil, handle.press(apiCtx, key, opts.ToKeyboardOptions())
 	}
 	act := f.newAction(
-		selector, DOMElementStateAttached, opts.Strict, press,
+		selector, DOMElementStateAttached, f.isStrict(opts.Strict, opts.strictSet), press,
 		[]string{}, false, opts.NoWaitAfter, opts.Timeout,
 	)
 	if _, err := call(f.ctx, act, opts.Timeout); err != nil {
@@ -1507,7 +1815,7 @@ func (f *Frame) selectOption(selector string, values sobek.Value, opts *FrameSel
 		return handle.selectOption(apiCtx, values)
 	}
 	act := f.newAction(
-		selector, DOMElementStateAttached, opts.Strict, selectOption,
+		selector, DOMElementStateAttached, f.isStrict(opts.Strict, opts.strictSet), selectOption,
 		[]string{}, opts.Force, opts.NoWaitAfter, opts.Timeout,
 	)
 	v, err := call(f.ctx, act, opts.Timeout)
@@ -1616,7 +1924,37 @@ func (f *Frame) tap(selector string, opts *FrameTapOptions) error {
 		return nil, handle.tap(apiCtx, p)
 	}
 	act := f.newPointerAction(
-		selector, DOMElementStateAttached, opts.Strict, tap, &opts.ElementHandleBasePointerOptions,
+		selector, DOMElementStateAttached, f.isStrict(opts.Strict, opts.strictSet), tap, &opts.ElementHandleBasePointerOptions,
+	)
+	if _, err := call(f.ctx, act, opts.Timeout); err != nil {
+		return errorFromDOMError(err)
+	}
+
+	return nil
+}
+
+func (f *Frame) scrollIntoViewIfNeeded(selector string, strict bool, opts *ElementHandleBaseOptions) error {
+	scrollIntoViewIfNeeded := func(apiCtx context.Context, handle *ElementHandle) (any, error) {
+		return handle.scrollIntoViewIfNeeded(apiCtx)
+	}
+	act := f.newAction(
+		selector, DOMElementStateAttached, strict, scrollIntoViewIfNeeded,
+		[]string{"visible", "stable"}, opts.Force, opts.NoWaitAfter, opts.Timeout,
+	)
+	if _, err := call(f.ctx, act, opts.Timeout); err != nil {
+		return errorFromDOMError(err)
+	}
+
+	return nil
+}
+
+func (f *Frame) selectText(selector string, strict bool, opts *ElementHandleBaseOptions) error {
+	selectText := func(apiCtx context.Context, handle *ElementHandle) (any, error) {
+		return nil, handle.selectText(apiCtx)
+	}
+	act := f.newAction(
+		selector, DOMElementStateAttached, strict, selectText,
+		[]string{}, opts.Force, opts.NoWaitAfter, opts.Timeout,
 	)
 	if _, err := call(f.ctx, act, opts.Timeout); err != nil {
 		return errorFromDOMError(err)
@@ -1630,7 +1968,7 @@ func (f *Frame) setInputFiles(selector string, files *Files, opts *FrameSetInput
 		return nil, handle.setInputFiles(apiCtx, files.Payload)
 	}
 	act := f.newAction(
-		selector, DOMElementStateAttached, opts.Strict,
+		selector, DOMElementStateAttached, f.isStrict(opts.Strict, opts.strictSet),
 		setInputFiles, []string{},
 		opts.Force, opts.NoWaitAfter, opts.Timeout,
 	)
@@ -1665,7 +2003,7 @@ func (f *Frame) textContent(selector string, opts *FrameTextContentOptions) (str
 		return handle.textContent(apiCtx)
 	}
 	act := f.newAction(
-		selector, DOMElementStateAttached, opts.Strict, TextContent,
+		selector, DOMElementStateAttached, f.isStrict(opts.Strict, opts.strictSet), TextContent,
 		[]string{}, false, true, opts.Timeout,
 	)
 	v, err := call(f.ctx, act, opts.Timeout)
@@ -1728,7 +2066,7 @@ func (f *Frame) typ(selector, text string, opts *FrameTypeOptions) error {
 		return nil, handle.typ(apiCtx, text, opts.ToKeyboardOptions())
 	}
 	act := f.newAction(
-		selector, DOMElementStateAttached, opts.Strict, typeText,
+		selector, DOMElementStateAttached, f.isStrict(opts.Strict, opts.strictSet), typeText,
 		[]string{}, false, opts.NoWaitAfter, opts.Timeout,
 	)
 	if _, err := call(f.ctx, act, opts.Timeout); err != nil {
@@ -2073,7 +2411,7 @@ type frameExecutionContext interface {
 func (f *Frame) runActionOnSelector(
 	ctx context.Context, selector string, strict bool, fn elementHandleActionFunc, nullResponder func() bool,
 ) (bool, error) {
-	handle, err := f.Query(selector, strict)
+	handle, err := f.query(selector, strict)
 	if err != nil {
 		return false, fmt.Errorf("query: %w", err)
 	}
@@ -2107,7 +2445,7 @@ func (f *Frame) newAction(
 	return func(apiCtx context.Context, resultCh chan any, errCh chan error) {
 		waitOpts := NewFrameWaitForSelectorOptions(f.defaultTimeout())
 		waitOpts.State = state
-		waitOpts.Strict = strict
+		waitOpts.Strict, waitOpts.strictSet = strict, true
 		handle, err := f.waitForSelector(selector, waitOpts)
 		if err != nil {
 			select {
@@ -2140,7 +2478,7 @@ func (f *Frame) newPointerAction(
 	return func(apiCtx context.Context, resultCh chan any, errCh chan error) {
 		waitOpts := NewFrameWaitForSelectorOptions(f.defaultTimeout())
 		waitOpts.State = state
-		waitOpts.Strict = strict
+		waitOpts.Strict, waitOpts.strictSet = strict, true
 		handle, err := f.waitForSelector(selector, waitOpts)
 		if err != nil {
 			select {
@@ -0,0 +1,114 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/grafana/sobek"
+
+	"github.com/grafana/xk6-browser/log"
+)
+
+// FrameLocator represents a way to find elements inside an iframe.
+//
+// Unlike an element handle to an iframe, a frame locator doesn't hold
+// on to a frame. It re-resolves the target frame through the frame
+// manager every time an action is performed, so that it keeps working
+// after the iframe reloads or navigates.
+type FrameLocator struct {
+	// selectors is the chain of iframe selectors from the root
+	// frame to the target frame. Each selector is queried in the
+	// content frame of the previous one.
+	selectors []string
+
+	frame *Frame
+
+	ctx context.Context
+	log *log.Logger
+}
+
+// NewFrameLocator creates and returns a new frame locator for the iframe
+// that matches the given selector in the frame f.
+func NewFrameLocator(ctx context.Context, selector string, f *Frame, l *log.Logger) *FrameLocator {
+	return &FrameLocator{
+		selectors: []string{selector},
+		frame:     f,
+		ctx:       ctx,
+		log:       l,
+	}
+}
+
+// FrameLocator creates and returns a new frame locator for the iframe that
+// matches the given selector inside this frame locator's iframe.
+func (fl *FrameLocator) FrameLocator(selector string) *FrameLocator {
+	fl.log.Debugf(
+		"FrameLocator:FrameLocator", "fid:%s furl:%q sels:%q sel:%q",
+		fl.frame.ID(), fl.frame.URL(), fl.selectors, selector,
+	)
+
+	return &FrameLocator{
+		selectors: append(fl.frameSelectors(), selector),
+		frame:     fl.frame,
+		ctx:       fl.ctx,
+		log:       fl.log,
+	}
+}
+
+// Locator creates and returns a new locator for the elements that match the
+// given selector inside this frame locator's iframe.
+func (fl *FrameLocator) Locator(selector string, opts sobek.Value) *Locator {
+	fl.log.Debugf(
+		"FrameLocator:Locator", "fid:%s furl:%q sels:%q sel:%q opts:%+v",
+		fl.frame.ID(), fl.frame.URL(), fl.selectors, selector, opts,
+	)
+
+	l := fl.frame.newLocator(selector, opts)
+	l.frameSelectors = fl.frameSelectors()
+
+	return l
+}
+
+// frameSelectors returns a copy of the iframe selectors so that the
+// locators derived from this frame locator don't share the same slice.
+func (fl *FrameLocator) frameSelectors() []string {
+	sels := make([]string, len(fl.selectors))
+	copy(sels, fl.selectors)
+
+	return sels
+}
+
+// resolveFrame walks the chain of iframe selectors starting from the frame f
+// and returns the content frame of the last iframe in the chain. It waits
+// for the iframe elements to be attached to the DOM within the timeout,
+// which is shared by the whole chain.
+func resolveFrame(f *Frame, selectors []string, timeout time.Duration) (*Frame, error) {
+	deadline := time.Now().Add(timeout)
+	for _, selector := range selectors {
+		remaining := timeout
+		if timeout > 0 {
+			if remaining = time.Until(deadline); remaining <= 0 {
+				return nil, fmt.Errorf("waiting for frame %q: %w", selector, ErrTimedOut)
+			}
+		}
+		opts := NewFrameWaitForSelectorOptions(remaining)
+		opts.State = DOMElementStateAttached
+		opts.Strict, opts.strictSet = true, true
+
+		handle, err := f.waitForSelector(selector, opts)
+		if err != nil {
+			return nil, fmt.Errorf("waiting for frame %q: %w", selector, err)
+		}
+		cf, err := handle.ContentFrame()
+		if derr := handle.Dispose(); derr != nil {
+			err = errors.Join(err, fmt.Errorf("disposing frame element handle: %w", derr))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("getting content frame of %q: %w", selector, err)
+		}
+		f = cf
+	}
+
+	return f, nil
+}
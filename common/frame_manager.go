@@ -159,6 +159,7 @@ func (m *FrameManager) frameAttached(frameID cdp.FrameID, parentFrameID cdp.Fram
 			"fmid:%d fid:%v pfid:%v", m.ID(), frameID, parentFrameID)
 
 		m.page.emit(EventPageFrameAttached, frame)
+		_ = m.page.callPageOnHandlers(EventPageFrameWasAttached, PageOnEvent{Frame: frame})
 	}
 }
 
@@ -208,8 +209,12 @@ func (m *FrameManager) frameLifecycleEvent(frameID cdp.FrameID, event LifecycleE
 		m.ID(), frameID, lifecycleEventToString[event])
 
 	frame, ok := m.getFrameByID(frameID)
-	if ok {
-		frame.onLifecycleEvent(event)
+	if !ok {
+		return
+	}
+	frame.onLifecycleEvent(event)
+	if frame == m.MainFrame() {
+		m.page.onMainFrameLifecycleEvent(event)
 	}
 }
 
@@ -378,6 +383,11 @@ func (m *FrameManager) frameNavigatedWithinDocument(frameID cdp.FrameID, url str
 
 	frame.setURL(url)
 	frame.emit(EventFrameNavigation, &NavigationEvent{url: url, name: frame.Name()})
+
+	// Navigations within the document, such as the history API navigations
+	// of single-page applications, are reported as frame navigations too.
+	m.page.emit(EventPageFrameNavigated, frame)
+	_ = m.page.callPageOnHandlers(EventPageFrameWasNavigated, PageOnEvent{Frame: frame})
 }
 
 func (m *FrameManager) frameRequestedNavigation(frameID cdp.FrameID, url string, documentID string) error {
@@ -478,6 +488,7 @@ func (m *FrameManager) removeFramesRecursively(frame *Frame) error {
 			m.ID(), frame.ID(), frame.Name(), frame.URL())
 
 		m.page.emit(EventPageFrameDetached, frame)
+		_ = m.page.callPageOnHandlers(EventPageFrameWasDetached, PageOnEvent{Frame: frame})
 	}
 
 	return nil
@@ -488,6 +499,10 @@ func (m *FrameManager) requestFailed(req *Request, canceled bool) {
 
 	defer m.page.emit(EventPageRequestFailed, req)
 
+	if t := m.page.tracing(); t != nil {
+		t.onRequestDone(m.page, req)
+	}
+
 	frame := req.getFrame()
 	if frame == nil {
 		m.logger.Debugf("FrameManager:requestFailed", "frame is nil")
@@ -519,6 +534,10 @@ func (m *FrameManager) requestFinished(req *Request) {
 
 	defer m.page.emit(EventPageRequestFinished, req)
 
+	if t := m.page.tracing(); t != nil {
+		t.onRequestDone(m.page, req)
+	}
+
 	frame := req.getFrame()
 	if frame == nil {
 		m.logger.Debugf("FrameManager:requestFinished:return",
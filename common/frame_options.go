@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 	"time"
@@ -13,29 +14,72 @@ import (
 	"github.com/grafana/xk6-browser/k6ext"
 )
 
+// FrameAddScriptTagOptions are options for Frame.addScriptTag.
+type FrameAddScriptTagOptions struct {
+	// URL is the URL of the script to load.
+	URL string `json:"url" js:"url"`
+	// Path is the path of the file whose content is the script. It's
+	// relative to the test script, and it's read the way that k6's
+	// open function reads the files, so it must be opened in the init
+	// context as well.
+	Path string `json:"path" js:"path"`
+	// Content is the content of the script.
+	Content string `json:"content" js:"content"`
+	// Type is the type of the script, such as module.
+	Type string `json:"type" js:"type"`
+}
+
+// FrameAddStyleTagOptions are options for Frame.addStyleTag.
+type FrameAddStyleTagOptions struct {
+	// URL is the URL of the stylesheet to load.
+	URL string `json:"url" js:"url"`
+	// Path is the path of the file whose content is the stylesheet.
+	// It's read the same way as the script tag path.
+	Path string `json:"path" js:"path"`
+	// Content is the content of the stylesheet.
+	Content string `json:"content" js:"content"`
+}
+
 type FrameBaseOptions struct {
 	Timeout time.Duration `json:"timeout"`
 	Strict  bool          `json:"strict"`
+	// strictSet is true if the strict option is set explicitly, in which
+	// case it overrides the strictSelectors option of the browser context.
+	strictSet bool
 }
 
 type FrameCheckOptions struct {
 	ElementHandleBasePointerOptions
-	Strict bool `json:"strict"`
+	Strict    bool `json:"strict"`
+	strictSet bool
 }
 
 type FrameClickOptions struct {
 	ElementHandleClickOptions
-	Strict bool `json:"strict"`
+	Strict    bool `json:"strict"`
+	strictSet bool
 }
 
 type FrameDblclickOptions struct {
 	ElementHandleDblclickOptions
-	Strict bool `json:"strict"`
+	Strict    bool `json:"strict"`
+	strictSet bool
+}
+
+// FrameDragAndDropOptions are options for Frame.DragAndDrop and Locator.DragTo.
+type FrameDragAndDropOptions struct {
+	ElementHandleBaseOptions
+	SourcePosition *Position `json:"sourcePosition"`
+	TargetPosition *Position `json:"targetPosition"`
+	Trial          bool      `json:"trial"`
+	Strict         bool      `json:"strict"`
+	strictSet      bool
 }
 
 type FrameFillOptions struct {
 	ElementHandleBaseOptions
-	Strict bool `json:"strict"`
+	Strict    bool `json:"strict"`
+	strictSet bool
 }
 
 type FrameGotoOptions struct {
@@ -46,7 +90,8 @@ type FrameGotoOptions struct {
 
 type FrameHoverOptions struct {
 	ElementHandleHoverOptions
-	Strict bool `json:"strict"`
+	Strict    bool `json:"strict"`
+	strictSet bool
 }
 
 type FrameInnerHTMLOptions struct {
@@ -78,21 +123,25 @@ type FrameIsEnabledOptions struct {
 }
 
 type FrameIsHiddenOptions struct {
-	Strict bool `json:"strict"`
+	Strict    bool `json:"strict"`
+	strictSet bool
 }
 
 type FrameIsVisibleOptions struct {
-	Strict bool `json:"strict"`
+	Strict    bool `json:"strict"`
+	strictSet bool
 }
 
 type FramePressOptions struct {
 	ElementHandlePressOptions
-	Strict bool `json:"strict"`
+	Strict    bool `json:"strict"`
+	strictSet bool
 }
 
 type FrameSelectOptionOptions struct {
 	ElementHandleBaseOptions
-	Strict bool `json:"strict"`
+	Strict    bool `json:"strict"`
+	strictSet bool
 }
 
 type FrameSetContentOptions struct {
@@ -103,13 +152,15 @@ type FrameSetContentOptions struct {
 // FrameSetInputFilesOptions are options for Frame.setInputFiles.
 type FrameSetInputFilesOptions struct {
 	ElementHandleSetInputFilesOptions
-	Strict bool `json:"strict"`
+	Strict    bool `json:"strict"`
+	strictSet bool
 }
 
 type FrameTapOptions struct {
 	ElementHandleBasePointerOptions
 	Modifiers []string `json:"modifiers"`
 	Strict    bool     `json:"strict"`
+	strictSet bool
 }
 
 type FrameTextContentOptions struct {
@@ -118,12 +169,14 @@ type FrameTextContentOptions struct {
 
 type FrameTypeOptions struct {
 	ElementHandleTypeOptions
-	Strict bool `json:"strict"`
+	Strict    bool `json:"strict"`
+	strictSet bool
 }
 
 type FrameUncheckOptions struct {
 	ElementHandleBasePointerOptions
-	Strict bool `json:"strict"`
+	Strict    bool `json:"strict"`
+	strictSet bool
 }
 
 // PollingType is the type of polling to use.
@@ -193,9 +246,61 @@ type FrameWaitForNavigationOptions struct {
 }
 
 type FrameWaitForSelectorOptions struct {
-	State   DOMElementState `json:"state"`
-	Strict  bool            `json:"strict"`
-	Timeout time.Duration   `json:"timeout"`
+	State     DOMElementState `json:"state"`
+	Strict    bool            `json:"strict"`
+	strictSet bool
+	Timeout   time.Duration `json:"timeout"`
+}
+
+// NewFrameAddScriptTagOptions creates a new FrameAddScriptTagOptions.
+func NewFrameAddScriptTagOptions() *FrameAddScriptTagOptions {
+	return &FrameAddScriptTagOptions{}
+}
+
+// Parse parses the frame addScriptTag options.
+func (o *FrameAddScriptTagOptions) Parse(ctx context.Context, opts sobek.Value) error {
+	if err := parseTagOptions(ctx, opts, o); err != nil {
+		return fmt.Errorf("parsing addScriptTag options: %w", err)
+	}
+	if o.URL == "" && o.Path == "" && o.Content == "" {
+		return fmt.Errorf("parsing addScriptTag options: %w", errTagSourceRequired)
+	}
+
+	return nil
+}
+
+// NewFrameAddStyleTagOptions creates a new FrameAddStyleTagOptions.
+func NewFrameAddStyleTagOptions() *FrameAddStyleTagOptions {
+	return &FrameAddStyleTagOptions{}
+}
+
+// Parse parses the frame addStyleTag options.
+func (o *FrameAddStyleTagOptions) Parse(ctx context.Context, opts sobek.Value) error {
+	if err := parseTagOptions(ctx, opts, o); err != nil {
+		return fmt.Errorf("parsing addStyleTag options: %w", err)
+	}
+	if o.URL == "" && o.Path == "" && o.Content == "" {
+		return fmt.Errorf("parsing addStyleTag options: %w", errTagSourceRequired)
+	}
+
+	return nil
+}
+
+// errTagSourceRequired is returned when the options of an added
+// script or style tag don't have a source.
+var errTagSourceRequired = errors.New("one of url, path or content is required")
+
+// parseTagOptions exports the options of an added script or style tag.
+func parseTagOptions(ctx context.Context, opts sobek.Value, o any) error {
+	if !sobekValueExists(opts) {
+		return nil
+	}
+	rt := k6ext.Runtime(ctx)
+	if err := rt.ExportTo(opts, o); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	return nil
 }
 
 func NewFrameBaseOptions(defaultTimeout time.Duration) *FrameBaseOptions {
@@ -214,6 +319,7 @@ func (o *FrameBaseOptions) Parse(ctx context.Context, opts sobek.Value) error {
 			switch k {
 			case "strict":
 				o.Strict = opts.Get(k).ToBoolean()
+				o.strictSet = true
 			case "timeout":
 				o.Timeout = time.Duration(opts.Get(k).ToInteger()) * time.Millisecond
 			}
@@ -234,7 +340,7 @@ func (o *FrameCheckOptions) Parse(ctx context.Context, opts sobek.Value) error {
 	if err := o.ElementHandleBasePointerOptions.Parse(ctx, opts); err != nil {
 		return err
 	}
-	o.Strict = parseStrict(ctx, opts)
+	o.Strict, o.strictSet = parseStrict(ctx, opts)
 	return nil
 }
 
@@ -250,7 +356,7 @@ func (o *FrameClickOptions) Parse(ctx context.Context, opts sobek.Value) error {
 	if err := o.ElementHandleClickOptions.Parse(ctx, opts); err != nil {
 		return err
 	}
-	o.Strict = parseStrict(ctx, opts)
+	o.Strict, o.strictSet = parseStrict(ctx, opts)
 	return nil
 }
 
@@ -266,7 +372,7 @@ func (o *FrameDblclickOptions) Parse(ctx context.Context, opts sobek.Value) erro
 	if err := o.ElementHandleDblclickOptions.Parse(ctx, opts); err != nil {
 		return err
 	}
-	o.Strict = parseStrict(ctx, opts)
+	o.Strict, o.strictSet = parseStrict(ctx, opts)
 	return nil
 }
 
@@ -282,7 +388,7 @@ func (o *FrameFillOptions) Parse(ctx context.Context, opts sobek.Value) error {
 	if err := o.ElementHandleBaseOptions.Parse(ctx, opts); err != nil {
 		return err
 	}
-	o.Strict = parseStrict(ctx, opts)
+	o.Strict, o.strictSet = parseStrict(ctx, opts)
 	return nil
 }
 
@@ -328,10 +434,61 @@ func (o *FrameHoverOptions) Parse(ctx context.Context, opts sobek.Value) error {
 	if err := o.ElementHandleHoverOptions.Parse(ctx, opts); err != nil {
 		return err
 	}
-	o.Strict = parseStrict(ctx, opts)
+	o.Strict, o.strictSet = parseStrict(ctx, opts)
+	return nil
+}
+
+// NewFrameDragAndDropOptions returns a new FrameDragAndDropOptions.
+func NewFrameDragAndDropOptions(defaultTimeout time.Duration) *FrameDragAndDropOptions {
+	return &FrameDragAndDropOptions{
+		ElementHandleBaseOptions: *NewElementHandleBaseOptions(defaultTimeout),
+	}
+}
+
+// Parse parses the frame drag and drop options.
+func (o *FrameDragAndDropOptions) Parse(ctx context.Context, opts sobek.Value) error {
+	if err := o.ElementHandleBaseOptions.Parse(ctx, opts); err != nil {
+		return err
+	}
+	if opts == nil || sobek.IsUndefined(opts) || sobek.IsNull(opts) {
+		return nil
+	}
+	rt := k6ext.Runtime(ctx)
+	obj := opts.ToObject(rt)
+	for _, k := range obj.Keys() {
+		switch k {
+		case "sourcePosition", "targetPosition":
+			var m map[string]float64
+			if err := rt.ExportTo(obj.Get(k), &m); err != nil {
+				return fmt.Errorf("parsing %s: %w", k, err)
+			}
+			p := &Position{X: m["x"], Y: m["y"]}
+			if k == "sourcePosition" {
+				o.SourcePosition = p
+			} else {
+				o.TargetPosition = p
+			}
+		case "trial":
+			o.Trial = obj.Get(k).ToBoolean()
+		case "strict":
+			o.Strict = obj.Get(k).ToBoolean()
+			o.strictSet = true
+		}
+	}
+
 	return nil
 }
 
+// pointerOptions returns the pointer options for acting on
+// the source or target element at the given position.
+func (o *FrameDragAndDropOptions) pointerOptions(p *Position) *ElementHandleBasePointerOptions {
+	return &ElementHandleBasePointerOptions{
+		ElementHandleBaseOptions: o.ElementHandleBaseOptions,
+		Position:                 p,
+		Trial:                    o.Trial,
+	}
+}
+
 func NewFrameInnerHTMLOptions(defaultTimeout time.Duration) *FrameInnerHTMLOptions {
 	return &FrameInnerHTMLOptions{
 		FrameBaseOptions: *NewFrameBaseOptions(defaultTimeout),
@@ -437,7 +594,7 @@ func NewFrameIsHiddenOptions() *FrameIsHiddenOptions {
 
 // Parse parses FrameIsHiddenOptions from sobek.Value.
 func (o *FrameIsHiddenOptions) Parse(ctx context.Context, opts sobek.Value) error {
-	o.Strict = parseStrict(ctx, opts)
+	o.Strict, o.strictSet = parseStrict(ctx, opts)
 	return nil
 }
 
@@ -448,7 +605,7 @@ func NewFrameIsVisibleOptions() *FrameIsVisibleOptions {
 
 // Parse parses FrameIsVisibleOptions from sobek.Value.
 func (o *FrameIsVisibleOptions) Parse(ctx context.Context, opts sobek.Value) error {
-	o.Strict = parseStrict(ctx, opts)
+	o.Strict, o.strictSet = parseStrict(ctx, opts)
 	return nil
 }
 
@@ -479,7 +636,7 @@ func (o *FrameSelectOptionOptions) Parse(ctx context.Context, opts sobek.Value)
 	if err := o.ElementHandleBaseOptions.Parse(ctx, opts); err != nil {
 		return err
 	}
-	o.Strict = parseStrict(ctx, opts)
+	o.Strict, o.strictSet = parseStrict(ctx, opts)
 	return nil
 }
 
@@ -554,6 +711,7 @@ func (o *FrameTapOptions) Parse(ctx context.Context, opts sobek.Value) error {
 				o.Modifiers = m
 			case "strict":
 				o.Strict = opts.Get(k).ToBoolean()
+				o.strictSet = true
 			}
 		}
 	}
@@ -600,7 +758,7 @@ func (o *FrameUncheckOptions) Parse(ctx context.Context, opts sobek.Value) error
 	if err := o.ElementHandleBasePointerOptions.Parse(ctx, opts); err != nil {
 		return err
 	}
-	o.Strict = parseStrict(ctx, opts)
+	o.Strict, o.strictSet = parseStrict(ctx, opts)
 	return nil
 }
 
@@ -721,6 +879,7 @@ func (o *FrameWaitForSelectorOptions) Parse(ctx context.Context, opts sobek.Valu
 				}
 			case "strict":
 				o.Strict = opts.Get(k).ToBoolean()
+				o.strictSet = true
 			case "timeout":
 				o.Timeout = time.Duration(opts.Get(k).ToInteger()) * time.Millisecond
 			}
@@ -742,18 +901,46 @@ func NewFrameDispatchEventOptions(defaultTimeout time.Duration) *FrameDispatchEv
 	}
 }
 
-func parseStrict(ctx context.Context, opts sobek.Value) bool {
-	var strict bool
+// LocatorOptions are options for Frame.Locator.
+type LocatorOptions struct {
+	// Strict makes the locator's operations fail when the selector
+	// matches more than one element. It's on by default.
+	Strict bool `json:"strict"`
+}
+
+// NewLocatorOptions returns a new LocatorOptions.
+func NewLocatorOptions() *LocatorOptions {
+	return &LocatorOptions{
+		Strict: true,
+	}
+}
+
+// Parse parses the locator options.
+func (o *LocatorOptions) Parse(ctx context.Context, opts sobek.Value) error {
+	if opts != nil && !sobek.IsUndefined(opts) && !sobek.IsNull(opts) {
+		opts := opts.ToObject(k6ext.Runtime(ctx))
+		for _, k := range opts.Keys() {
+			if k == "strict" {
+				o.Strict = opts.Get(k).ToBoolean()
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseStrict returns the strict option, and whether it's set.
+func parseStrict(ctx context.Context, opts sobek.Value) (strict, set bool) {
 
 	rt := k6ext.Runtime(ctx)
 	if opts != nil && !sobek.IsUndefined(opts) && !sobek.IsNull(opts) {
 		opts := opts.ToObject(rt)
 		for _, k := range opts.Keys() {
 			if k == "strict" {
-				strict = opts.Get(k).ToBoolean()
+				strict, set = opts.Get(k).ToBoolean(), true
 			}
 		}
 	}
 
-	return strict
+	return strict, set
 }
@@ -119,3 +119,41 @@ func TestFrameWaitForNavigationOptionsParse(t *testing.T) {
 				`load, domcontentloaded, networkidle`)
 	})
 }
+
+func TestLocatorOptionsParse(t *testing.T) {
+	t.Parallel()
+
+	vu := k6test.NewVU(t)
+
+	opts := NewLocatorOptions()
+	require.NoError(t, opts.Parse(vu.Context(), nil))
+	assert.True(t, opts.Strict)
+
+	opts = NewLocatorOptions()
+	require.NoError(t, opts.Parse(vu.Context(), vu.ToSobekValue(map[string]any{"strict": false})))
+	assert.False(t, opts.Strict)
+}
+
+func TestFrameStrictOptionParse(t *testing.T) {
+	t.Parallel()
+
+	vu := k6test.NewVU(t)
+
+	opts := NewFrameFillOptions(0)
+	require.NoError(t, opts.Parse(vu.Context(), nil))
+	assert.False(t, opts.Strict)
+	assert.False(t, opts.strictSet)
+
+	opts = NewFrameFillOptions(0)
+	require.NoError(t, opts.Parse(vu.Context(), vu.ToSobekValue(map[string]any{"strict": false})))
+	assert.False(t, opts.Strict)
+	assert.True(t, opts.strictSet)
+
+	// An explicit strict option overrides the browser context's,
+	// and an unset one is strict if either is on.
+	var f *Frame
+	assert.False(t, f.isStrict(false, true))
+	assert.True(t, f.isStrict(true, true))
+	assert.True(t, f.isStrict(true, false))
+	assert.False(t, f.isStrict(false, false))
+}
@@ -307,8 +307,14 @@ func (fs *FrameSession) initEvents() {
 					fs.onDetachedFromTarget(ev)
 				case *cdppage.EventJavascriptDialogOpening:
 					fs.onEventJavascriptDialogOpening(ev)
+				case *cdppage.EventFileChooserOpened:
+					// adopting the file input element waits for CDP
+					// responses, so don't block the event loop.
+					go fs.page.onFileChooserOpened(ev)
 				case *cdpruntime.EventBindingCalled:
 					fs.onEventBindingCalled(ev)
+				case *cdppage.EventScreencastFrame:
+					fs.onScreencastFrame(ev)
 				}
 			}
 		}
@@ -320,9 +326,16 @@ func (fs *FrameSession) onEventBindingCalled(event *cdpruntime.EventBindingCalle
 		"sid:%v tid:%v name:%s payload:%s",
 		fs.session.ID(), fs.targetID, event.Name, event.Payload)
 
-	err := fs.parseAndEmitWebVitalMetric(event.Payload)
-	if err != nil {
-		fs.logger.Errorf("FrameSession:onEventBindingCalled", "failed to emit web vital metric: %v", err)
+	switch event.Name {
+	case webVitalBinding:
+		err := fs.parseAndEmitWebVitalMetric(event.Payload)
+		if err != nil {
+			fs.logger.Errorf("FrameSession:onEventBindingCalled", "failed to emit web vital metric: %v", err)
+		}
+	case exposedBinding:
+		// The binding function waits for the VU's event loop,
+		// so it's called outside of the event loop of the session.
+		go fs.page.onBindingCalled(event)
 	}
 }
 
@@ -390,6 +403,13 @@ func (fs *FrameSession) onEventJavascriptDialogOpening(event *cdppage.EventJavas
 		"sid:%v tid:%v url:%v dialogType:%s",
 		fs.session.ID(), fs.targetID, event.URL, event.Type)
 
+	// Let the page.on('dialog') handlers handle the dialog,
+	// and only handle it here if there are no handlers.
+	dialog := NewDialog(fs.ctx, fs.session, string(event.Type), event.Message, event.DefaultPrompt)
+	if fs.page.onDialog(dialog) {
+		return
+	}
+
 	// Dialog type of beforeunload needs to accept the
 	// dialog, instead of dismissing it. We're unable to
 	// dismiss beforeunload dialog boxes at the moment as
@@ -510,6 +530,9 @@ func (fs *FrameSession) initOptions() error {
 	if opts.BypassCSP {
 		optActions = append(optActions, cdppage.SetBypassCSP(true))
 	}
+	if fs.page.interceptsFileChooser.Load() {
+		optActions = append(optActions, cdppage.SetInterceptFileChooserDialog(true))
+	}
 	if opts.IgnoreHTTPSErrors {
 		optActions = append(optActions, security.SetIgnoreCertificateErrors(true))
 	}
@@ -583,6 +606,7 @@ func (fs *FrameSession) initRendererEvents() {
 		cdproto.EventPageJavascriptDialogOpening,
 		cdproto.EventPageLifecycleEvent,
 		cdproto.EventPageNavigatedWithinDocument,
+		cdproto.EventPageScreencastFrame,
 		cdproto.EventRuntimeConsoleAPICalled,
 		cdproto.EventRuntimeExceptionThrown,
 		cdproto.EventRuntimeExecutionContextCreated,
@@ -661,7 +685,7 @@ func (fs *FrameSession) onConsoleAPICalled(event *cdpruntime.EventConsoleAPICall
 }
 
 func (fs *FrameSession) onExceptionThrown(event *cdpruntime.EventExceptionThrown) {
-	fs.page.emit(EventPageError, event.ExceptionDetails)
+	fs.page.onPageError(event.ExceptionDetails)
 }
 
 func (fs *FrameSession) onExecutionContextCreated(event *cdpruntime.EventExecutionContextCreated) {
@@ -0,0 +1,264 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// Delay distributions for humanized keystrokes.
+const (
+	DelayDistributionNormal    = "normal"
+	DelayDistributionLogNormal = "lognormal"
+	DelayDistributionUniform   = "uniform"
+)
+
+// Humanize configures the human-like input mode of the mouse and the
+// keyboard. When set in the browser context options, the mouse moves
+// along curved paths with varying velocity, and the keyboard types with
+// randomized per-keystroke delays and occasionally mistypes a character
+// and corrects it with Backspace. Zero fields take their default values.
+type Humanize struct {
+	// MouseSpeed is the average speed of the mouse pointer in pixels
+	// per second.
+	MouseSpeed float64 `js:"mouseSpeed"`
+	// KeystrokeDelay is the distribution of the delays between
+	// keystrokes.
+	KeystrokeDelay DelayDistribution `js:"keystrokeDelay"`
+	// TypoRate is the probability of mistyping a character.
+	// Set it to zero to disable typos.
+	TypoRate *float64 `js:"typoRate"`
+	// Seed seeds the random number generator to make the input
+	// reproducible. Zero means a random seed.
+	Seed int64 `js:"seed"`
+}
+
+// DelayDistribution is a distribution of delays in milliseconds.
+// Delays are clamped to the [Min, Max] range.
+type DelayDistribution struct {
+	// Distribution is one of normal, lognormal or uniform.
+	Distribution string  `js:"distribution"`
+	Mean         float64 `js:"mean"`
+	StdDev       float64 `js:"stdDev"`
+	Min          float64 `js:"min"`
+	Max          float64 `js:"max"`
+}
+
+// Default humanize options.
+const (
+	DefaultHumanizeMouseSpeed   = 1500.0
+	DefaultHumanizeTypoRate     = 0.02
+	DefaultKeystrokeDelayMean   = 120.0
+	DefaultKeystrokeDelayStdDev = 40.0
+	DefaultKeystrokeDelayMin    = 30.0
+	DefaultKeystrokeDelayMax    = 600.0
+)
+
+// Validate validates the [Humanize] options.
+func (h *Humanize) Validate() error {
+	if h.MouseSpeed < 0 {
+		return fmt.Errorf("invalid humanize mouseSpeed %.2f: must be positive", h.MouseSpeed)
+	}
+	if r := h.TypoRate; r != nil && (*r < 0 || *r > 1) {
+		return fmt.Errorf("invalid humanize typoRate %.2f: must be between 0 and 1", *r)
+	}
+	d := h.KeystrokeDelay
+	switch d.Distribution {
+	case "", DelayDistributionNormal, DelayDistributionLogNormal, DelayDistributionUniform:
+	default:
+		return fmt.Errorf("invalid humanize keystrokeDelay distribution %q", d.Distribution)
+	}
+	if d.Mean < 0 || d.StdDev < 0 || d.Min < 0 || d.Max < 0 {
+		return errors.New("invalid humanize keystrokeDelay: values must be positive")
+	}
+	if d.Max > 0 && d.Min > d.Max {
+		return fmt.Errorf("invalid humanize keystrokeDelay: min %.2f is greater than max %.2f", d.Min, d.Max)
+	}
+
+	return nil
+}
+
+// humanizer generates human-like input for the mouse and the keyboard
+// of a page. It is safe for concurrent use.
+type humanizer struct {
+	opts     Humanize
+	typoRate float64
+
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+// newHumanizer returns a humanizer with the given options, or nil if
+// the options are nil, which means that humanized input is disabled.
+func newHumanizer(h *Humanize) *humanizer {
+	if h == nil {
+		return nil
+	}
+	opts := *h
+	if opts.MouseSpeed == 0 {
+		opts.MouseSpeed = DefaultHumanizeMouseSpeed
+	}
+	typoRate := DefaultHumanizeTypoRate
+	if opts.TypoRate != nil {
+		typoRate = *opts.TypoRate
+	}
+	d := &opts.KeystrokeDelay
+	if d.Distribution == "" {
+		d.Distribution = DelayDistributionNormal
+	}
+	if d.Mean == 0 {
+		d.Mean = DefaultKeystrokeDelayMean
+	}
+	if d.StdDev == 0 {
+		d.StdDev = DefaultKeystrokeDelayStdDev
+	}
+	if d.Min == 0 {
+		d.Min = math.Min(DefaultKeystrokeDelayMin, d.Mean)
+	}
+	if d.Max == 0 {
+		d.Max = math.Max(DefaultKeystrokeDelayMax, d.Mean)
+	}
+	seed := opts.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	return &humanizer{
+		opts:     opts,
+		typoRate: typoRate,
+		rnd:      rand.New(rand.NewSource(seed)), //nolint:gosec
+	}
+}
+
+// float64 returns a random number in [0, 1).
+func (h *humanizer) float64() float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.rnd.Float64()
+}
+
+// normFloat64 returns a normally distributed random number.
+func (h *humanizer) normFloat64() float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.rnd.NormFloat64()
+}
+
+// keystrokeDelay returns the delay before the next keystroke.
+func (h *humanizer) keystrokeDelay() time.Duration {
+	d := h.opts.KeystrokeDelay
+
+	var ms float64
+	switch d.Distribution {
+	case DelayDistributionUniform:
+		ms = d.Min + h.float64()*(d.Max-d.Min)
+	case DelayDistributionLogNormal:
+		sigma2 := math.Log(1 + (d.StdDev*d.StdDev)/(d.Mean*d.Mean))
+		mu := math.Log(d.Mean) - sigma2/2
+		ms = math.Exp(mu + math.Sqrt(sigma2)*h.normFloat64())
+	default:
+		ms = d.Mean + d.StdDev*h.normFloat64()
+	}
+	ms = math.Max(d.Min, math.Min(d.Max, ms))
+
+	return time.Duration(ms * float64(time.Millisecond))
+}
+
+// qwertyNeighbors are the adjacent keys of the letters and digits on
+// a QWERTY keyboard, which are the likely typos of a character.
+var qwertyNeighbors = map[rune]string{ //nolint:gochecknoglobals
+	'1': "2q", '2': "13w", '3': "24e", '4': "35r", '5': "46t",
+	'6': "57y", '7': "68u", '8': "79i", '9': "80o", '0': "9p",
+	'q': "wa", 'w': "qes", 'e': "wrd", 'r': "etf", 't': "ryg",
+	'y': "tuh", 'u': "yij", 'i': "uok", 'o': "ipl", 'p': "o",
+	'a': "qsz", 's': "awdx", 'd': "sefc", 'f': "drgv", 'g': "fthb",
+	'h': "gyjn", 'j': "hukm", 'k': "jil", 'l': "ko",
+	'z': "ax", 'x': "zsc", 'c': "xdv", 'v': "cfb", 'b': "vgn",
+	'n': "bhm", 'm': "nj",
+}
+
+// typo returns a mistyped character for c, and true if c should be
+// mistyped before typing it.
+func (h *humanizer) typo(c rune) (rune, bool) {
+	neighbors, ok := qwertyNeighbors[unicode.ToLower(c)]
+	if !ok || h.float64() >= h.typoRate {
+		return 0, false
+	}
+	rs := []rune(neighbors)
+	t := rs[int(h.float64()*float64(len(rs)))]
+	if unicode.IsUpper(c) {
+		t = unicode.ToUpper(t)
+	}
+
+	return t, true
+}
+
+// pathPoint is a point on a humanized mouse path, and the delay
+// before the mouse pointer is moved to it.
+type pathPoint struct {
+	Position
+	delay time.Duration
+}
+
+// mousePath returns the points of a curved path from one position to
+// another. The path is a cubic Bezier curve with randomly placed
+// control points, and it is traversed with a minimum-jerk velocity
+// profile: slow at the start and the end, and fast in the middle.
+func (h *humanizer) mousePath(from, to Position) []pathPoint {
+	dx, dy := to.X-from.X, to.Y-from.Y
+	dist := math.Hypot(dx, dy)
+	if dist < 1 {
+		return []pathPoint{{Position: to}}
+	}
+
+	// Place the control points around the straight line at a random
+	// distance, which is proportional to the distance between the
+	// two positions.
+	nx, ny := -dy/dist, dx/dist
+	spread := dist * 0.3
+	o1 := spread * (h.float64()*2 - 1)
+	o2 := spread * (h.float64()*2 - 1)
+	c1 := Position{X: from.X + dx*0.3 + nx*o1, Y: from.Y + dy*0.3 + ny*o1}
+	c2 := Position{X: from.X + dx*0.7 + nx*o2, Y: from.Y + dy*0.7 + ny*o2}
+
+	const (
+		minSteps = 5
+		maxSteps = 100
+	)
+	steps := int(math.Max(minSteps, math.Min(maxSteps, dist/10)))
+	// The duration varies by ±20% around the average speed.
+	duration := dist / h.opts.MouseSpeed * (0.8 + h.float64()*0.4)
+	delay := time.Duration(duration / float64(steps) * float64(time.Second))
+
+	points := make([]pathPoint, 0, steps)
+	for i := 1; i <= steps; i++ {
+		t := minimumJerk(float64(i) / float64(steps))
+		points = append(points, pathPoint{
+			Position: cubicBezier(from, c1, c2, to, t),
+			delay:    delay,
+		})
+	}
+	// Make sure that the pointer lands exactly on the target.
+	points[len(points)-1].Position = to
+
+	return points
+}
+
+// minimumJerk maps the elapsed time fraction to the travelled distance
+// fraction of a minimum-jerk movement.
+func minimumJerk(t float64) float64 {
+	return t * t * t * (10 - 15*t + 6*t*t)
+}
+
+func cubicBezier(p0, p1, p2, p3 Position, t float64) Position {
+	u := 1 - t
+	a, b, c, d := u*u*u, 3*u*u*t, 3*u*t*t, t*t*t
+	return Position{
+		X: a*p0.X + b*p1.X + c*p2.X + d*p3.X,
+		Y: a*p0.Y + b*p1.Y + c*p2.Y + d*p3.Y,
+	}
+}
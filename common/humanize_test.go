@@ -0,0 +1,167 @@
+package common
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHumanizeValidate(t *testing.T) {
+	t.Parallel()
+
+	rate := func(r float64) *float64 { return &r }
+
+	tests := []struct {
+		name    string
+		opts    Humanize
+		wantErr string
+	}{
+		{
+			name: "defaults",
+		},
+		{
+			name: "valid",
+			opts: Humanize{
+				MouseSpeed: 800,
+				TypoRate:   rate(0),
+				KeystrokeDelay: DelayDistribution{
+					Distribution: DelayDistributionLogNormal, Mean: 100, StdDev: 30, Min: 20, Max: 400,
+				},
+			},
+		},
+		{
+			name:    "negative_mouse_speed",
+			opts:    Humanize{MouseSpeed: -1},
+			wantErr: "invalid humanize mouseSpeed",
+		},
+		{
+			name:    "typo_rate_out_of_range",
+			opts:    Humanize{TypoRate: rate(1.5)},
+			wantErr: "invalid humanize typoRate",
+		},
+		{
+			name:    "unknown_distribution",
+			opts:    Humanize{KeystrokeDelay: DelayDistribution{Distribution: "poisson"}},
+			wantErr: `distribution "poisson"`,
+		},
+		{
+			name:    "min_greater_than_max",
+			opts:    Humanize{KeystrokeDelay: DelayDistribution{Min: 100, Max: 50}},
+			wantErr: "min 100.00 is greater than max 50.00",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.opts.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestHumanizerKeystrokeDelay(t *testing.T) {
+	t.Parallel()
+
+	for _, dist := range []string{
+		DelayDistributionNormal, DelayDistributionLogNormal, DelayDistributionUniform,
+	} {
+		dist := dist
+		t.Run(dist, func(t *testing.T) {
+			t.Parallel()
+
+			h := newHumanizer(&Humanize{
+				Seed: 1,
+				KeystrokeDelay: DelayDistribution{
+					Distribution: dist, Mean: 100, StdDev: 50, Min: 40, Max: 200,
+				},
+			})
+			var (
+				sum      time.Duration
+				distinct = make(map[time.Duration]bool)
+			)
+			const n = 1000
+			for i := 0; i < n; i++ {
+				d := h.keystrokeDelay()
+				require.GreaterOrEqual(t, d, 40*time.Millisecond)
+				require.LessOrEqual(t, d, 200*time.Millisecond)
+				sum += d
+				distinct[d] = true
+			}
+			assert.Greater(t, len(distinct), n/2, "delays should vary")
+			mean := sum / n
+			assert.InDelta(t, 110*time.Millisecond, mean, float64(30*time.Millisecond))
+		})
+	}
+}
+
+func TestHumanizerTypo(t *testing.T) {
+	t.Parallel()
+
+	always := 1.0
+	h := newHumanizer(&Humanize{Seed: 1, TypoRate: &always})
+
+	typo, ok := h.typo('s')
+	require.True(t, ok)
+	assert.Contains(t, qwertyNeighbors['s'], string(typo))
+
+	typo, ok = h.typo('S')
+	require.True(t, ok)
+	assert.Contains(t, "AWDX", string(typo))
+
+	_, ok = h.typo('!')
+	assert.False(t, ok, "characters without neighbors should not be mistyped")
+
+	never := 0.0
+	h = newHumanizer(&Humanize{Seed: 1, TypoRate: &never})
+	for _, c := range "the quick brown fox" {
+		_, ok := h.typo(c)
+		assert.False(t, ok)
+	}
+}
+
+func TestHumanizerMousePath(t *testing.T) {
+	t.Parallel()
+
+	h := newHumanizer(&Humanize{Seed: 1, MouseSpeed: 1000})
+	from, to := Position{X: 10, Y: 10}, Position{X: 510, Y: 310}
+
+	path := h.mousePath(from, to)
+	require.GreaterOrEqual(t, len(path), 5)
+	assert.Equal(t, to, path[len(path)-1].Position, "path should end at the target")
+
+	var (
+		total    time.Duration
+		straight = true
+		prev     = from
+		steps    []float64
+	)
+	for _, p := range path {
+		total += p.delay
+		// distance of the point from the straight line
+		d := math.Abs((to.Y-from.Y)*p.X-(to.X-from.X)*p.Y+to.X*from.Y-to.Y*from.X) /
+			math.Hypot(to.X-from.X, to.Y-from.Y)
+		if d > 1 {
+			straight = false
+		}
+		steps = append(steps, math.Hypot(p.X-prev.X, p.Y-prev.Y))
+		prev = p.Position
+	}
+	assert.False(t, straight, "path should be curved")
+	// 583px at 1000px/s takes 583ms ±20%
+	assert.InDelta(t, 583*time.Millisecond, total, float64(120*time.Millisecond))
+	// the pointer should be faster in the middle than at the ends
+	mid := steps[len(steps)/2]
+	assert.Greater(t, mid, steps[0])
+	assert.Greater(t, mid, steps[len(steps)-1])
+
+	assert.Len(t, h.mousePath(to, to), 1)
+}
@@ -17,3 +17,15 @@ var WebVitalIIFEScript string
 //
 //go:embed web_vital_init.js
 var WebVitalInitScript string
+
+// ExposedBindingScript exposes a binding to the page
+// as a function that can be called by the page.
+//
+//go:embed expose_binding.js
+var ExposedBindingScript string
+
+// SnapshotScript serializes the document of a page
+// into a DOM snapshot of a trace.
+//
+//go:embed snapshot.js
+var SnapshotScript string
@@ -0,0 +1,46 @@
+// Exposes a k6 binding to the page as a global function of the given
+// name. Calling the function sends its name, its arguments and a call
+// sequence number to k6 with the binding of bindingName, and returns a
+// promise that is settled when k6 delivers the result of the call.
+(bindingName, name) => {
+  const binding = globalThis[bindingName];
+  if (!binding) {
+    return;
+  }
+  if (!globalThis.__k6browserBindings) {
+    Object.defineProperty(globalThis, '__k6browserBindings', {
+      value: new Map(),
+      enumerable: false,
+    });
+  }
+  const bindings = globalThis.__k6browserBindings;
+  if (bindings.has(name)) {
+    return;
+  }
+
+  const callbacks = new Map();
+  let lastSeq = 0;
+  bindings.set(name, {
+    deliver(seq, result, error) {
+      const callback = callbacks.get(seq);
+      if (!callback) {
+        return;
+      }
+      callbacks.delete(seq);
+      if (error !== null) {
+        callback.reject(new Error(error));
+      } else {
+        callback.resolve(result);
+      }
+    },
+  });
+
+  globalThis[name] = (...args) => {
+    const seq = ++lastSeq;
+    const promise = new Promise((resolve, reject) => {
+      callbacks.set(seq, { resolve, reject });
+    });
+    binding(JSON.stringify({ name, seq, args }));
+    return promise;
+  };
+}
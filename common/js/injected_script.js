@@ -106,15 +106,491 @@ function oneLine(s) {
   return s.replace(/\n/g, "↵").replace(/\t/g, "⇆");
 }
 
+function normalizeWhiteSpace(text) {
+  return text.replace(/\s+/g, " ").trim();
+}
+
+// unquote strips the quotes of a quoted CSS argument or text selector body
+// and unescapes the escaped characters within it.
+function unquote(s) {
+  s = s.trim();
+  if (
+    s.length >= 2 &&
+    (s[0] === '"' || s[0] === "'") &&
+    s[s.length - 1] === s[0]
+  ) {
+    return s.substring(1, s.length - 1).replace(/\\(.)/g, "$1");
+  }
+  return s;
+}
+
+// splitTopLevel splits s at the separator characters that are not
+// enclosed in quotes, parentheses or brackets.
+function splitTopLevel(s, separator) {
+  const parts = [];
+  let depth = 0;
+  let quote = "";
+  let start = 0;
+  for (let i = 0; i < s.length; i++) {
+    const c = s[i];
+    if (quote) {
+      if (c === "\\") {
+        i++;
+      } else if (c === quote) {
+        quote = "";
+      }
+    } else if (c === '"' || c === "'") {
+      quote = c;
+    } else if (c === "(" || c === "[") {
+      depth++;
+    } else if (c === ")" || c === "]") {
+      depth--;
+    } else if (c === separator && depth === 0) {
+      parts.push(s.substring(start, i));
+      start = i + 1;
+    }
+  }
+  parts.push(s.substring(start));
+  return parts;
+}
+
+// TextMatcher matches the text of elements against a text selector.
+// Strict matchers require the whole normalized text to be equal, lax
+// matchers look for a case-insensitive substring, and regular expression
+// matchers test the full text of the element.
+class TextMatcher {
+  constructor(kind, text, flags) {
+    this._kind = kind;
+    if (kind === "regex") {
+      this._re = new RegExp(text, flags);
+    } else if (kind === "strict") {
+      this._text = normalizeWhiteSpace(text);
+    } else {
+      this._text = normalizeWhiteSpace(text).toLowerCase();
+    }
+  }
+
+  // fromSelector creates a matcher from a text engine selector body such
+  // as "foo", 'foo', /foo/i or foo.
+  static fromSelector(body) {
+    body = body.trim();
+    const re = /^\/(.*)\/([a-z]*)$/s.exec(body);
+    if (re) {
+      return new TextMatcher("regex", re[1], re[2]);
+    }
+    const unquoted = unquote(body);
+    return new TextMatcher(unquoted === body ? "lax" : "strict", unquoted);
+  }
+
+  matches(text) {
+    if (this._kind === "regex") {
+      this._re.lastIndex = 0;
+      return this._re.test(text);
+    }
+    text = normalizeWhiteSpace(text);
+    if (this._kind === "strict") {
+      return text === this._text;
+    }
+    return text.toLowerCase().includes(this._text);
+  }
+}
+
+// elementText returns the text of the element and its descendants,
+// including the ones in its shadow root, but excluding the contents of
+// scripts and styles.
+function elementText(cache, root) {
+  let text = cache.get(root);
+  if (text !== undefined) {
+    return text;
+  }
+  text = "";
+  const nodes = root.shadowRoot
+    ? [...root.shadowRoot.childNodes, ...root.childNodes]
+    : root.childNodes;
+  for (const child of nodes) {
+    if (child.nodeType === 3 /*Node.TEXT_NODE*/) {
+      text += child.nodeValue;
+    } else if (
+      child.nodeType === 1 /*Node.ELEMENT_NODE*/ &&
+      !["SCRIPT", "NOSCRIPT", "STYLE", "HEAD"].includes(child.nodeName)
+    ) {
+      text += elementText(cache, child);
+    }
+  }
+  cache.set(root, text);
+  return text;
+}
+
+// elementMatchesText returns "none" if the element's text doesn't match,
+// "selfAndChildren" if one of its children matches as well, and "self" if
+// the element is the smallest one that matches.
+function elementMatchesText(cache, element, matcher) {
+  if (["SCRIPT", "NOSCRIPT", "STYLE", "HEAD"].includes(element.nodeName)) {
+    return "none";
+  }
+  if (!matcher.matches(elementText(cache, element))) {
+    return "none";
+  }
+  const children = element.shadowRoot
+    ? [...element.shadowRoot.children, ...element.children]
+    : element.children;
+  for (const child of children) {
+    if (matcher.matches(elementText(cache, child))) {
+      return "selfAndChildren";
+    }
+  }
+  return "self";
+}
+
+// Layout scorers return the distance between the element's box and the
+// box of the element it's positioned against, or undefined if the
+// element is not positioned as expected.
+const layoutScorers = {
+  "left-of": (box, inner, maxDistance) => {
+    const distance = inner.left - box.right;
+    if (distance < 0 || distance > maxDistance) {
+      return undefined;
+    }
+    return distance + verticalGap(box, inner);
+  },
+  "right-of": (box, inner, maxDistance) => {
+    const distance = box.left - inner.right;
+    if (distance < 0 || distance > maxDistance) {
+      return undefined;
+    }
+    return distance + verticalGap(box, inner);
+  },
+  above: (box, inner, maxDistance) => {
+    const distance = inner.top - box.bottom;
+    if (distance < 0 || distance > maxDistance) {
+      return undefined;
+    }
+    return distance + horizontalGap(box, inner);
+  },
+  below: (box, inner, maxDistance) => {
+    const distance = box.top - inner.bottom;
+    if (distance < 0 || distance > maxDistance) {
+      return undefined;
+    }
+    return distance + horizontalGap(box, inner);
+  },
+  near: (box, inner, maxDistance) => {
+    const distance = Math.max(
+      horizontalGap(box, inner),
+      verticalGap(box, inner)
+    );
+    if (distance > maxDistance) {
+      return undefined;
+    }
+    return distance;
+  },
+};
+
+// defaultNearDistance is the maximum distance in CSS pixels between the
+// elements matched by the :near() pseudo-class when not specified.
+const defaultNearDistance = 50;
+
+function horizontalGap(box1, box2) {
+  return Math.max(box1.left - box2.right, box2.left - box1.right, 0);
+}
+
+function verticalGap(box1, box2) {
+  return Math.max(box1.top - box2.bottom, box2.top - box1.bottom, 0);
+}
+
+const textPseudoClasses = new Set(["has-text", "text-is", "text-matches"]);
+const customPseudoClasses = new Set([
+  ...textPseudoClasses,
+  "visible",
+  ...Object.keys(layoutScorers),
+]);
+
+// parseCSS splits a CSS selector list into complex selectors, and each
+// complex selector into compound selectors joined by combinators. The
+// pseudo-classes that browsers don't support are separated from the
+// native part of each compound selector so that they can be evaluated
+// by CSSQueryEngine.
+function parseCSS(selector) {
+  return splitTopLevel(selector, ",").map((complex) => {
+    const compounds = [];
+    let compound = { combinator: "", native: "", custom: [] };
+    let combinator = "";
+    let i = 0;
+
+    const endCompound = () => {
+      if (compound.native === "" && compound.custom.length === 0) {
+        return;
+      }
+      compounds.push(compound);
+      compound = { combinator: "", native: "", custom: [] };
+    };
+    // readBalanced reads from an opening parenthesis or bracket up to the
+    // matching closing one and returns the text in between.
+    const readBalanced = () => {
+      const start = i;
+      let depth = 0;
+      let quote = "";
+      for (; i < complex.length; i++) {
+        const c = complex[i];
+        if (quote) {
+          if (c === "\\") {
+            i++;
+          } else if (c === quote) {
+            quote = "";
+          }
+        } else if (c === '"' || c === "'") {
+          quote = c;
+        } else if (c === "(" || c === "[") {
+          depth++;
+        } else if (c === ")" || c === "]") {
+          depth--;
+          if (depth === 0) {
+            i++;
+            return complex.substring(start + 1, i - 1);
+          }
+        }
+      }
+      throw new Error(`unterminated "${complex.substring(start)}" in "${selector}"`);
+    };
+
+    while (i < complex.length) {
+      const c = complex[i];
+      if (/\s/.test(c) || c === ">" || c === "+" || c === "~") {
+        if (/\s/.test(c)) {
+          combinator = combinator || " ";
+        } else {
+          combinator = c;
+        }
+        endCompound();
+        i++;
+        continue;
+      }
+      if (compound.native === "" && compound.custom.length === 0) {
+        compound.combinator = compounds.length ? combinator : "";
+        combinator = "";
+      }
+      if (c === "(" || c === "[") {
+        const start = i;
+        readBalanced();
+        compound.native += complex.substring(start, i);
+        continue;
+      }
+      if (c === '"' || c === "'") {
+        const start = i;
+        for (i++; i < complex.length && complex[i] !== c; i++) {
+          if (complex[i] === "\\") {
+            i++;
+          }
+        }
+        i++;
+        compound.native += complex.substring(start, i);
+        continue;
+      }
+      if (c === "\\") {
+        compound.native += complex.substring(i, i + 2);
+        i += 2;
+        continue;
+      }
+      if (c === ":" && complex[i + 1] !== ":") {
+        const name = /^[a-zA-Z-]*/.exec(complex.substring(i + 1))[0];
+        if (customPseudoClasses.has(name)) {
+          i += 1 + name.length;
+          let arg;
+          if (complex[i] === "(") {
+            arg = readBalanced();
+          }
+          compound.custom.push({ name, arg });
+          continue;
+        }
+      }
+      compound.native += c;
+      i++;
+    }
+    endCompound();
+
+    return compounds;
+  });
+}
+
 class CSSQueryEngine {
   queryAll(root, selector) {
-    return root.querySelectorAll(selector);
+    const complexes = parseCSS(selector);
+    const hasCustom = complexes.some((compounds) =>
+      compounds.some((compound) => compound.custom.length)
+    );
+    if (!hasCustom) {
+      return root.querySelectorAll(selector);
+    }
+
+    const textCache = new Map();
+    const result = new Set();
+    for (const compounds of complexes) {
+      for (const element of this._queryComplex(root, compounds, textCache)) {
+        result.add(element);
+      }
+    }
+    return [...result];
+  }
+
+  _queryComplex(root, compounds, textCache) {
+    if (!compounds.length) {
+      return [];
+    }
+    const last = compounds[compounds.length - 1];
+    const candidates = root.querySelectorAll(last.native || "*");
+
+    const scored = [];
+    for (const element of candidates) {
+      const score = this._matchesCompound(root, element, last, textCache);
+      if (
+        score !== undefined &&
+        this._matchesChain(root, element, compounds, compounds.length - 1, textCache)
+      ) {
+        scored.push({ element, score });
+      }
+    }
+    // Elements matched by layout pseudo-classes are sorted by their
+    // distance to the elements they're positioned against.
+    scored.sort((a, b) => a.score - b.score);
+    return scored.map((s) => s.element);
+  }
+
+  // _matchesChain checks whether the ancestors or siblings of the element
+  // match the compound selectors that come before the one at index.
+  _matchesChain(root, element, compounds, index, textCache) {
+    if (index === 0) {
+      return true;
+    }
+    const prev = compounds[index - 1];
+    const matches = (e) =>
+      this._matchesCompound(root, e, prev, textCache) !== undefined &&
+      this._matchesChain(root, e, compounds, index - 1, textCache);
+
+    switch (compounds[index].combinator) {
+      case ">": {
+        const parent = element.parentElement;
+        return !!parent && matches(parent);
+      }
+      case "+": {
+        const sibling = element.previousElementSibling;
+        return !!sibling && matches(sibling);
+      }
+      case "~": {
+        for (
+          let e = element.previousElementSibling;
+          e;
+          e = e.previousElementSibling
+        ) {
+          if (matches(e)) {
+            return true;
+          }
+        }
+        return false;
+      }
+      default: {
+        for (let e = element.parentElement; e; e = e.parentElement) {
+          if (matches(e)) {
+            return true;
+          }
+        }
+        return false;
+      }
+    }
+  }
+
+  // _matchesCompound returns the layout score of the element if it matches
+  // the compound selector, or undefined otherwise. Elements that match a
+  // compound selector without layout pseudo-classes have a score of 0.
+  _matchesCompound(root, element, compound, textCache) {
+    if (compound.native && !element.matches(compound.native)) {
+      return undefined;
+    }
+    let score = 0;
+    for (const { name, arg } of compound.custom) {
+      if (name === "visible") {
+        if (!isVisible(element)) {
+          return undefined;
+        }
+        continue;
+      }
+      if (textPseudoClasses.has(name)) {
+        const args = splitTopLevel(arg || "", ",").map(unquote);
+        const matcher =
+          name === "has-text"
+            ? new TextMatcher("lax", args[0])
+            : name === "text-is"
+            ? new TextMatcher("strict", args[0])
+            : new TextMatcher("regex", args[0], args[1]);
+        const kind = elementMatchesText(textCache, element, matcher);
+        if (kind === "none" || (name !== "has-text" && kind !== "self")) {
+          return undefined;
+        }
+        continue;
+      }
+      const layoutScore = this._layoutScore(root, element, name, arg);
+      if (layoutScore === undefined) {
+        return undefined;
+      }
+      score += layoutScore;
+    }
+    return score;
+  }
+
+  _layoutScore(root, element, name, arg) {
+    const args = splitTopLevel(arg || "", ",");
+    let maxDistance = name === "near" ? defaultNearDistance : Infinity;
+    if (args.length > 1 && /^\s*\d+(\.\d+)?\s*$/.test(args[args.length - 1])) {
+      maxDistance = parseFloat(args.pop());
+    }
+    const inner = this.queryAll(root, args.join(","));
+    const box = element.getBoundingClientRect();
+    let best;
+    for (const e of inner) {
+      if (e === element) {
+        continue;
+      }
+      const score = layoutScorers[name](
+        box,
+        e.getBoundingClientRect(),
+        maxDistance
+      );
+      if (score !== undefined && (best === undefined || score < best)) {
+        best = score;
+      }
+    }
+    return best;
   }
 }
 
+// TextQueryEngine finds the smallest elements whose text matches the
+// selector.
 class TextQueryEngine {
   queryAll(root, selector) {
-    return root.queryAll(selector);
+    const matcher = TextMatcher.fromSelector(selector);
+    const cache = new Map();
+    const result = [];
+    for (const element of root.querySelectorAll("*")) {
+      if (elementMatchesText(cache, element, matcher) === "self") {
+        result.push(element);
+      }
+    }
+    return result;
+  }
+}
+
+// CustomQueryEngine adapts a user registered selector engine to the query
+// engine interface. Engines may implement query, queryAll or both.
+class CustomQueryEngine {
+  constructor(engine) {
+    this._engine = engine;
+  }
+
+  queryAll(root, selector) {
+    if (typeof this._engine.queryAll === "function") {
+      return Array.from(this._engine.queryAll(root, selector) || []);
+    }
+    const element = this._engine.query(root, selector);
+    return element ? [element] : [];
   }
 }
 
@@ -193,7 +669,7 @@ function copyNodesToDocument(sourceNode, targetNode) {
 }
 
 class InjectedScript {
-  constructor() {
+  constructor(customEngines = []) {
     this._replaceRafWithTimeout = false;
     this._stableRafCount = 10;
     this._queryEngines = {
@@ -201,6 +677,9 @@ class InjectedScript {
       text: new TextQueryEngine(),
       xpath: new XPathQueryEngine(),
     };
+    for (const { name, engine } of customEngines) {
+      this._queryEngines[name] = new CustomQueryEngine(engine);
+    }
   }
 
   _queryEngineAll(part, root) {
@@ -214,26 +693,22 @@ class InjectedScript {
 
     const part = selector.parts[index];
     if (part.name === "nth") {
-      let filtered = [];
-      if (part.body === "0") {
-        filtered = roots.slice(0, 1);
-      } else if (part.body === "-1") {
-        if (roots.length) {
-          filtered = roots.slice(roots.length - 1);
-        }
-      } else {
-        if (typeof selector.capture === "number") {
-          return "error:nthnocapture";
-        }
-        const nth = part.body;
-        const set = new Set();
-        for (const root of roots) {
-          set.add(root.element);
-          if (nth + 1 === set.size) {
-            filtered = [root];
-          }
+      const nth = parseInt(part.body, 10);
+      if (nth !== 0 && nth !== -1 && typeof selector.capture === "number") {
+        return "error:nthnocapture";
+      }
+      // The same element can be matched through different roots,
+      // so nth counts the unique elements.
+      const seen = new Set();
+      const unique = [];
+      for (const root of roots) {
+        if (!seen.has(root.element)) {
+          seen.add(root.element);
+          unique.push(root);
         }
       }
+      const i = nth < 0 ? unique.length + nth : nth;
+      const filtered = i >= 0 && i < unique.length ? [unique[i]] : [];
       return this._querySelectorRecursively(
         filtered,
         selector,
@@ -243,8 +718,13 @@ class InjectedScript {
     }
 
     if (part.name === "visible") {
-      const visible = Boolean(part.body);
-      return roots.filter((match) => visible === isVisible(match.element));
+      const visible = part.body.trim() === "true";
+      return this._querySelectorRecursively(
+        roots.filter((match) => visible === isVisible(match.element)),
+        selector,
+        index + 1,
+        queryCache
+      );
     }
 
     const result = [];
@@ -505,6 +985,26 @@ class InjectedScript {
     return "done";
   }
 
+  dropFiles(node, payloads) {
+    if (node.nodeType !== Node.ELEMENT_NODE)
+      return "error:notelement";
+
+    const dt = new DataTransfer();
+    for (const file of payloads || []) {
+      const bytes = Uint8Array.from(atob(file.buffer), c => c.charCodeAt(0));
+      dt.items.add(new File([bytes], file.name, { type: file.mimeType, lastModified: file.lastModifiedMs }));
+    }
+    for (const type of ["dragenter", "dragover", "drop"]) {
+      node.dispatchEvent(new DragEvent(type, {
+        bubbles: true,
+        cancelable: true,
+        composed: true,
+        dataTransfer: dt,
+      }));
+    }
+    return "done";
+  }
+
   getElementBorderWidth(node) {
     if (
       node.nodeType !== 1 /*Node.ELEMENT_NODE*/ ||
@@ -596,6 +1096,43 @@ class InjectedScript {
     return "done";
   }
 
+  blurNode(node) {
+    if (!node.isConnected) {
+      return "error:notconnected";
+    }
+    if (node.nodeType !== 1 /*Node.ELEMENT_NODE*/) {
+      return "error:notelement";
+    }
+    node.blur();
+    return "done";
+  }
+
+  // highlight draws an overlay over the elements for debugging. It
+  // replaces the overlay of the previous call.
+  highlight(elements) {
+    const id = "__k6_browser_highlight__";
+    const previous = document.getElementById(id);
+    if (previous) {
+      previous.remove();
+    }
+    const container = document.createElement("x-k6-browser-highlight");
+    container.id = id;
+    container.style.cssText =
+      "position: fixed; inset: 0; pointer-events: none; z-index: 2147483647;";
+    for (const element of elements) {
+      const rect = element.getBoundingClientRect();
+      const box = document.createElement("x-k6-browser-highlight-box");
+      box.style.cssText = `position: fixed; box-sizing: border-box;
+        left: ${rect.left}px; top: ${rect.top}px;
+        width: ${rect.width}px; height: ${rect.height}px;
+        background-color: rgba(111, 168, 220, 0.5);
+        outline: 1px solid rgb(111, 168, 220);`;
+      container.appendChild(box);
+    }
+    document.documentElement.appendChild(container);
+    return "done";
+  }
+
   getDocumentElement(node) {
     const doc = node;
     if (doc.documentElement && doc.documentElement.ownerDocument === doc) {
@@ -674,6 +1211,19 @@ class InjectedScript {
     );
   }
 
+  strictModeViolationError(selector, elements) {
+    const maxPreviews = 10;
+    const previews = elements
+      .slice(0, maxPreviews)
+      .map((e, i) => `\n    ${i + 1}) ${this.previewNode(e)}`);
+    if (elements.length > maxPreviews) {
+      previews.push(`\n    ...and ${elements.length - maxPreviews} more`);
+    }
+    return `error:strictmodeviolation: selector ${JSON.stringify(
+      selector.selector
+    )} resolved to ${elements.length} elements:${previews.join("")}`;
+  }
+
   querySelector(selector, strict, root) {
     if (!root["querySelector"]) {
       return "error:notqueryablenode";
@@ -685,7 +1235,10 @@ class InjectedScript {
       new Map()
     );
     if (strict && result.length > 1) {
-      throw "error:strictmodeviolation";
+      throw this.strictModeViolationError(
+        selector,
+        result.map((r) => r.capture || r.element)
+      );
     }
     if (result.length == 0) {
       return null;
@@ -996,7 +1549,7 @@ class InjectedScript {
         } else {
           if (elements.length > 1) {
             if (strict) {
-              throw "error:strictmodeviolation";
+              throw this.strictModeViolationError(selector, elements);
             }
           }
         }
@@ -0,0 +1,91 @@
+// Serializes the document of the page into the snapshot format of the
+// Playwright trace viewer. Elements are [tagName, attributes, ...children]
+// arrays, and text nodes are strings. Scripts are left out, the values of
+// form controls are kept as attributes, and the rules of the accessible
+// stylesheets are inlined so that the snapshot renders without the page.
+() => {
+  const skipped = new Set(['SCRIPT', 'NOSCRIPT']);
+
+  const styleRules = (sheet) => {
+    try {
+      return Array.from(sheet.cssRules, (rule) => rule.cssText).join('\n');
+    } catch (e) {
+      // The rules of cross-origin stylesheets are not accessible.
+      return undefined;
+    }
+  };
+
+  const attributes = (el) => {
+    const attrs = {};
+    for (const { name, value } of Array.from(el.attributes)) {
+      attrs[name] = value;
+    }
+    if (el instanceof HTMLInputElement) {
+      if (el.type === 'checkbox' || el.type === 'radio') {
+        if (el.checked) {
+          attrs.checked = '';
+        } else {
+          delete attrs.checked;
+        }
+      } else if (el.type !== 'file') {
+        attrs.value = el.value;
+      }
+    }
+    if (el instanceof HTMLOptionElement) {
+      if (el.selected) {
+        attrs.selected = '';
+      } else {
+        delete attrs.selected;
+      }
+    }
+    return attrs;
+  };
+
+  const visit = (node) => {
+    if (node.nodeType === Node.TEXT_NODE) {
+      return node.nodeValue;
+    }
+    if (node.nodeType !== Node.ELEMENT_NODE) {
+      return undefined;
+    }
+    const tag = node.nodeName;
+    if (skipped.has(tag)) {
+      return undefined;
+    }
+    if (tag === 'LINK' && node.rel === 'stylesheet' && node.sheet) {
+      const rules = styleRules(node.sheet);
+      if (rules !== undefined) {
+        return ['STYLE', {}, rules];
+      }
+    }
+    const snapshot = [tag, attributes(node)];
+    if (tag === 'STYLE' && node.sheet) {
+      const rules = styleRules(node.sheet);
+      snapshot.push(rules !== undefined ? rules : node.textContent);
+      return snapshot;
+    }
+    if (tag === 'TEXTAREA') {
+      snapshot.push(node.value);
+      return snapshot;
+    }
+    const children = node.shadowRoot ? node.shadowRoot.childNodes : node.childNodes;
+    for (const child of Array.from(children)) {
+      const c = visit(child);
+      if (c !== undefined) {
+        snapshot.push(c);
+      }
+    }
+    return snapshot;
+  };
+
+  const doctype = document.doctype ? document.doctype.name : '';
+  return {
+    doctype,
+    html: visit(document.documentElement),
+    url: document.location.href,
+    viewport: {
+      width: window.innerWidth,
+      height: window.innerHeight,
+    },
+  };
+}
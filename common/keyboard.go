@@ -35,6 +35,10 @@ type Keyboard struct {
 	pressedKeys map[int64]bool // tracks keys through down() and up()
 	layoutName  string         // us by default
 	layout      keyboardlayout.KeyboardLayout
+
+	// humanizer randomizes the typing cadence and makes typos.
+	// It is nil unless humanized input is enabled.
+	humanizer *humanizer
 }
 
 // NewKeyboard returns a new keyboard with a "us" layout.
@@ -309,6 +313,9 @@ func (k *Keyboard) press(key string, opts KeyboardOptions) error {
 }
 
 func (k *Keyboard) typ(text string, opts KeyboardOptions) error {
+	if k.humanizer != nil {
+		return k.humanizedTyp(text)
+	}
 	layout := keyboardlayout.GetKeyboardLayout(k.layoutName)
 	for _, c := range text {
 		if opts.Delay > 0 {
@@ -330,6 +337,50 @@ func (k *Keyboard) typ(text string, opts KeyboardOptions) error {
 	return nil
 }
 
+// humanizedTyp types the text with randomized delays between the
+// keystrokes, occasionally mistyping a character and correcting it.
+// It ignores the delay option.
+func (k *Keyboard) humanizedTyp(text string) error {
+	layout := keyboardlayout.GetKeyboardLayout(k.layoutName)
+	isKey := func(c rune) bool {
+		_, ok := layout.ValidKeys[keyboardlayout.KeyInput(c)]
+		return ok
+	}
+	keystroke := func(key string) error {
+		if err := sleep(k.ctx, k.humanizer.keystrokeDelay()); err != nil {
+			return err
+		}
+		if err := k.press(key, KeyboardOptions{}); err != nil {
+			return fmt.Errorf("pressing key: %w", err)
+		}
+		return nil
+	}
+	for _, c := range text {
+		if !isKey(c) {
+			if err := sleep(k.ctx, k.humanizer.keystrokeDelay()); err != nil {
+				return err
+			}
+			if err := k.insertText(string(c)); err != nil {
+				return fmt.Errorf("inserting text: %w", err)
+			}
+			continue
+		}
+		if t, ok := k.humanizer.typo(c); ok && isKey(t) {
+			if err := keystroke(string(t)); err != nil {
+				return err
+			}
+			if err := keystroke("Backspace"); err != nil {
+				return err
+			}
+		}
+		if err := keystroke(string(c)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func wait(ctx context.Context, delay int64) error {
 	t := time.NewTimer(time.Duration(delay) * time.Millisecond)
 	select {
@@ -343,3 +394,19 @@ func wait(ctx context.Context, delay int64) error {
 
 	return nil
 }
+
+// sleep is like wait but takes a duration.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("%w", ctx.Err())
+	case <-t.C:
+	}
+
+	return nil
+}
@@ -2,17 +2,20 @@ package common
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/grafana/sobek"
 
+	"github.com/grafana/xk6-browser/common/js"
 	"github.com/grafana/xk6-browser/log"
 )
 
 // Strict mode:
 // All operations on locators throw an exception if more
-// than one element matches the locator's selector.
+// than one element matches the locator's selector, unless
+// the locator is created with the strict option set to false.
 //
 // See Issue #100 for more details.
 
@@ -20,6 +23,16 @@ import (
 type Locator struct {
 	selector string
 
+	// frameSelectors is the chain of iframe selectors that leads from
+	// frame to the frame that selector is queried in. It's empty for
+	// locators that are not created from a frame locator.
+	frameSelectors []string
+
+	// strict is on if the locator's operations should fail when
+	// the selector matches more than one element. It's on by default,
+	// and an explicit strict option overrides the browser context's.
+	strict bool
+
 	frame *Frame
 
 	ctx context.Context
@@ -30,6 +43,7 @@ type Locator struct {
 func NewLocator(ctx context.Context, selector string, f *Frame, l *log.Logger) *Locator {
 	return &Locator{
 		selector: selector,
+		strict:   true,
 		frame:    f,
 		ctx:      ctx,
 		log:      l,
@@ -51,6 +65,43 @@ func (l *Locator) Clear(opts *FrameFillOptions) error {
 	return nil
 }
 
+// ContentFrame returns a frame locator for the iframe element that matches
+// the locator's selector.
+func (l *Locator) ContentFrame() *FrameLocator {
+	l.log.Debugf("Locator:ContentFrame", "fid:%s furl:%q sel:%q", l.frame.ID(), l.frame.URL(), l.selector)
+
+	return &FrameLocator{
+		selectors: append(append([]string{}, l.frameSelectors...), l.selector),
+		frame:     l.frame,
+		ctx:       l.ctx,
+		log:       l.log,
+	}
+}
+
+// targetFrame returns the frame that the locator's selector is queried in.
+// For locators created from a frame locator, the frame is re-resolved on
+// every call so that the locator keeps working after the iframe reloads.
+// Resolving the frame uses up the action's timeout, so that the frame and
+// the action don't wait for longer than the timeout together.
+func (l *Locator) targetFrame(timeout *time.Duration) (*Frame, error) {
+	if len(l.frameSelectors) == 0 {
+		return l.frame, nil
+	}
+
+	start := time.Now()
+	f, err := resolveFrame(l.frame, l.frameSelectors, *timeout)
+	if err != nil {
+		return nil, err
+	}
+	if *timeout > 0 {
+		if *timeout -= time.Since(start); *timeout <= 0 {
+			return nil, fmt.Errorf("resolving the frame of %q: %w", l.selector, ErrTimedOut)
+		}
+	}
+
+	return f, nil
+}
+
 // Timeout will return the default timeout or the one set by the user.
 func (l *Locator) Timeout() time.Duration {
 	return l.frame.defaultTimeout()
@@ -59,7 +110,7 @@ func (l *Locator) Timeout() time.Duration {
 // Click on an element using locator's selector with strict mode on.
 func (l *Locator) Click(opts *FrameClickOptions) error {
 	l.log.Debugf("Locator:Click", "fid:%s furl:%q sel:%q opts:%+v", l.frame.ID(), l.frame.URL(), l.selector, opts)
-	_, span := TraceAPICall(l.ctx, l.frame.page.targetID.String(), "locator.click")
+	_, span := tracePageAPICall(l.ctx, l.frame.page, "locator.click")
 	defer span.End()
 
 	if err := l.click(opts); err != nil {
@@ -76,8 +127,12 @@ func (l *Locator) Click(opts *FrameClickOptions) error {
 // click is like Click but takes parsed options and neither throws an
 // error, or applies slow motion.
 func (l *Locator) click(opts *FrameClickOptions) error {
-	opts.Strict = true
-	return l.frame.click(l.selector, opts)
+	opts.Strict, opts.strictSet = l.strict, true
+	f, err := l.targetFrame(&opts.Timeout)
+	if err != nil {
+		return err
+	}
+	return f.click(l.selector, opts)
 }
 
 // Dblclick double clicks on an element using locator's selector with strict mode on.
@@ -100,8 +155,12 @@ func (l *Locator) Dblclick(opts sobek.Value) error {
 // Dblclick is like Dblclick but takes parsed options and neither throws an
 // error, or applies slow motion.
 func (l *Locator) dblclick(opts *FrameDblclickOptions) error {
-	opts.Strict = true
-	return l.frame.dblclick(l.selector, opts)
+	opts.Strict, opts.strictSet = l.strict, true
+	f, err := l.targetFrame(&opts.Timeout)
+	if err != nil {
+		return err
+	}
+	return f.dblclick(l.selector, opts)
 }
 
 // SetChecked sets the checked state of the element using locator's selector
@@ -126,8 +185,12 @@ func (l *Locator) SetChecked(checked bool, opts sobek.Value) error {
 }
 
 func (l *Locator) setChecked(checked bool, opts *FrameCheckOptions) error {
-	opts.Strict = true
-	return l.frame.setChecked(l.selector, checked, opts)
+	opts.Strict, opts.strictSet = l.strict, true
+	f, err := l.targetFrame(&opts.Timeout)
+	if err != nil {
+		return err
+	}
+	return f.setChecked(l.selector, checked, opts)
 }
 
 // Check on an element using locator's selector with strict mode on.
@@ -150,8 +213,12 @@ func (l *Locator) Check(opts sobek.Value) error {
 // check is like Check but takes parsed options and neither throws an
 // error, or applies slow motion.
 func (l *Locator) check(opts *FrameCheckOptions) error {
-	opts.Strict = true
-	return l.frame.check(l.selector, opts)
+	opts.Strict, opts.strictSet = l.strict, true
+	f, err := l.targetFrame(&opts.Timeout)
+	if err != nil {
+		return err
+	}
+	return f.check(l.selector, opts)
 }
 
 // Uncheck on an element using locator's selector with strict mode on.
@@ -174,8 +241,12 @@ func (l *Locator) Uncheck(opts sobek.Value) error {
 // uncheck is like Uncheck but takes parsed options and neither throws
 // an error, or applies slow motion.
 func (l *Locator) uncheck(opts *FrameUncheckOptions) error {
-	opts.Strict = true
-	return l.frame.uncheck(l.selector, opts)
+	opts.Strict, opts.strictSet = l.strict, true
+	f, err := l.targetFrame(&opts.Timeout)
+	if err != nil {
+		return err
+	}
+	return f.uncheck(l.selector, opts)
 }
 
 // IsChecked returns true if the element matches the locator's
@@ -198,8 +269,12 @@ func (l *Locator) IsChecked(opts sobek.Value) (bool, error) {
 // isChecked is like IsChecked but takes parsed options and does not
 // throw an error.
 func (l *Locator) isChecked(opts *FrameIsCheckedOptions) (bool, error) {
-	opts.Strict = true
-	return l.frame.isChecked(l.selector, opts)
+	opts.Strict, opts.strictSet = l.strict, true
+	f, err := l.targetFrame(&opts.Timeout)
+	if err != nil {
+		return false, err
+	}
+	return f.isChecked(l.selector, opts)
 }
 
 // IsEditable returns true if the element matches the locator's
@@ -222,8 +297,12 @@ func (l *Locator) IsEditable(opts sobek.Value) (bool, error) {
 // isEditable is like IsEditable but takes parsed options and does not
 // throw an error.
 func (l *Locator) isEditable(opts *FrameIsEditableOptions) (bool, error) {
-	opts.Strict = true
-	return l.frame.isEditable(l.selector, opts)
+	opts.Strict, opts.strictSet = l.strict, true
+	f, err := l.targetFrame(&opts.Timeout)
+	if err != nil {
+		return false, err
+	}
+	return f.isEditable(l.selector, opts)
 }
 
 // IsEnabled returns true if the element matches the locator's
@@ -246,8 +325,12 @@ func (l *Locator) IsEnabled(opts sobek.Value) (bool, error) {
 // isEnabled is like IsEnabled but takes parsed options and does not
 // throw an error.
 func (l *Locator) isEnabled(opts *FrameIsEnabledOptions) (bool, error) {
-	opts.Strict = true
-	return l.frame.isEnabled(l.selector, opts)
+	opts.Strict, opts.strictSet = l.strict, true
+	f, err := l.targetFrame(&opts.Timeout)
+	if err != nil {
+		return false, err
+	}
+	return f.isEnabled(l.selector, opts)
 }
 
 // IsDisabled returns true if the element matches the locator's
@@ -270,8 +353,12 @@ func (l *Locator) IsDisabled(opts sobek.Value) (bool, error) {
 // IsDisabled is like IsDisabled but takes parsed options and does not
 // throw an error.
 func (l *Locator) isDisabled(opts *FrameIsDisabledOptions) (bool, error) {
-	opts.Strict = true
-	return l.frame.isDisabled(l.selector, opts)
+	opts.Strict, opts.strictSet = l.strict, true
+	f, err := l.targetFrame(&opts.Timeout)
+	if err != nil {
+		return false, err
+	}
+	return f.isDisabled(l.selector, opts)
 }
 
 // IsVisible returns true if the element matches the locator's
@@ -279,7 +366,12 @@ func (l *Locator) isDisabled(opts *FrameIsDisabledOptions) (bool, error) {
 func (l *Locator) IsVisible() (bool, error) {
 	l.log.Debugf("Locator:IsVisible", "fid:%s furl:%q sel:%q", l.frame.ID(), l.frame.URL(), l.selector)
 
-	visible, err := l.frame.isVisible(l.selector, &FrameIsVisibleOptions{Strict: true})
+	timeout := l.frame.defaultTimeout()
+	f, err := l.targetFrame(&timeout)
+	if err != nil {
+		return false, fmt.Errorf("checking is %q visible: %w", l.selector, err)
+	}
+	visible, err := f.isVisible(l.selector, &FrameIsVisibleOptions{Strict: l.strict, strictSet: true})
 	if err != nil {
 		return false, fmt.Errorf("checking is %q visible: %w", l.selector, err)
 	}
@@ -292,7 +384,12 @@ func (l *Locator) IsVisible() (bool, error) {
 func (l *Locator) IsHidden() (bool, error) {
 	l.log.Debugf("Locator:IsHidden", "fid:%s furl:%q sel:%q", l.frame.ID(), l.frame.URL(), l.selector)
 
-	hidden, err := l.frame.isHidden(l.selector, &FrameIsHiddenOptions{Strict: true})
+	timeout := l.frame.defaultTimeout()
+	f, err := l.targetFrame(&timeout)
+	if err != nil {
+		return false, fmt.Errorf("checking is %q hidden: %w", l.selector, err)
+	}
+	hidden, err := f.isHidden(l.selector, &FrameIsHiddenOptions{Strict: l.strict, strictSet: true})
 	if err != nil {
 		return false, fmt.Errorf("checking is %q hidden: %w", l.selector, err)
 	}
@@ -300,6 +397,154 @@ func (l *Locator) IsHidden() (bool, error) {
 	return hidden, nil
 }
 
+// Count returns the number of elements matching the locator's selector.
+// It doesn't wait for the elements.
+func (l *Locator) Count() (int, error) {
+	l.log.Debugf("Locator:Count", "fid:%s furl:%q sel:%q", l.frame.ID(), l.frame.URL(), l.selector)
+
+	n, err := l.count()
+	if err != nil {
+		return 0, fmt.Errorf("counting elements of %q: %w", l.selector, err)
+	}
+
+	return n, nil
+}
+
+func (l *Locator) count() (int, error) {
+	v, err := l.evaluateAll(`elements => elements.length`)
+	if err != nil {
+		return 0, err
+	}
+	switch n := v.(type) {
+	case int64:
+		return int(n), nil
+	case float64:
+		return int(n), nil
+	default:
+		return 0, fmt.Errorf("unexpected type %T", v)
+	}
+}
+
+// Evaluate waits for the element matching the locator's selector to be
+// attached to the DOM and evaluates the page function with the element
+// as its first argument.
+func (l *Locator) Evaluate(pageFunc string, args ...any) (any, error) {
+	l.log.Debugf("Locator:Evaluate", "fid:%s furl:%q sel:%q", l.frame.ID(), l.frame.URL(), l.selector)
+
+	v, err := l.evaluate(pageFunc, args...)
+	if err != nil {
+		return nil, fmt.Errorf("evaluating %q: %w", l.selector, err)
+	}
+
+	return v, nil
+}
+
+func (l *Locator) evaluate(pageFunc string, args ...any) (_ any, rerr error) {
+	h, err := l.waitForElement(l.frame.defaultTimeout())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := h.Dispose(); err != nil {
+			rerr = errors.Join(rerr, fmt.Errorf("disposing element handle: %w", err))
+		}
+	}()
+
+	return h.Evaluate(pageFunc, args...)
+}
+
+// EvaluateHandle waits for the element matching the locator's selector to be
+// attached to the DOM and evaluates the page function with the element as
+// its first argument. It returns the result as a JS handle.
+func (l *Locator) EvaluateHandle(pageFunc string, args ...any) (JSHandleAPI, error) {
+	l.log.Debugf("Locator:EvaluateHandle", "fid:%s furl:%q sel:%q", l.frame.ID(), l.frame.URL(), l.selector)
+
+	jsh, err := l.evaluateHandle(pageFunc, args...)
+	if err != nil {
+		return nil, fmt.Errorf("evaluating handle for %q: %w", l.selector, err)
+	}
+
+	return jsh, nil
+}
+
+func (l *Locator) evaluateHandle(pageFunc string, args ...any) (_ JSHandleAPI, rerr error) {
+	h, err := l.waitForElement(l.frame.defaultTimeout())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := h.Dispose(); err != nil {
+			rerr = errors.Join(rerr, fmt.Errorf("disposing element handle: %w", err))
+		}
+	}()
+
+	return h.EvaluateHandle(pageFunc, args...)
+}
+
+// EvaluateAll evaluates the page function with an array of all the elements
+// matching the locator's selector as its first argument. Unlike Evaluate,
+// it doesn't wait for the elements, and the array can be empty.
+func (l *Locator) EvaluateAll(pageFunc string, args ...any) (any, error) {
+	l.log.Debugf("Locator:EvaluateAll", "fid:%s furl:%q sel:%q", l.frame.ID(), l.frame.URL(), l.selector)
+
+	v, err := l.evaluateAll(pageFunc, args...)
+	if err != nil {
+		return nil, fmt.Errorf("evaluating all %q: %w", l.selector, err)
+	}
+
+	return v, nil
+}
+
+func (l *Locator) evaluateAll(pageFunc string, args ...any) (_ any, rerr error) {
+	timeout := l.frame.defaultTimeout()
+	f, err := l.targetFrame(&timeout)
+	if err != nil {
+		return nil, err
+	}
+	document, err := f.document()
+	if err != nil {
+		return nil, fmt.Errorf("getting document: %w", err)
+	}
+	parsedSelector, err := f.parseSelector(l.selector)
+	if err != nil {
+		return nil, fmt.Errorf("parsing selector: %w", err)
+	}
+	result, err := document.evalWithScript(
+		l.ctx,
+		evalOptions{forceCallable: true, returnByValue: false},
+		js.QueryAll,
+		parsedSelector,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying elements: %w", err)
+	}
+	elements, ok := result.(JSHandleAPI)
+	if !ok {
+		return nil, fmt.Errorf("querying elements: %w", ErrJSHandleInvalid)
+	}
+	defer func() {
+		if err := elements.Dispose(); err != nil {
+			rerr = errors.Join(rerr, fmt.Errorf("disposing elements handle: %w", err))
+		}
+	}()
+
+	return elements.Evaluate(pageFunc, args...)
+}
+
+// waitForElement waits for the element matching the locator's selector
+// to be attached to the DOM and returns its handle.
+func (l *Locator) waitForElement(timeout time.Duration) (*ElementHandle, error) {
+	f, err := l.targetFrame(&timeout)
+	if err != nil {
+		return nil, err
+	}
+	opts := NewFrameWaitForSelectorOptions(timeout)
+	opts.State = DOMElementStateAttached
+	opts.Strict, opts.strictSet = l.strict, true
+
+	return f.waitForSelector(l.selector, opts)
+}
+
 // Fill out the element using locator's selector with strict mode on.
 func (l *Locator) Fill(value string, opts sobek.Value) error {
 	l.log.Debugf(
@@ -321,8 +566,156 @@ func (l *Locator) Fill(value string, opts sobek.Value) error {
 }
 
 func (l *Locator) fill(value string, opts *FrameFillOptions) error {
-	opts.Strict = true
-	return l.frame.fill(l.selector, value, opts)
+	opts.Strict, opts.strictSet = l.strict, true
+	f, err := l.targetFrame(&opts.Timeout)
+	if err != nil {
+		return err
+	}
+	return f.fill(l.selector, value, opts)
+}
+
+// Blur removes the focus from the element using locator's selector
+// with strict mode on.
+func (l *Locator) Blur(opts *FrameBaseOptions) error {
+	l.log.Debugf("Locator:Blur", "fid:%s furl:%q sel:%q opts:%+v", l.frame.ID(), l.frame.URL(), l.selector, opts)
+
+	if err := l.blur(opts); err != nil {
+		return fmt.Errorf("blurring %q: %w", l.selector, err)
+	}
+
+	applySlowMo(l.ctx)
+
+	return nil
+}
+
+func (l *Locator) blur(opts *FrameBaseOptions) error {
+	opts.Strict, opts.strictSet = l.strict, true
+	f, err := l.targetFrame(&opts.Timeout)
+	if err != nil {
+		return err
+	}
+	return f.blur(l.selector, opts)
+}
+
+// BoundingBox returns the bounding box of the element using locator's
+// selector with strict mode on. It returns nil if the element is not
+// rendered, for example, when it's hidden.
+func (l *Locator) BoundingBox(opts *FrameBaseOptions) (*Rect, error) {
+	l.log.Debugf("Locator:BoundingBox", "fid:%s furl:%q sel:%q opts:%+v", l.frame.ID(), l.frame.URL(), l.selector, opts)
+
+	rect, err := l.boundingBox(opts)
+	if err != nil {
+		return nil, fmt.Errorf("getting bounding box of %q: %w", l.selector, err)
+	}
+
+	return rect, nil
+}
+
+func (l *Locator) boundingBox(opts *FrameBaseOptions) (*Rect, error) {
+	opts.Strict, opts.strictSet = l.strict, true
+	f, err := l.targetFrame(&opts.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	return f.boundingBox(l.selector, opts)
+}
+
+// Highlight draws an overlay over all the elements matching the locator's
+// selector. It's meant for debugging scripts in headful mode.
+func (l *Locator) Highlight() error {
+	l.log.Debugf("Locator:Highlight", "fid:%s furl:%q sel:%q", l.frame.ID(), l.frame.URL(), l.selector)
+
+	timeout := l.frame.defaultTimeout()
+	f, err := l.targetFrame(&timeout)
+	if err != nil {
+		return fmt.Errorf("highlighting %q: %w", l.selector, err)
+	}
+	if err := f.highlight(l.selector); err != nil {
+		return fmt.Errorf("highlighting %q: %w", l.selector, err)
+	}
+
+	return nil
+}
+
+// Screenshot takes a screenshot of the element using locator's selector
+// with strict mode on.
+func (l *Locator) Screenshot(opts *ElementHandleScreenshotOptions, sp ScreenshotPersister) (_ []byte, rerr error) {
+	l.log.Debugf("Locator:Screenshot", "fid:%s furl:%q sel:%q opts:%+v", l.frame.ID(), l.frame.URL(), l.selector, opts)
+
+	h, err := l.waitForElement(opts.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("taking screenshot of %q: %w", l.selector, err)
+	}
+	defer func() {
+		if err := h.Dispose(); err != nil {
+			rerr = errors.Join(rerr, fmt.Errorf("disposing element handle: %w", err))
+		}
+	}()
+
+	buf, err := h.Screenshot(opts, sp)
+	if err != nil {
+		return nil, fmt.Errorf("taking screenshot of %q: %w", l.selector, err)
+	}
+
+	return buf, nil
+}
+
+// ScrollIntoViewIfNeeded scrolls the element using locator's selector
+// into view if needed with strict mode on.
+func (l *Locator) ScrollIntoViewIfNeeded(opts *ElementHandleBaseOptions) error {
+	l.log.Debugf(
+		"Locator:ScrollIntoViewIfNeeded", "fid:%s furl:%q sel:%q opts:%+v",
+		l.frame.ID(), l.frame.URL(), l.selector, opts,
+	)
+
+	f, err := l.targetFrame(&opts.Timeout)
+	if err != nil {
+		return fmt.Errorf("scrolling %q into view: %w", l.selector, err)
+	}
+	if err := f.scrollIntoViewIfNeeded(l.selector, l.strict, opts); err != nil {
+		return fmt.Errorf("scrolling %q into view: %w", l.selector, err)
+	}
+
+	applySlowMo(l.ctx)
+
+	return nil
+}
+
+// SelectText selects the text of the element using locator's selector
+// with strict mode on.
+func (l *Locator) SelectText(opts *ElementHandleBaseOptions) error {
+	l.log.Debugf("Locator:SelectText", "fid:%s furl:%q sel:%q opts:%+v", l.frame.ID(), l.frame.URL(), l.selector, opts)
+
+	f, err := l.targetFrame(&opts.Timeout)
+	if err != nil {
+		return fmt.Errorf("selecting text of %q: %w", l.selector, err)
+	}
+	if err := f.selectText(l.selector, l.strict, opts); err != nil {
+		return fmt.Errorf("selecting text of %q: %w", l.selector, err)
+	}
+
+	applySlowMo(l.ctx)
+
+	return nil
+}
+
+// SetInputFiles sets the files of the file input element using
+// locator's selector with strict mode on.
+func (l *Locator) SetInputFiles(files *Files, opts *FrameSetInputFilesOptions) error {
+	l.log.Debugf("Locator:SetInputFiles", "fid:%s furl:%q sel:%q opts:%+v", l.frame.ID(), l.frame.URL(), l.selector, opts)
+
+	opts.Strict, opts.strictSet = l.strict, true
+	f, err := l.targetFrame(&opts.Timeout)
+	if err != nil {
+		return fmt.Errorf("setting input files on %q: %w", l.selector, err)
+	}
+	if err := f.setInputFiles(l.selector, files, opts); err != nil {
+		return fmt.Errorf("setting input files on %q: %w", l.selector, err)
+	}
+
+	applySlowMo(l.ctx)
+
+	return nil
 }
 
 // Focus on the element using locator's selector with strict mode on.
@@ -343,8 +736,12 @@ func (l *Locator) Focus(opts sobek.Value) error {
 }
 
 func (l *Locator) focus(opts *FrameBaseOptions) error {
-	opts.Strict = true
-	return l.frame.focus(l.selector, opts)
+	opts.Strict, opts.strictSet = l.strict, true
+	f, err := l.targetFrame(&opts.Timeout)
+	if err != nil {
+		return err
+	}
+	return f.focus(l.selector, opts)
 }
 
 // GetAttribute of the element using locator's selector with strict mode on.
@@ -368,8 +765,12 @@ func (l *Locator) GetAttribute(name string, opts sobek.Value) (string, bool, err
 }
 
 func (l *Locator) getAttribute(name string, opts *FrameBaseOptions) (string, bool, error) {
-	opts.Strict = true
-	return l.frame.getAttribute(l.selector, name, opts)
+	opts.Strict, opts.strictSet = l.strict, true
+	f, err := l.targetFrame(&opts.Timeout)
+	if err != nil {
+		return "", false, err
+	}
+	return f.getAttribute(l.selector, name, opts)
 }
 
 // InnerHTML returns the element's inner HTML that matches
@@ -390,8 +791,12 @@ func (l *Locator) InnerHTML(opts sobek.Value) (string, error) {
 }
 
 func (l *Locator) innerHTML(opts *FrameInnerHTMLOptions) (string, error) {
-	opts.Strict = true
-	return l.frame.innerHTML(l.selector, opts)
+	opts.Strict, opts.strictSet = l.strict, true
+	f, err := l.targetFrame(&opts.Timeout)
+	if err != nil {
+		return "", err
+	}
+	return f.innerHTML(l.selector, opts)
 }
 
 // InnerText returns the element's inner text that matches
@@ -412,8 +817,12 @@ func (l *Locator) InnerText(opts sobek.Value) (string, error) {
 }
 
 func (l *Locator) innerText(opts *FrameInnerTextOptions) (string, error) {
-	opts.Strict = true
-	return l.frame.innerText(l.selector, opts)
+	opts.Strict, opts.strictSet = l.strict, true
+	f, err := l.targetFrame(&opts.Timeout)
+	if err != nil {
+		return "", err
+	}
+	return f.innerText(l.selector, opts)
 }
 
 // TextContent returns the element's text content that matches
@@ -436,8 +845,12 @@ func (l *Locator) TextContent(opts sobek.Value) (string, bool, error) {
 }
 
 func (l *Locator) textContent(opts *FrameTextContentOptions) (string, bool, error) {
-	opts.Strict = true
-	return l.frame.textContent(l.selector, opts)
+	opts.Strict, opts.strictSet = l.strict, true
+	f, err := l.targetFrame(&opts.Timeout)
+	if err != nil {
+		return "", false, err
+	}
+	return f.textContent(l.selector, opts)
 }
 
 // InputValue returns the element's input value that matches
@@ -458,8 +871,12 @@ func (l *Locator) InputValue(opts sobek.Value) (string, error) {
 }
 
 func (l *Locator) inputValue(opts *FrameInputValueOptions) (string, error) {
-	opts.Strict = true
-	return l.frame.inputValue(l.selector, opts)
+	opts.Strict, opts.strictSet = l.strict, true
+	f, err := l.targetFrame(&opts.Timeout)
+	if err != nil {
+		return "", err
+	}
+	return f.inputValue(l.selector, opts)
 }
 
 // SelectOption filters option values of the first element that matches
@@ -483,8 +900,12 @@ func (l *Locator) SelectOption(values sobek.Value, opts sobek.Value) ([]string,
 }
 
 func (l *Locator) selectOption(values sobek.Value, opts *FrameSelectOptionOptions) ([]string, error) {
-	opts.Strict = true
-	return l.frame.selectOption(l.selector, values, opts)
+	opts.Strict, opts.strictSet = l.strict, true
+	f, err := l.targetFrame(&opts.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	return f.selectOption(l.selector, values, opts)
 }
 
 // Press the given key on the element found that matches the locator's
@@ -509,8 +930,12 @@ func (l *Locator) Press(key string, opts sobek.Value) error {
 }
 
 func (l *Locator) press(key string, opts *FramePressOptions) error {
-	opts.Strict = true
-	return l.frame.press(l.selector, key, opts)
+	opts.Strict, opts.strictSet = l.strict, true
+	f, err := l.targetFrame(&opts.Timeout)
+	if err != nil {
+		return err
+	}
+	return f.press(l.selector, key, opts)
 }
 
 // Type text on the element found that matches the locator's
@@ -520,7 +945,7 @@ func (l *Locator) Type(text string, opts sobek.Value) error {
 		"Locator:Type", "fid:%s furl:%q sel:%q text:%q opts:%+v",
 		l.frame.ID(), l.frame.URL(), l.selector, text, opts,
 	)
-	_, span := TraceAPICall(l.ctx, l.frame.page.targetID.String(), "locator.type")
+	_, span := tracePageAPICall(l.ctx, l.frame.page, "locator.type")
 	defer span.End()
 
 	copts := NewFrameTypeOptions(l.frame.defaultTimeout())
@@ -541,8 +966,12 @@ func (l *Locator) Type(text string, opts sobek.Value) error {
 }
 
 func (l *Locator) typ(text string, opts *FrameTypeOptions) error {
-	opts.Strict = true
-	return l.frame.typ(l.selector, text, opts)
+	opts.Strict, opts.strictSet = l.strict, true
+	f, err := l.targetFrame(&opts.Timeout)
+	if err != nil {
+		return err
+	}
+	return f.typ(l.selector, text, opts)
 }
 
 // Hover moves the pointer over the element that matches the locator's
@@ -564,16 +993,19 @@ func (l *Locator) Hover(opts sobek.Value) error {
 }
 
 func (l *Locator) hover(opts *FrameHoverOptions) error {
-	opts.Strict = true
-	return l.frame.hover(l.selector, opts)
+	opts.Strict, opts.strictSet = l.strict, true
+	f, err := l.targetFrame(&opts.Timeout)
+	if err != nil {
+		return err
+	}
+	return f.hover(l.selector, opts)
 }
 
 // Tap the element found that matches the locator's selector with strict mode on.
 func (l *Locator) Tap(opts *FrameTapOptions) error {
 	l.log.Debugf("Locator:Tap", "fid:%s furl:%q sel:%q opts:%+v", l.frame.ID(), l.frame.URL(), l.selector, opts)
 
-	opts.Strict = true
-	if err := l.frame.tap(l.selector, opts); err != nil {
+	if err := l.tap(opts); err != nil {
 		return fmt.Errorf("tapping on %q: %w", l.selector, err)
 	}
 
@@ -582,6 +1014,15 @@ func (l *Locator) Tap(opts *FrameTapOptions) error {
 	return nil
 }
 
+func (l *Locator) tap(opts *FrameTapOptions) error {
+	opts.Strict, opts.strictSet = l.strict, true
+	f, err := l.targetFrame(&opts.Timeout)
+	if err != nil {
+		return err
+	}
+	return f.tap(l.selector, opts)
+}
+
 // DispatchEvent dispatches an event for the element matching the
 // locator's selector with strict mode on.
 func (l *Locator) DispatchEvent(typ string, eventInit any, opts *FrameDispatchEventOptions) error {
@@ -600,8 +1041,67 @@ func (l *Locator) DispatchEvent(typ string, eventInit any, opts *FrameDispatchEv
 }
 
 func (l *Locator) dispatchEvent(typ string, eventInit any, opts *FrameDispatchEventOptions) error {
-	opts.Strict = true
-	return l.frame.dispatchEvent(l.selector, typ, eventInit, opts)
+	opts.Strict, opts.strictSet = l.strict, true
+	f, err := l.targetFrame(&opts.Timeout)
+	if err != nil {
+		return err
+	}
+	return f.dispatchEvent(l.selector, typ, eventInit, opts)
+}
+
+// DragTo drags the element matching the locator's selector onto
+// the element matching the target locator's selector.
+func (l *Locator) DragTo(target *Locator, opts *FrameDragAndDropOptions) error {
+	l.log.Debugf(
+		"Locator:DragTo", "fid:%s furl:%q sel:%q target:%q opts:%+v",
+		l.frame.ID(), l.frame.URL(), l.selector, target.selector, opts,
+	)
+
+	if err := l.dragTo(target, opts); err != nil {
+		return fmt.Errorf("dragging %q to %q: %w", l.selector, target.selector, err)
+	}
+
+	applySlowMo(l.ctx)
+
+	return nil
+}
+
+func (l *Locator) dragTo(target *Locator, opts *FrameDragAndDropOptions) error {
+	sf, err := l.targetFrame(&opts.Timeout)
+	if err != nil {
+		return err
+	}
+	tf, err := target.targetFrame(&opts.Timeout)
+	if err != nil {
+		return err
+	}
+	if err := sf.dragFrom(l.selector, l.strict, opts.pointerOptions(opts.SourcePosition)); err != nil {
+		return err
+	}
+
+	if err := tf.dropAt(target.selector, target.strict, opts.pointerOptions(opts.TargetPosition)); err != nil {
+		return errors.Join(err, tf.page.Mouse.cancelDrag())
+	}
+
+	return nil
+}
+
+// DropFiles drops the files onto the element matching the locator's
+// selector, as if they were dragged there from outside the browser.
+func (l *Locator) DropFiles(files *Files, opts *ElementHandleBaseOptions) error {
+	l.log.Debugf("Locator:DropFiles", "fid:%s furl:%q sel:%q opts:%+v", l.frame.ID(), l.frame.URL(), l.selector, opts)
+
+	f, err := l.targetFrame(&opts.Timeout)
+	if err != nil {
+		return fmt.Errorf("dropping files on %q: %w", l.selector, err)
+	}
+	if err := f.dropFiles(l.selector, l.strict, files, opts); err != nil {
+		return fmt.Errorf("dropping files on %q: %w", l.selector, err)
+	}
+
+	applySlowMo(l.ctx)
+
+	return nil
 }
 
 // WaitFor waits for the element matching the locator's selector with strict mode on.
@@ -620,8 +1120,12 @@ func (l *Locator) WaitFor(opts sobek.Value) error {
 }
 
 func (l *Locator) waitFor(opts *FrameWaitForSelectorOptions) error {
-	opts.Strict = true
-	_, err := l.frame.waitFor(l.selector, opts, 20)
+	opts.Strict, opts.strictSet = l.strict, true
+	f, err := l.targetFrame(&opts.Timeout)
+	if err != nil {
+		return err
+	}
+	_, err = f.waitFor(l.selector, opts, 20)
 	return err
 }
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/chromedp/cdproto"
 	"github.com/chromedp/cdproto/cdp"
 	"github.com/chromedp/cdproto/input"
 	"github.com/grafana/sobek"
@@ -20,6 +21,14 @@ type Mouse struct {
 	x               float64
 	y               float64
 	button          input.MouseButton
+
+	// humanizer makes the mouse move along human-like paths.
+	// It is nil unless humanized input is enabled.
+	humanizer *humanizer
+
+	// drag holds the data of a native (HTML5) drag operation that was
+	// intercepted by the mouse. It is nil when there is no ongoing drag.
+	drag *input.DragData
 }
 
 // NewMouse creates a new mouse.
@@ -121,6 +130,9 @@ func (m *Mouse) Up(opts sobek.Value) error {
 
 func (m *Mouse) up(opts *MouseDownUpOptions) error {
 	m.button = input.None
+	if m.drag != nil {
+		return m.drop()
+	}
 	action := input.DispatchMouseEvent(input.MouseReleased, m.x, m.y).
 		WithButton(input.MouseButton(opts.Button)).
 		WithModifiers(input.Modifier(m.keyboard.modifiers)).
@@ -149,16 +161,237 @@ func (m *Mouse) move(x float64, y float64, opts *MouseMoveOptions) error {
 	fromY := m.y
 	m.x = x
 	m.y = y
-	for i := int64(1); i <= opts.Steps; i++ {
-		x := fromX + (m.x-fromX)*float64(i/opts.Steps)
-		y := fromY + (m.y-fromY)*float64(i/opts.Steps)
+	if m.humanizer != nil {
+		return m.humanizedMove(Position{X: fromX, Y: fromY}, Position{X: x, Y: y})
+	}
+	for _, p := range movePath(Position{X: fromX, Y: fromY}, Position{X: x, Y: y}, opts.Steps) {
+		if err := m.dispatchMove(p.X, p.Y); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// movePath returns the positions of a move that goes from one position
+// to the other along a straight line in the given number of steps.
+func movePath(from, to Position, steps int64) []Position {
+	path := make([]Position, 0, max(steps, 0))
+	for i := int64(1); i <= steps; i++ {
+		ratio := float64(i) / float64(steps)
+		path = append(path, Position{
+			X: from.X + (to.X-from.X)*ratio,
+			Y: from.Y + (to.Y-from.Y)*ratio,
+		})
+	}
+
+	return path
+}
+
+// Wheel will trigger a MouseWheel event in the browser at the current
+// mouse position. Scrolling happens asynchronously in the browser.
+func (m *Mouse) Wheel(deltaX float64, deltaY float64) error {
+	if err := m.wheel(deltaX, deltaY); err != nil {
+		return fmt.Errorf("scrolling the mouse wheel by x:%f y:%f: %w", deltaX, deltaY, err)
+	}
+	return nil
+}
+
+func (m *Mouse) wheel(deltaX float64, deltaY float64) error {
+	action := input.DispatchMouseEvent(input.MouseWheel, m.x, m.y).
+		WithDeltaX(deltaX).
+		WithDeltaY(deltaY).
+		WithModifiers(input.Modifier(m.keyboard.modifiers))
+	if err := action.Do(cdp.WithExecutor(m.ctx, m.session)); err != nil {
+		return fmt.Errorf("mouse wheel: %w", err)
+	}
+
+	return nil
+}
+
+// humanizedMove moves the mouse pointer along a human-like path,
+// ignoring the steps option.
+func (m *Mouse) humanizedMove(from, to Position) error {
+	for _, p := range m.humanizer.mousePath(from, to) {
+		if err := sleep(m.ctx, p.delay); err != nil {
+			return err
+		}
+		if err := m.dispatchMove(p.X, p.Y); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dispatchMove moves the mouse pointer to the given coordinates.
+// While the left button is pressed, the move might start a native
+// drag operation, which is then intercepted and continued with drag
+// events instead of mouse events.
+func (m *Mouse) dispatchMove(x, y float64) error {
+	if m.drag != nil {
+		return m.dispatchDrag(input.DragOver, x, y)
+	}
+	moveTo := func() error {
 		action := input.DispatchMouseEvent(input.MouseMoved, x, y).
 			WithButton(m.button).
 			WithModifiers(input.Modifier(m.keyboard.modifiers))
 		if err := action.Do(cdp.WithExecutor(m.ctx, m.session)); err != nil {
 			return fmt.Errorf("mouse move: %w", err)
 		}
+		return nil
+	}
+	if m.button != input.Left {
+		return moveTo()
+	}
+
+	return m.interceptDrag(x, y, moveTo)
+}
+
+// dragDetectorJS arms a listener for the next mousemove in a frame and
+// returns a function resolving to whether that move started a drag.
+const dragDetectorJS = `() => {
+	let didStartDrag = Promise.resolve(false);
+	let dragEvent = null;
+	const dragListener = (event) => dragEvent = event;
+	const mouseListener = () => {
+		didStartDrag = new Promise(resolve => {
+			window.addEventListener('dragstart', dragListener, { once: true, capture: true });
+			setTimeout(() => resolve(dragEvent ? !dragEvent.defaultPrevented : false), 0);
+		});
+	};
+	window.addEventListener('mousemove', mouseListener, { once: true, capture: true });
+	return () => didStartDrag;
+}`
+
+// interceptDrag runs the move function with drag interception enabled.
+// If the move starts a native drag in any of the page frames, the drag
+// data sent by the browser is kept so that the following moves and the
+// button release are dispatched as drag events.
+func (m *Mouse) interceptDrag(x, y float64, move func() error) error {
+	if err := m.interceptMoveDrag(move); err != nil {
+		return err
+	}
+	if m.drag == nil {
+		return nil
+	}
+
+	return m.dispatchDrag(input.DragEnter, x, y)
+}
+
+// interceptMoveDrag runs the move function, and keeps the drag data if
+// the move starts a drag. The interception is disabled afterwards even if
+// the move fails, so that the browser doesn't keep intercepting the drags.
+func (m *Mouse) interceptMoveDrag(move func() error) (err error) {
+	ctx, cancel := context.WithCancel(m.ctx)
+	defer cancel()
+
+	intercepted := make(chan Event)
+	m.session.on(ctx, []string{cdproto.EventInputDragIntercepted}, intercepted)
+
+	detectors := m.armDragDetectors()
+	if err := input.SetInterceptDrags(true).Do(cdp.WithExecutor(m.ctx, m.session)); err != nil {
+		return fmt.Errorf("enabling drag interception: %w", err)
+	}
+	defer func() {
+		// The interception is disabled even if the mouse context is done.
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(m.ctx), time.Second)
+		defer cancel()
+		derr := input.SetInterceptDrags(false).Do(cdp.WithExecutor(ctx, m.session))
+		if derr != nil && err == nil {
+			err = fmt.Errorf("disabling drag interception: %w", derr)
+		}
+	}()
+	if err := move(); err != nil {
+		return err
+	}
+	if !dragStarted(detectors) {
+		return nil
+	}
+	select {
+	case <-m.ctx.Done():
+		return fmt.Errorf("waiting for drag interception: %w", m.ctx.Err())
+	case <-time.After(m.timeoutSettings.timeout()):
+		return fmt.Errorf("waiting for drag interception: %w", ErrTimedOut)
+	case ev := <-intercepted:
+		if e, ok := ev.data.(*input.EventDragIntercepted); ok {
+			m.drag = e.Data
+		}
 	}
 
 	return nil
 }
+
+// armDragDetectors installs a drag detector in each frame of the page
+// that has a utility execution context, and returns their handles.
+func (m *Mouse) armDragDetectors() []JSHandleAPI {
+	var detectors []JSHandleAPI
+	for _, f := range m.frame.manager.Frames() {
+		f.executionContextMu.RLock()
+		ec := f.executionContexts[utilityWorld]
+		f.executionContextMu.RUnlock()
+		if ec == nil {
+			continue
+		}
+		h, err := ec.EvalHandle(m.ctx, dragDetectorJS)
+		if err != nil {
+			continue
+		}
+		detectors = append(detectors, h)
+	}
+
+	return detectors
+}
+
+// dragStarted reports whether any of the detectors saw a drag starting
+// and disposes all of them.
+func dragStarted(detectors []JSHandleAPI) bool {
+	var started bool
+	for _, h := range detectors {
+		v, err := h.Evaluate(`detector => detector()`)
+		if err == nil {
+			if b, ok := v.(bool); ok && b {
+				started = true
+			}
+		}
+		_ = h.Dispose()
+	}
+
+	return started
+}
+
+func (m *Mouse) dispatchDrag(typ input.DispatchDragEventType, x, y float64) error {
+	action := input.DispatchDragEvent(typ, x, y, m.drag).
+		WithModifiers(input.Modifier(m.keyboard.modifiers))
+	if err := action.Do(cdp.WithExecutor(m.ctx, m.session)); err != nil {
+		return fmt.Errorf("dispatching %s drag event: %w", typ, err)
+	}
+
+	return nil
+}
+
+// drop finishes the intercepted drag operation at the current position.
+func (m *Mouse) drop() error {
+	defer func() { m.drag = nil }()
+	if err := m.dispatchDrag(input.DragOver, m.x, m.y); err != nil {
+		return err
+	}
+
+	return m.dispatchDrag(input.Drop, m.x, m.y)
+}
+
+// cancelDrag cancels the drag operation started by pressing the mouse
+// button, so that a failed drop doesn't leave the button pressed or the
+// intercepted drag pending for the following actions.
+func (m *Mouse) cancelDrag() error {
+	if m.drag != nil {
+		defer func() { m.drag = nil }()
+		m.button = input.None
+		return m.dispatchDrag(input.DragCancel, m.x, m.y)
+	}
+	if m.button == input.None {
+		return nil
+	}
+
+	return m.up(NewMouseDownUpOptions())
+}
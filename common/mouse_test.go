@@ -0,0 +1,21 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMovePath(t *testing.T) {
+	t.Parallel()
+
+	from, to := Position{X: 0, Y: 10}, Position{X: 100, Y: 50}
+	assert.Equal(t, []Position{
+		{X: 25, Y: 20},
+		{X: 50, Y: 30},
+		{X: 75, Y: 40},
+		{X: 100, Y: 50},
+	}, movePath(from, to, 4))
+	assert.Equal(t, []Position{to}, movePath(from, to, 1))
+	assert.Empty(t, movePath(from, to, 0))
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
@@ -291,6 +292,37 @@ func handleURLTag(mi metricInterceptor, url string, method string, tags *k6metri
 	return tags
 }
 
+// pushCustomMetrics pushes the samples of the browser's custom metrics as
+// connected samples, tagged with the current tags of the VU and timestamped
+// with the current time. The url tag is set to url unless it's empty or the
+// url system tag is disabled.
+func pushCustomMetrics(
+	ctx context.Context, mi metricInterceptor, url string,
+	samples func(cm *k6ext.CustomMetrics) []k6metrics.Sample,
+) {
+	vu := k6ext.GetVU(ctx)
+	if vu == nil || vu.State() == nil {
+		return
+	}
+	state := vu.State()
+	cm := k6ext.GetCustomMetrics(ctx)
+	if cm == nil {
+		return
+	}
+
+	tags := state.Tags.GetCurrentValues().Tags
+	if state.Options.SystemTags.Has(k6metrics.TagURL) && url != "" {
+		tags = handleURLTag(mi, url, http.MethodGet, tags)
+	}
+	now := time.Now()
+	ss := samples(cm)
+	for i := range ss {
+		ss[i].Tags = tags
+		ss[i].Time = now
+	}
+	k6metrics.PushIfNotDone(ctx, state.Samples, k6metrics.ConnectedSamples{Samples: ss})
+}
+
 func (m *NetworkManager) handleRequestRedirect(
 	req *Request, redirectResponse *network.Response, timestamp *cdp.MonotonicTime,
 ) {
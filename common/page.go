@@ -7,8 +7,10 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/chromedp/cdproto"
@@ -42,6 +44,42 @@ const (
 
 	// EventPageMetricCalled represents the page.on('metric') event.
 	EventPageMetricCalled PageOnEventName = "metric"
+
+	// EventPageDialogOpened represents the page.on('dialog') event.
+	EventPageDialogOpened PageOnEventName = "dialog"
+
+	// EventPageDownloadStarted represents the page.on('download') event.
+	EventPageDownloadStarted PageOnEventName = "download"
+
+	// EventPageFileChooserOpened represents the page.on('filechooser') event.
+	EventPageFileChooserOpened PageOnEventName = "filechooser"
+
+	// EventPageErrorThrown represents the page.on('pageerror') event.
+	EventPageErrorThrown PageOnEventName = "pageerror"
+
+	// EventPagePopupOpened represents the page.on('popup') event.
+	EventPagePopupOpened PageOnEventName = "popup"
+
+	// EventPageClosed represents the page.on('close') event.
+	EventPageClosed PageOnEventName = "close"
+
+	// EventPageCrashed represents the page.on('crash') event.
+	EventPageCrashed PageOnEventName = "crash"
+
+	// EventPageLoaded represents the page.on('load') event.
+	EventPageLoaded PageOnEventName = "load"
+
+	// EventPageDOMContentLoaded represents the page.on('domcontentloaded') event.
+	EventPageDOMContentLoaded PageOnEventName = "domcontentloaded"
+
+	// EventPageFrameWasAttached represents the page.on('frameattached') event.
+	EventPageFrameWasAttached PageOnEventName = "frameattached"
+
+	// EventPageFrameWasDetached represents the page.on('framedetached') event.
+	EventPageFrameWasDetached PageOnEventName = "framedetached"
+
+	// EventPageFrameWasNavigated represents the page.on('framenavigated') event.
+	EventPageFrameWasNavigated PageOnEventName = "framenavigated"
 )
 
 // MediaType represents the type of media to emulate.
@@ -211,6 +249,8 @@ type Page struct {
 	// - FrameSession.initEvents.onFrameDetached->FrameManager.frameDetached.removeFramesRecursively->Page.IsClosed
 	closedMu sync.RWMutex
 	closed   bool
+	// closeFns are called when the page closes.
+	closeFns []func()
 
 	// TODO: setter change these fields (mutex?)
 	emulatedSize     *EmulatedSize
@@ -222,15 +262,34 @@ type Page struct {
 	backgroundPage bool
 
 	eventCh         chan Event
-	eventHandlers   map[PageOnEventName][]PageOnHandler
+	eventHandlers   map[PageOnEventName][]*pageOnEventHandler
 	eventHandlersMu sync.RWMutex
 
+	bindings bindings
+
+	// pageErrorMu protects the fields that fail the iteration
+	// with the first uncaught page error.
+	pageErrorMu      sync.Mutex
+	pageErrorFailure error
+	pageErrorFail    func(error)
+	pageErrorDone    func()
+
+	video *Video
+
+	screencastMu sync.Mutex
+	screencasts  int
+
 	mainFrameSession *FrameSession
 	frameSessions    map[cdp.FrameID]*FrameSession
 	frameSessionsMu  sync.RWMutex
-	workers          map[target.SessionID]*Worker
-	routes           []any // TODO: Implement
-	vu               k6modules.VU
+
+	// interceptsFileChooser is true once the page intercepts
+	// file choosers for the file chooser events.
+	interceptsFileChooser atomic.Bool
+
+	workers map[target.SessionID]*Worker
+	routes  []any // TODO: Implement
+	vu      k6modules.VU
 
 	logger *log.Logger
 }
@@ -261,7 +320,7 @@ func NewPage(
 		Keyboard:         NewKeyboard(ctx, s),
 		jsEnabled:        true,
 		eventCh:          make(chan Event),
-		eventHandlers:    make(map[PageOnEventName][]PageOnHandler),
+		eventHandlers:    make(map[PageOnEventName][]*pageOnEventHandler),
 		frameSessions:    make(map[cdp.FrameID]*FrameSession),
 		workers:          make(map[target.SessionID]*Worker),
 		vu:               k6ext.GetVU(ctx),
@@ -290,18 +349,35 @@ func NewPage(
 	p.frameSessions[cdp.FrameID(tid)] = p.mainFrameSession
 	p.frameSessionsMu.Unlock()
 	p.Mouse = NewMouse(ctx, s, p.frameManager.MainFrame(), bctx.timeoutSettings, p.Keyboard)
+	if h := newHumanizer(bctx.opts.Humanize); h != nil {
+		p.Mouse.humanizer = h
+		p.Keyboard.humanizer = h
+	}
 	p.Touchscreen = NewTouchscreen(ctx, s, p.Keyboard)
 
 	p.initEvents()
 
+	if bctx.opts.VideosPath != "" && !bp {
+		if err := p.startVideo(); err != nil {
+			return nil, err
+		}
+	}
+	if t := bctx.tracing; t != nil && !bp {
+		if err := t.onPage(&p); err != nil {
+			return nil, fmt.Errorf("tracing page: %w", err)
+		}
+	}
+
 	action := target.SetAutoAttach(true, true).WithFlatten(true)
 	if err := action.Do(cdp.WithExecutor(p.ctx, p.session)); err != nil {
 		return nil, fmt.Errorf("internal error while auto attaching to browser pages: %w", err)
 	}
 
-	add := runtime.AddBinding(webVitalBinding)
-	if err := add.Do(cdp.WithExecutor(p.ctx, p.session)); err != nil {
-		return nil, fmt.Errorf("internal error while adding binding to page: %w", err)
+	for _, binding := range []string{webVitalBinding, exposedBinding} {
+		add := runtime.AddBinding(binding)
+		if err := add.Do(cdp.WithExecutor(p.ctx, p.session)); err != nil {
+			return nil, fmt.Errorf("internal error while adding binding to page: %w", err)
+		}
 	}
 
 	if err := bctx.applyAllInitScripts(&p); err != nil {
@@ -457,24 +533,9 @@ func (p *Page) urlTagName(url string, method string) (string, bool) {
 		method: method,
 	}
 
-	p.eventHandlersMu.RLock()
-	defer p.eventHandlersMu.RUnlock()
-	for _, h := range p.eventHandlers[EventPageMetricCalled] {
-		err := func() error {
-			// Handlers can register other handlers, so we need to
-			// unlock the mutex before calling the next handler.
-			p.eventHandlersMu.RUnlock()
-			defer p.eventHandlersMu.RLock()
-
-			// Call and wait for the handler to complete.
-			return h(PageOnEvent{
-				Metric: em,
-			})
-		}()
-		if err != nil {
-			p.logger.Debugf("urlTagName", "handler returned an error: %v", err)
-			return "", false
-		}
+	// Call and wait for the handlers to complete.
+	if err := p.callPageOnHandlers(EventPageMetricCalled, PageOnEvent{Metric: em}); err != nil {
+		return "", false
 	}
 
 	// If a match was found then the name field in em will have been updated.
@@ -489,6 +550,10 @@ func (p *Page) urlTagName(url string, method string) (string, bool) {
 }
 
 func (p *Page) onConsoleAPICalled(event *runtime.EventConsoleAPICalled) {
+	if t := p.tracing(); t != nil {
+		t.onConsoleAPICalled(p, event)
+	}
+
 	if !hasPageOnHandler(p, EventPageConsoleAPICalled) {
 		return
 	}
@@ -499,17 +564,40 @@ func (p *Page) onConsoleAPICalled(event *runtime.EventConsoleAPICalled) {
 		return
 	}
 
-	p.eventHandlersMu.RLock()
-	defer p.eventHandlersMu.RUnlock()
-	for _, h := range p.eventHandlers[EventPageConsoleAPICalled] {
-		err := h(PageOnEvent{
-			ConsoleMessage: m,
-		})
-		if err != nil {
-			p.logger.Debugf("onConsoleAPICalled", "handler returned an error: %v", err)
-			return
-		}
+	_ = p.callPageOnHandlers(EventPageConsoleAPICalled, PageOnEvent{
+		ConsoleMessage: m,
+	})
+}
+
+// onDialog calls the page.on('dialog') handlers with the dialog.
+// It returns false if there are no handlers, in which case the
+// caller should handle the dialog. The handlers are responsible
+// for accepting or dismissing the dialog, otherwise the page stays
+// blocked by it.
+func (p *Page) onDialog(d *Dialog) bool {
+	if !hasPageOnHandler(p, EventPageDialogOpened) {
+		return false
+	}
+
+	_ = p.callPageOnHandlers(EventPageDialogOpened, PageOnEvent{
+		Dialog: d,
+	})
+
+	return true
+}
+
+// onDownload emits the download to the page.waitForEvent('download')
+// waiters, and calls the page.on('download') handlers with it.
+func (p *Page) onDownload(d *Download) {
+	p.emit(EventPageDownload, d)
+
+	if !hasPageOnHandler(p, EventPageDownloadStarted) {
+		return
 	}
+
+	_ = p.callPageOnHandlers(EventPageDownloadStarted, PageOnEvent{
+		Download: d,
+	})
 }
 
 func (p *Page) consoleMsgFromConsoleEvent(e *runtime.EventConsoleAPICalled) (*ConsoleMessage, error) {
@@ -560,18 +648,65 @@ func (p *Page) didClose() {
 	p.logger.Debugf("Page:didClose", "sid:%v", p.sessionID())
 
 	p.closedMu.Lock()
-	{
-		p.closed = true
-	}
+	p.closed = true
+	closeFns := p.closeFns
+	p.closeFns = nil
 	p.closedMu.Unlock()
 
 	p.emit(EventPageClose, p)
+	_ = p.callPageOnHandlers(EventPageClosed, PageOnEvent{Page: p})
+	p.stopFailingOnPageError()
+	for _, fn := range closeFns {
+		fn()
+	}
+
+	if p.video != nil {
+		go p.video.finish()
+	}
 }
 
 func (p *Page) didCrash() {
 	p.logger.Debugf("Page:didCrash", "sid:%v", p.sessionID())
 
 	p.emit(EventPageCrash, p)
+	_ = p.callPageOnHandlers(EventPageCrashed, PageOnEvent{Page: p})
+}
+
+// onMainFrameLifecycleEvent calls the page.on('load') and
+// page.on('domcontentloaded') handlers when the main frame
+// fires the load and DOMContentLoaded events.
+func (p *Page) onMainFrameLifecycleEvent(event LifecycleEvent) {
+	switch event { //nolint:exhaustive
+	case LifecycleEventLoad:
+		_ = p.callPageOnHandlers(EventPageLoaded, PageOnEvent{Page: p})
+	case LifecycleEventDOMContentLoad:
+		_ = p.callPageOnHandlers(EventPageDOMContentLoaded, PageOnEvent{Page: p})
+	}
+}
+
+// callPageOnHandlers calls the page.on handlers of the event with the
+// event data. It stops at, and returns, the first error of a handler.
+//
+// The handlers are called without holding the lock of the handlers,
+// so that they can add and remove handlers.
+func (p *Page) callPageOnHandlers(event PageOnEventName, ev PageOnEvent) error {
+	p.eventHandlersMu.RLock()
+	handlers := slices.Clone(p.eventHandlers[event])
+	p.eventHandlersMu.RUnlock()
+
+	for _, h := range handlers {
+		// A once handler is removed before it's called, so that
+		// it's called for a single event only.
+		if h.once && !p.removeEventHandler(event, h) {
+			continue
+		}
+		if err := h.handle(ev); err != nil {
+			p.logger.Debugf("Page:callPageOnHandlers", "event:%q handler returned an error: %v", event, err)
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (p *Page) evaluateOnNewDocument(source string) error {
@@ -800,6 +935,32 @@ func (p *Page) viewportSize() Size {
 	}
 }
 
+// AddInitScript adds a script that is evaluated in every frame of the page
+// when it's created or navigated, before the scripts of the document.
+func (p *Page) AddInitScript(script string) error {
+	p.logger.Debugf("Page:AddInitScript", "sid:%v", p.sessionID())
+
+	if err := p.evaluateOnNewDocument(script); err != nil {
+		return fmt.Errorf("adding init script to page: %w", err)
+	}
+
+	return nil
+}
+
+// AddScriptTag adds a script tag to the main frame of the page.
+func (p *Page) AddScriptTag(opts *FrameAddScriptTagOptions) (*ElementHandle, error) {
+	p.logger.Debugf("Page:AddScriptTag", "sid:%v", p.sessionID())
+
+	return p.MainFrame().AddScriptTag(opts)
+}
+
+// AddStyleTag adds a style tag to the main frame of the page.
+func (p *Page) AddStyleTag(opts *FrameAddStyleTagOptions) (*ElementHandle, error) {
+	p.logger.Debugf("Page:AddStyleTag", "sid:%v", p.sessionID())
+
+	return p.MainFrame().AddStyleTag(opts)
+}
+
 // BringToFront activates the browser tab for this page.
 func (p *Page) BringToFront() error {
 	p.logger.Debugf("Page:BringToFront", "sid:%v", p.sessionID())
@@ -851,7 +1012,7 @@ func (p *Page) Click(selector string, opts *FrameClickOptions) error {
 // Close closes the page.
 func (p *Page) Close(_ sobek.Value) error {
 	p.logger.Debugf("Page:Close", "sid:%v", p.sessionID())
-	_, span := TraceAPICall(p.ctx, p.targetID.String(), "page.close")
+	_, span := tracePageAPICall(p.ctx, p, "page.close")
 	defer span.End()
 
 	// forcing the pagehide event to trigger web vitals metrics.
@@ -920,6 +1081,13 @@ func (p *Page) DispatchEvent(selector string, typ string, eventInit any, opts *F
 	return p.MainFrame().DispatchEvent(selector, typ, eventInit, opts)
 }
 
+// DragAndDrop drags the source element onto the target element.
+func (p *Page) DragAndDrop(source, target string, opts *FrameDragAndDropOptions) error {
+	p.logger.Debugf("Page:DragAndDrop", "sid:%v source:%s target:%s", p.sessionID(), source, target)
+
+	return p.MainFrame().DragAndDrop(source, target, opts)
+}
+
 // EmulateMedia emulates the given media type.
 func (p *Page) EmulateMedia(opts sobek.Value) error {
 	p.logger.Debugf("Page:EmulateMedia", "sid:%v", p.sessionID())
@@ -1006,6 +1174,14 @@ func (p *Page) Focus(selector string, opts sobek.Value) error {
 	return p.MainFrame().Focus(selector, opts)
 }
 
+// FrameLocator creates and returns a new frame locator for the iframe that
+// matches the given selector in the main frame.
+func (p *Page) FrameLocator(selector string) *FrameLocator {
+	p.logger.Debugf("Page:FrameLocator", "sid:%s sel: %q", p.sessionID(), selector)
+
+	return p.MainFrame().FrameLocator(selector)
+}
+
 // Frames returns a list of frames on the page.
 func (p *Page) Frames() []*Frame {
 	return p.frameManager.Frames()
@@ -1038,9 +1214,9 @@ func (p *Page) GetTouchscreen() *Touchscreen {
 // Goto will navigate the page to the specified URL and return a HTTP response object.
 func (p *Page) Goto(url string, opts *FrameGotoOptions) (*Response, error) {
 	p.logger.Debugf("Page:Goto", "sid:%v url:%q", p.sessionID(), url)
-	_, span := TraceAPICall(
+	_, span := tracePageAPICall(
 		p.ctx,
-		p.targetID.String(),
+		p,
 		"page.goto",
 		trace.WithAttributes(attribute.String("page.goto.url", url)),
 	)
@@ -1090,6 +1266,21 @@ func (p *Page) IsClosed() bool {
 	return p.closed
 }
 
+// OnClose registers fn to be called once the page closes, after the
+// page.on('close') handlers. fn is called right away if the page is
+// already closed.
+func (p *Page) OnClose(fn func()) {
+	p.closedMu.Lock()
+	if !p.closed {
+		p.closeFns = append(p.closeFns, fn)
+		p.closedMu.Unlock()
+		return
+	}
+	p.closedMu.Unlock()
+
+	fn()
+}
+
 // IsDisabled returns true if the first element that matches the selector
 // is disabled. Otherwise, returns false.
 func (p *Page) IsDisabled(selector string, opts sobek.Value) (bool, error) {
@@ -1174,23 +1365,147 @@ type PageOnEvent struct {
 
 	// Metric is the metric event event.
 	Metric *MetricEvent
+
+	// Dialog is the dialog event.
+	Dialog *Dialog
+
+	// Download is the download event.
+	Download *Download
+
+	// FileChooser is the file chooser event.
+	FileChooser *FileChooser
+
+	// PageError is the page error event.
+	PageError *PageError
+
+	// Popup is the popup event.
+	Popup *Page
+
+	// Page is the page of the close, crash, load and
+	// domcontentloaded events.
+	Page *Page
+
+	// Frame is the frame of the frameattached, framedetached
+	// and framenavigated events.
+	Frame *Frame
+}
+
+// pageOnEventHandler is a handler that is subscribed to a page event.
+type pageOnEventHandler struct {
+	key    any
+	once   bool
+	handle PageOnHandler
 }
 
 // On subscribes to a page event for which the given handler will be executed
-// passing in the ConsoleMessage associated with the event.
-// The only accepted event value is 'console'.
+// passing in the data associated with the event, such as the ConsoleMessage
+// of the 'console' event, or the Dialog of the 'dialog' event.
 func (p *Page) On(event PageOnEventName, handler PageOnHandler) error {
+	return p.AddEventHandler(event, nil, handler, false)
+}
+
+// AddEventHandler subscribes the handler to a page event in the same way
+// as On. The key identifies the handler for RemoveEventHandler, and can be
+// nil if the handler is never removed on its own. If once is true, the
+// handler is removed after it is called for the first event.
+func (p *Page) AddEventHandler(event PageOnEventName, key any, handler PageOnHandler, once bool) error {
+	if event == EventPageFileChooserOpened {
+		if err := p.interceptFileChooser(); err != nil {
+			return err
+		}
+	}
+
 	p.eventHandlersMu.Lock()
 	defer p.eventHandlersMu.Unlock()
 
-	if _, ok := p.eventHandlers[event]; !ok {
-		p.eventHandlers[event] = make([]PageOnHandler, 0, 1)
-	}
-	p.eventHandlers[event] = append(p.eventHandlers[event], handler)
+	p.eventHandlers[event] = append(p.eventHandlers[event], &pageOnEventHandler{
+		key:    key,
+		once:   once,
+		handle: handler,
+	})
 
 	return nil
 }
 
+// RemoveEventHandler unsubscribes the most recently added handler of the
+// key from a page event. It returns false if there is no such handler.
+func (p *Page) RemoveEventHandler(event PageOnEventName, key any) bool {
+	if key == nil {
+		return false
+	}
+
+	p.eventHandlersMu.Lock()
+	defer p.eventHandlersMu.Unlock()
+
+	handlers := p.eventHandlers[event]
+	for i := len(handlers) - 1; i >= 0; i-- {
+		if handlers[i].key == key {
+			p.deleteEventHandler(event, i)
+			return true
+		}
+	}
+
+	return false
+}
+
+// RemoveAllEventHandlers unsubscribes all the handlers from a page event,
+// or from all the page events if the event is empty.
+func (p *Page) RemoveAllEventHandlers(event PageOnEventName) {
+	p.eventHandlersMu.Lock()
+	defer p.eventHandlersMu.Unlock()
+
+	if event == "" {
+		clear(p.eventHandlers)
+		return
+	}
+	delete(p.eventHandlers, event)
+}
+
+// EventHandlerCount returns the number of handlers that are subscribed to
+// a page event, or to all the page events if the event is empty.
+func (p *Page) EventHandlerCount(event PageOnEventName) int {
+	p.eventHandlersMu.RLock()
+	defer p.eventHandlersMu.RUnlock()
+
+	if event != "" {
+		return len(p.eventHandlers[event])
+	}
+	var n int
+	for _, handlers := range p.eventHandlers {
+		n += len(handlers)
+	}
+
+	return n
+}
+
+// removeEventHandler unsubscribes the handler from a page event.
+// It returns false if the handler has already been removed.
+func (p *Page) removeEventHandler(event PageOnEventName, h *pageOnEventHandler) bool {
+	p.eventHandlersMu.Lock()
+	defer p.eventHandlersMu.Unlock()
+
+	i := slices.Index(p.eventHandlers[event], h)
+	if i < 0 {
+		return false
+	}
+	p.deleteEventHandler(event, i)
+
+	return true
+}
+
+// deleteEventHandler deletes the handler at the index from the handlers
+// of a page event. The event is deleted when it has no handlers left, so
+// that hasPageOnHandler reports it correctly. It must be called with the
+// handlers lock held.
+func (p *Page) deleteEventHandler(event PageOnEventName, i int) {
+	handlers := slices.Delete(p.eventHandlers[event], i, i+1)
+	if len(handlers) == 0 {
+		delete(p.eventHandlers, event)
+		return
+	}
+	p.eventHandlers[event] = handlers
+}
+
 // Opener returns the opener of the target.
 func (p *Page) Opener() *Page {
 	return p.opener
@@ -1220,7 +1535,7 @@ func (p *Page) QueryAll(selector string) ([]*ElementHandle, error) {
 // Reload will reload the current page.
 func (p *Page) Reload(opts sobek.Value) (*Response, error) { //nolint:funlen
 	p.logger.Debugf("Page:Reload", "sid:%v", p.sessionID())
-	_, span := TraceAPICall(p.ctx, p.targetID.String(), "page.reload")
+	_, span := tracePageAPICall(p.ctx, p, "page.reload")
 	defer span.End()
 
 	reloadOpts := NewPageReloadOptions(
@@ -1322,12 +1637,19 @@ func (p *Page) Reload(opts sobek.Value) (*Response, error) { //nolint:funlen
 
 // Screenshot will instruct Chrome to save a screenshot of the current page and save it to specified file.
 func (p *Page) Screenshot(opts *PageScreenshotOptions, sp ScreenshotPersister) ([]byte, error) {
-	spanCtx, span := TraceAPICall(p.ctx, p.targetID.String(), "page.screenshot")
+	spanCtx, span := tracePageAPICall(p.ctx, p, "page.screenshot")
 	defer span.End()
 
 	span.SetAttributes(attribute.String("screenshot.path", opts.Path))
 
-	s := newScreenshotter(spanCtx, sp, p.logger)
+	ctx := spanCtx
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(spanCtx, opts.Timeout)
+		defer cancel()
+	}
+
+	s := newScreenshotter(ctx, sp, p.logger)
 	buf, err := s.screenshotPage(p, opts)
 	if err != nil {
 		err := fmt.Errorf("taking screenshot of page: %w", err)
@@ -1338,6 +1660,60 @@ func (p *Page) Screenshot(opts *PageScreenshotOptions, sp ScreenshotPersister) (
 	return buf, err
 }
 
+// ScrollUntil scrolls the page with the mouse wheel until the given
+// condition holds. It checks the condition before each scroll, and
+// returns the number of scrolls it took for the condition to hold.
+// It returns an error if the condition doesn't hold after
+// opts.MaxScrolls scrolls.
+func (p *Page) ScrollUntil(until func() (bool, error), opts *PageScrollUntilOptions) (int64, error) {
+	p.logger.Debugf("Page:ScrollUntil", "sid:%v opts:%+v", p.sessionID(), opts)
+
+	step := opts.Step
+	if step == 0 {
+		step = p.viewportSize().Height
+	}
+	for scrolls := int64(0); ; scrolls++ {
+		ok, err := until()
+		if err != nil {
+			return scrolls, fmt.Errorf("scrolling until condition: %w", err)
+		}
+		if ok {
+			return scrolls, nil
+		}
+		if scrolls >= opts.MaxScrolls {
+			return scrolls, fmt.Errorf("scrolling until condition: condition not met after %d scrolls", scrolls)
+		}
+		if err := p.Mouse.wheel(0, step); err != nil {
+			return scrolls, fmt.Errorf("scrolling until condition: %w", err)
+		}
+		if err := p.waitForScroll(opts.Delay); err != nil {
+			return scrolls, fmt.Errorf("scrolling until condition: %w", err)
+		}
+	}
+}
+
+// waitForScroll waits for the page to render the scrolled content,
+// so that scroll handlers and intersection observers run, and then
+// waits for the given delay.
+func (p *Page) waitForScroll(delay time.Duration) error {
+	const rafs = `() => new Promise(resolve => requestAnimationFrame(() => requestAnimationFrame(resolve)))`
+	if _, err := p.MainFrame().EvaluateWithContext(p.ctx, rafs); err != nil {
+		return fmt.Errorf("waiting for scroll: %w", err)
+	}
+	if delay <= 0 {
+		return nil
+	}
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-p.ctx.Done():
+		return fmt.Errorf("waiting for scroll: %w", p.ctx.Err())
+	case <-t.C:
+	}
+
+	return nil
+}
+
 // SelectOption selects the given options and returns the array of
 // option values of the first element found that matches the selector.
 func (p *Page) SelectOption(selector string, values sobek.Value, opts sobek.Value) ([]string, error) {
@@ -1505,6 +1881,74 @@ func (p *Page) ViewportSize() map[string]float64 {
 	}
 }
 
+// pageWaitForEvents are the events that page.waitForEvent can wait for.
+var pageWaitForEvents = map[string]bool{ //nolint:gochecknoglobals
+	EventPageDownload:    true,
+	EventPageFilechooser: true,
+	EventPagePopup:       true,
+}
+
+// WaitForEvent waits for the page event and returns its data, such as the
+// Download of the download event. If the predicate is not nil, it waits
+// for the first event data for which the predicate returns true.
+func (p *Page) WaitForEvent(event string, predicate func(data any) (bool, error), timeout time.Duration) (any, error) {
+	wait, err := p.ExpectEvent(event, predicate, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return wait()
+}
+
+// ExpectEvent starts waiting for the page event, and returns a function
+// that blocks until the event is emitted and returns its data the way
+// WaitForEvent does. Starting to wait before the action that causes the
+// event makes sure that the event is not missed, and that the file chooser
+// is intercepted before it opens.
+func (p *Page) ExpectEvent(
+	event string, predicate func(data any) (bool, error), timeout time.Duration,
+) (wait func() (any, error), _ error) {
+	p.logger.Debugf("Page:ExpectEvent", "sid:%v event:%q", p.sessionID(), event)
+
+	if !pageWaitForEvents[event] {
+		return nil, fmt.Errorf("waiting for page event: unsupported event %q", event)
+	}
+	if event == EventPageFilechooser {
+		if err := p.interceptFileChooser(); err != nil {
+			return nil, fmt.Errorf("waiting for page event: %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(p.ctx, timeout)
+	ch := make(chan Event)
+	p.on(ctx, []string{event}, ch)
+
+	return func() (any, error) {
+		defer cancel() // This will remove the event handler once we return from here.
+
+		for {
+			select {
+			case <-ctx.Done():
+				if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					return nil, fmt.Errorf("waitForEvent %q timed out after %v", event, timeout)
+				}
+				return nil, ctx.Err() //nolint:wrapcheck
+			case ev := <-ch:
+				if predicate == nil {
+					return ev.data, nil
+				}
+				ok, err := predicate(ev.data)
+				if err != nil {
+					return nil, fmt.Errorf("predicate function failed: %w", err)
+				}
+				if ok {
+					return ev.data, nil
+				}
+			}
+		}
+	}, nil
+}
+
 // WaitForFunction waits for the given predicate to return a truthy value.
 func (p *Page) WaitForFunction(js string, opts *FrameWaitForFunctionOptions, jsArgs ...any) (any, error) {
 	p.logger.Debugf("Page:WaitForFunction", "sid:%v", p.sessionID())
@@ -1521,7 +1965,7 @@ func (p *Page) WaitForLoadState(state string, opts sobek.Value) error {
 // WaitForNavigation waits for the given navigation lifecycle event to happen.
 func (p *Page) WaitForNavigation(opts *FrameWaitForNavigationOptions) (*Response, error) {
 	p.logger.Debugf("Page:WaitForNavigation", "sid:%v", p.sessionID())
-	_, span := TraceAPICall(p.ctx, p.targetID.String(), "page.waitForNavigation")
+	_, span := tracePageAPICall(p.ctx, p, "page.waitForNavigation")
 	defer span.End()
 
 	resp, err := p.frameManager.MainFrame().WaitForNavigation(opts)
@@ -1546,7 +1990,7 @@ func (p *Page) WaitForSelector(selector string, opts sobek.Value) (*ElementHandl
 func (p *Page) WaitForTimeout(timeout int64) {
 	p.logger.Debugf("Page:WaitForTimeout", "sid:%v timeout:%d", p.sessionID(), timeout)
 
-	_, span := TraceAPICall(p.ctx, p.targetID.String(), "page.waitForTimeout")
+	_, span := tracePageAPICall(p.ctx, p, "page.waitForTimeout")
 	defer span.End()
 
 	p.frameManager.MainFrame().WaitForTimeout(timeout)
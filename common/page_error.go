@@ -0,0 +1,195 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	cdpruntime "github.com/chromedp/cdproto/runtime"
+	k6metrics "go.k6.io/k6/metrics"
+
+	"github.com/grafana/xk6-browser/k6ext"
+)
+
+// PageError represents an uncaught exception that is thrown in a page.
+type PageError struct {
+	// Name is the name of the error, such as TypeError, or an empty
+	// string if the thrown value is not an error.
+	Name string
+	// Message is the message of the error, or the thrown value if it
+	// is not an error.
+	Message string
+	// Stack is the stack trace of the error.
+	Stack string
+	// URL is the URL of the script that threw the error.
+	URL string
+}
+
+// NewPageError returns a new page error from the details of an
+// uncaught exception.
+func NewPageError(details *cdpruntime.ExceptionDetails) *PageError {
+	pe := PageError{
+		Message: details.Text,
+		URL:     details.URL,
+	}
+
+	var frames []*cdpruntime.CallFrame
+	if details.StackTrace != nil {
+		frames = details.StackTrace.CallFrames
+	}
+	if pe.URL == "" && len(frames) > 0 {
+		pe.URL = frames[0].URL
+	}
+
+	exc := details.Exception
+	if exc == nil || exc.Subtype != cdpruntime.SubtypeError {
+		if msg := parseExceptionDetails(details); msg != "" {
+			pe.Message = msg
+		}
+		pe.Stack = formatCallFrames(frames)
+
+		return &pe
+	}
+
+	// The description of an error is its stack, which starts with
+	// the name and the message of the error, such as:
+	//
+	//	TypeError: foo is not a function
+	//	    at bar (https://example.com/app.js:1:2)
+	pe.Name = exc.ClassName
+	pe.Stack = exc.Description
+	msg, _, _ := strings.Cut(exc.Description, "\n    at ")
+	switch {
+	case msg == pe.Name:
+		pe.Message = ""
+	case strings.HasPrefix(msg, pe.Name+": "):
+		pe.Message = strings.TrimPrefix(msg, pe.Name+": ")
+	default:
+		pe.Message = msg
+	}
+	if pe.Stack == "" {
+		pe.Stack = formatCallFrames(frames)
+	}
+
+	return &pe
+}
+
+// Error returns the name and the message of the page error.
+func (pe *PageError) Error() string {
+	if pe.Name == "" {
+		return pe.Message
+	}
+	if pe.Message == "" {
+		return pe.Name
+	}
+
+	return pe.Name + ": " + pe.Message
+}
+
+// formatCallFrames formats the call frames of a stack trace in the
+// same way as the stack of a JavaScript error.
+func formatCallFrames(frames []*cdpruntime.CallFrame) string {
+	var sb strings.Builder
+	for i, f := range frames {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		fn := f.FunctionName
+		if fn == "" {
+			fn = "<anonymous>"
+		}
+		// Line and column numbers of call frames are zero-based.
+		fmt.Fprintf(&sb, "    at %s (%s:%d:%d)", fn, f.URL, f.LineNumber+1, f.ColumnNumber+1)
+	}
+
+	return sb.String()
+}
+
+// onPageError emits the page error of an uncaught exception, calls the
+// page.on('pageerror') handlers with it, and counts it in the page
+// errors metric. If the browser context is set to fail on page errors,
+// it fails the current iteration.
+func (p *Page) onPageError(details *cdpruntime.ExceptionDetails) {
+	p.emit(EventPageError, details)
+
+	pe := NewPageError(details)
+	p.emitPageErrorMetric(pe)
+
+	if p.browserCtx != nil && p.browserCtx.opts.FailOnPageError {
+		p.failIteration(pe)
+	}
+
+	_ = p.callPageOnHandlers(EventPageErrorThrown, PageOnEvent{
+		PageError: pe,
+	})
+}
+
+// emitPageErrorMetric counts the page error in the page errors metric.
+func (p *Page) emitPageErrorMetric(pe *PageError) {
+	pushCustomMetrics(p.ctx, p, pe.URL, func(cm *k6ext.CustomMetrics) []k6metrics.Sample {
+		return []k6metrics.Sample{
+			{
+				TimeSeries: k6metrics.TimeSeries{Metric: cm.BrowserPageErrors},
+				Value:      1,
+			},
+		}
+	})
+}
+
+// FailIterationOnPageError makes the first uncaught page error fail the
+// current iteration, if the browser context is set to fail on page errors.
+// The error is recorded and passed to fail, which is called off the event
+// loop, so it must hand the error over to the event loop, such as with a
+// task queue that is created on the event loop. done is called when the
+// page closes, or right away if the page doesn't fail on page errors, after
+// which fail is no longer called.
+func (p *Page) FailIterationOnPageError(fail func(error), done func()) {
+	if p.browserCtx == nil || !p.browserCtx.opts.FailOnPageError {
+		done()
+		return
+	}
+
+	p.pageErrorMu.Lock()
+	p.pageErrorFail, p.pageErrorDone = fail, done
+	err := p.pageErrorFailure
+	p.pageErrorMu.Unlock()
+
+	// The page error might be thrown before fail is set.
+	if err != nil {
+		fail(err)
+	}
+	if p.IsClosed() {
+		p.stopFailingOnPageError()
+	}
+}
+
+// failIteration records the first page error that fails the current
+// iteration, and passes it to the function set with
+// [Page.FailIterationOnPageError].
+func (p *Page) failIteration(pe *PageError) {
+	p.pageErrorMu.Lock()
+	if p.pageErrorFailure != nil {
+		p.pageErrorMu.Unlock()
+		return
+	}
+	err := fmt.Errorf("uncaught page error on %q: %w", pe.URL, pe)
+	p.pageErrorFailure = err
+	fail := p.pageErrorFail
+	p.pageErrorMu.Unlock()
+
+	if fail != nil {
+		fail(err)
+	}
+}
+
+// stopFailingOnPageError stops failing the iteration with the page errors
+// of the closed page.
+func (p *Page) stopFailingOnPageError() {
+	p.pageErrorMu.Lock()
+	done := p.pageErrorDone
+	p.pageErrorFail, p.pageErrorDone = nil, nil
+	p.pageErrorMu.Unlock()
+
+	if done != nil {
+		done()
+	}
+}
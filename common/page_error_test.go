@@ -0,0 +1,156 @@
+package common
+
+import (
+	"testing"
+
+	cdpruntime "github.com/chromedp/cdproto/runtime"
+	"github.com/mailru/easyjson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPageError(t *testing.T) {
+	t.Parallel()
+
+	stackTrace := &cdpruntime.StackTrace{
+		CallFrames: []*cdpruntime.CallFrame{
+			{FunctionName: "bar", URL: "https://example.com/app.js", LineNumber: 9, ColumnNumber: 4},
+			{URL: "https://example.com/app.js", LineNumber: 19, ColumnNumber: 0},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		details *cdpruntime.ExceptionDetails
+		want    *PageError
+	}{
+		{
+			name: "error",
+			details: &cdpruntime.ExceptionDetails{
+				Text: "Uncaught",
+				URL:  "https://example.com/app.js",
+				Exception: &cdpruntime.RemoteObject{
+					Type:        cdpruntime.TypeObject,
+					Subtype:     cdpruntime.SubtypeError,
+					ClassName:   "TypeError",
+					Description: "TypeError: foo is not a function\n    at bar (https://example.com/app.js:10:5)",
+				},
+			},
+			want: &PageError{
+				Name:    "TypeError",
+				Message: "foo is not a function",
+				Stack:   "TypeError: foo is not a function\n    at bar (https://example.com/app.js:10:5)",
+				URL:     "https://example.com/app.js",
+			},
+		},
+		{
+			name: "error_without_message",
+			details: &cdpruntime.ExceptionDetails{
+				Text: "Uncaught",
+				Exception: &cdpruntime.RemoteObject{
+					Type:        cdpruntime.TypeObject,
+					Subtype:     cdpruntime.SubtypeError,
+					ClassName:   "Error",
+					Description: "Error\n    at bar (https://example.com/app.js:10:5)",
+				},
+				StackTrace: stackTrace,
+			},
+			want: &PageError{
+				Name:  "Error",
+				Stack: "Error\n    at bar (https://example.com/app.js:10:5)",
+				URL:   "https://example.com/app.js",
+			},
+		},
+		{
+			name: "thrown_value",
+			details: &cdpruntime.ExceptionDetails{
+				Text: "Uncaught",
+				Exception: &cdpruntime.RemoteObject{
+					Type:  cdpruntime.TypeString,
+					Value: easyjson.RawMessage(`"oops"`),
+				},
+				StackTrace: stackTrace,
+			},
+			want: &PageError{
+				Message: "oops",
+				Stack: "    at bar (https://example.com/app.js:10:5)\n" +
+					"    at <anonymous> (https://example.com/app.js:20:1)",
+				URL: "https://example.com/app.js",
+			},
+		},
+		{
+			name: "no_exception",
+			details: &cdpruntime.ExceptionDetails{
+				Text: "Uncaught SyntaxError: Unexpected token",
+				URL:  "https://example.com/app.js",
+			},
+			want: &PageError{
+				Message: "Uncaught SyntaxError: Unexpected token",
+				URL:     "https://example.com/app.js",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, NewPageError(tt.details))
+		})
+	}
+}
+
+func TestPageErrorError(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "TypeError: foo", (&PageError{Name: "TypeError", Message: "foo"}).Error())
+	assert.Equal(t, "Error", (&PageError{Name: "Error"}).Error())
+	assert.Equal(t, "oops", (&PageError{Message: "oops"}).Error())
+}
+
+func TestPageFailIterationOnPageError(t *testing.T) {
+	t.Parallel()
+
+	newPage := func(failOnPageError bool) *Page {
+		return &Page{
+			browserCtx: &BrowserContext{
+				opts: &BrowserContextOptions{FailOnPageError: failOnPageError},
+			},
+		}
+	}
+	pe := &PageError{Name: "TypeError", Message: "boom", URL: "https://example.com/app.js"}
+
+	t.Run("fail", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			errs []error
+			done int
+		)
+		p := newPage(true)
+		// The error thrown before the function is set is not lost.
+		p.failIteration(pe)
+		p.FailIterationOnPageError(func(err error) { errs = append(errs, err) }, func() { done++ })
+		// Only the first error fails the iteration.
+		p.failIteration(&PageError{Message: "later"})
+
+		require.Len(t, errs, 1)
+		require.ErrorIs(t, errs[0], pe)
+		assert.EqualError(t, errs[0], `uncaught page error on "https://example.com/app.js": TypeError: boom`)
+		assert.Zero(t, done)
+
+		p.stopFailingOnPageError()
+		p.stopFailingOnPageError()
+		assert.Equal(t, 1, done)
+	})
+	t.Run("no_fail", func(t *testing.T) {
+		t.Parallel()
+
+		var done int
+		p := newPage(false)
+		p.FailIterationOnPageError(func(error) { t.Error("unexpected page error failure") }, func() { done++ })
+		p.failIteration(pe)
+
+		assert.Equal(t, 1, done)
+	})
+}
@@ -3,6 +3,7 @@ package common
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,18 +19,93 @@ type PageEmulateMediaOptions struct {
 	ReducedMotion ReducedMotion `json:"reducedMotion"`
 }
 
+// PagePDFOptions are options for Page.PDF. The lengths are in inches.
+type PagePDFOptions struct {
+	// Path is the path to save the PDF to.
+	Path string `json:"path"`
+	// Width and Height are the paper size, which is either set by
+	// the paper format, or by the width and height options.
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+	// Landscape sets the paper orientation to landscape.
+	Landscape bool `json:"landscape"`
+	// Margin is the margin of the paper.
+	Margin PagePDFMargin `json:"margin"`
+	// PrintBackground prints the background graphics.
+	PrintBackground bool `json:"printBackground" js:"printBackground"`
+	// Scale is the scale of the webpage rendering, between 0.1 and 2.
+	Scale float64 `json:"scale"`
+	// PageRanges are the page ranges to print, such as "1-5, 8".
+	// An empty string prints all the pages.
+	PageRanges string `json:"pageRanges" js:"pageRanges"`
+	// DisplayHeaderFooter displays the header and the footer.
+	DisplayHeaderFooter bool `json:"displayHeaderFooter" js:"displayHeaderFooter"`
+	// HeaderTemplate and FooterTemplate are the HTML templates of the
+	// header and the footer.
+	HeaderTemplate string `json:"headerTemplate" js:"headerTemplate"`
+	FooterTemplate string `json:"footerTemplate" js:"footerTemplate"`
+	// PreferCSSPageSize prefers the page size of the CSS @page rule
+	// to the paper size.
+	PreferCSSPageSize bool `json:"preferCSSPageSize" js:"preferCSSPageSize"`
+}
+
+// PagePDFMargin is the margin of the paper in inches.
+type PagePDFMargin struct {
+	Top    float64 `json:"top"`
+	Right  float64 `json:"right"`
+	Bottom float64 `json:"bottom"`
+	Left   float64 `json:"left"`
+}
+
+// pdfPaperFormats are the sizes of the paper formats in inches.
+var pdfPaperFormats = map[string][2]float64{ //nolint:gochecknoglobals
+	"letter":  {8.5, 11},
+	"legal":   {8.5, 14},
+	"tabloid": {11, 17},
+	"ledger":  {17, 11},
+	"a0":      {33.1, 46.8},
+	"a1":      {23.4, 33.1},
+	"a2":      {16.54, 23.4},
+	"a3":      {11.7, 16.54},
+	"a4":      {8.27, 11.7},
+	"a5":      {5.83, 8.27},
+	"a6":      {4.13, 5.83},
+}
+
+// pdfLengthUnits are the number of units in an inch.
+var pdfLengthUnits = map[string]float64{ //nolint:gochecknoglobals
+	"px": 96,
+	"in": 1,
+	"cm": 2.54,
+	"mm": 25.4,
+}
+
 type PageReloadOptions struct {
 	WaitUntil LifecycleEvent `json:"waitUntil" js:"waitUntil"`
 	Timeout   time.Duration  `json:"timeout"`
 }
 
+// PageScrollUntilOptions are options for Page.ScrollUntil.
+type PageScrollUntilOptions struct {
+	// MaxScrolls is the maximum number of mouse wheel scrolls.
+	MaxScrolls int64 `json:"maxScrolls" js:"maxScrolls"`
+	// Step is the vertical distance of a single scroll in pixels.
+	// Zero means the height of the viewport.
+	Step float64 `json:"step"`
+	// Delay is the time to wait after each scroll, giving the page
+	// time to load more content.
+	Delay time.Duration `json:"delay"`
+}
+
 type PageScreenshotOptions struct {
+	ScreenshotBaseOptions
 	Clip           *page.Viewport `json:"clip"`
 	Path           string         `json:"path"`
 	Format         ImageFormat    `json:"format"`
 	FullPage       bool           `json:"fullPage"`
 	OmitBackground bool           `json:"omitBackground"`
 	Quality        int64          `json:"quality"`
+	Timeout        time.Duration  `json:"timeout"`
 }
 
 func NewPageEmulateMediaOptions(
@@ -61,6 +137,127 @@ func (o *PageEmulateMediaOptions) Parse(ctx context.Context, opts sobek.Value) e
 	return nil
 }
 
+// NewPagePDFOptions returns the default page PDF options,
+// which print a letter-sized PDF without margins.
+func NewPagePDFOptions() *PagePDFOptions {
+	size := pdfPaperFormats["letter"]
+	return &PagePDFOptions{
+		Width:  size[0],
+		Height: size[1],
+		Scale:  1,
+	}
+}
+
+// Parse parses the page PDF options.
+func (o *PagePDFOptions) Parse(ctx context.Context, opts sobek.Value) error { //nolint:cyclop
+	if !sobekValueExists(opts) {
+		return nil
+	}
+
+	rt := k6ext.Runtime(ctx)
+	obj := opts.ToObject(rt)
+	var err error
+	for _, k := range obj.Keys() {
+		v := obj.Get(k)
+		switch k {
+		case "displayHeaderFooter":
+			o.DisplayHeaderFooter = v.ToBoolean()
+		case "footerTemplate":
+			o.FooterTemplate = v.String()
+		case "format":
+			size, ok := pdfPaperFormats[strings.ToLower(v.String())]
+			if !ok {
+				return fmt.Errorf("parsing pdf options: unknown paper format %q", v.String())
+			}
+			o.Width, o.Height = size[0], size[1]
+		case "headerTemplate":
+			o.HeaderTemplate = v.String()
+		case "landscape":
+			o.Landscape = v.ToBoolean()
+		case "margin":
+			err = o.parseMargin(rt, v)
+		case "pageRanges":
+			o.PageRanges = v.String()
+		case "path":
+			o.Path = v.String()
+		case "preferCSSPageSize":
+			o.PreferCSSPageSize = v.ToBoolean()
+		case "printBackground":
+			o.PrintBackground = v.ToBoolean()
+		case "scale":
+			o.Scale = v.ToFloat()
+			if o.Scale < 0.1 || o.Scale > 2 {
+				err = fmt.Errorf("scale %v is not between 0.1 and 2", o.Scale)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("parsing pdf options: %w", err)
+		}
+	}
+
+	// The width and the height take precedence over the format.
+	for k, dst := range map[string]*float64{"width": &o.Width, "height": &o.Height} {
+		if v := obj.Get(k); sobekValueExists(v) {
+			if *dst, err = parsePDFLength(v); err != nil {
+				return fmt.Errorf("parsing pdf options: %s: %w", k, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (o *PagePDFOptions) parseMargin(rt *sobek.Runtime, margin sobek.Value) error {
+	if !sobekValueExists(margin) {
+		return nil
+	}
+	obj := margin.ToObject(rt)
+	sides := map[string]*float64{
+		"top":    &o.Margin.Top,
+		"right":  &o.Margin.Right,
+		"bottom": &o.Margin.Bottom,
+		"left":   &o.Margin.Left,
+	}
+	for k, dst := range sides {
+		v := obj.Get(k)
+		if !sobekValueExists(v) {
+			continue
+		}
+		var err error
+		if *dst, err = parsePDFLength(v); err != nil {
+			return fmt.Errorf("margin %s: %w", k, err)
+		}
+	}
+
+	return nil
+}
+
+// parsePDFLength parses a length, which is either a number of pixels or
+// a string with a px, in, cm or mm unit, and returns it in inches.
+func parsePDFLength(v sobek.Value) (float64, error) {
+	if n, ok := v.Export().(int64); ok {
+		return float64(n) / pdfLengthUnits["px"], nil
+	}
+	if n, ok := v.Export().(float64); ok {
+		return n / pdfLengthUnits["px"], nil
+	}
+
+	s := strings.TrimSpace(v.String())
+	unit := "px"
+	if len(s) > 2 {
+		if _, ok := pdfLengthUnits[strings.ToLower(s[len(s)-2:])]; ok {
+			unit = strings.ToLower(s[len(s)-2:])
+			s = s[:len(s)-2]
+		}
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid length %q", v.String())
+	}
+
+	return n / pdfLengthUnits[unit], nil
+}
+
 func NewPageReloadOptions(defaultWaitUntil LifecycleEvent, defaultTimeout time.Duration) *PageReloadOptions {
 	return &PageReloadOptions{
 		WaitUntil: defaultWaitUntil,
@@ -90,14 +287,16 @@ func (o *PageReloadOptions) Parse(ctx context.Context, opts sobek.Value) error {
 	return nil
 }
 
-func NewPageScreenshotOptions() *PageScreenshotOptions {
+func NewPageScreenshotOptions(defaultTimeout time.Duration) *PageScreenshotOptions {
 	return &PageScreenshotOptions{
-		Clip:           nil,
-		Path:           "",
-		Format:         ImageFormatPNG,
-		FullPage:       false,
-		OmitBackground: false,
-		Quality:        100,
+		ScreenshotBaseOptions: *NewScreenshotBaseOptions(),
+		Clip:                  nil,
+		Path:                  "",
+		Format:                ImageFormatPNG,
+		FullPage:              false,
+		OmitBackground:        false,
+		Quality:               100,
+		Timeout:               defaultTimeout,
 	}
 }
 
@@ -106,6 +305,9 @@ func (o *PageScreenshotOptions) Parse(ctx context.Context, opts sobek.Value) err
 	if !sobekValueExists(opts) {
 		return nil
 	}
+	if err := o.ScreenshotBaseOptions.Parse(ctx, opts); err != nil {
+		return err
+	}
 
 	rt := k6ext.Runtime(ctx)
 	formatSpecified := false
@@ -114,7 +316,7 @@ func (o *PageScreenshotOptions) Parse(ctx context.Context, opts sobek.Value) err
 		switch k {
 		case "clip":
 			var c map[string]float64
-			if rt.ExportTo(obj.Get(k), &c) != nil {
+			if rt.ExportTo(obj.Get(k), &c) == nil {
 				o.Clip = &page.Viewport{
 					X:      c["x"],
 					Y:      c["y"],
@@ -131,6 +333,8 @@ func (o *PageScreenshotOptions) Parse(ctx context.Context, opts sobek.Value) err
 			o.Path = obj.Get(k).String()
 		case "quality":
 			o.Quality = obj.Get(k).ToInteger()
+		case "timeout":
+			o.Timeout = time.Duration(obj.Get(k).ToInteger()) * time.Millisecond
 		case "type":
 			if f, ok := imageFormatToID[obj.Get(k).String()]; ok {
 				o.Format = f
@@ -140,9 +344,41 @@ func (o *PageScreenshotOptions) Parse(ctx context.Context, opts sobek.Value) err
 	}
 
 	// Infer file format by path if format not explicitly specified (default is PNG)
-	if o.Path != "" && !formatSpecified {
-		if strings.HasSuffix(o.Path, ".jpg") || strings.HasSuffix(o.Path, ".jpeg") {
-			o.Format = ImageFormatJPEG
+	if f, ok := imageFormatFromPath(o.Path); ok && !formatSpecified {
+		o.Format = f
+	}
+
+	return nil
+}
+
+// NewPageScrollUntilOptions returns a new PageScrollUntilOptions.
+func NewPageScrollUntilOptions() *PageScrollUntilOptions {
+	return &PageScrollUntilOptions{
+		MaxScrolls: 20,
+		Step:       0,
+		Delay:      100 * time.Millisecond,
+	}
+}
+
+// Parse parses the page scroll until options.
+func (o *PageScrollUntilOptions) Parse(ctx context.Context, opts sobek.Value) error {
+	if !sobekValueExists(opts) {
+		return nil
+	}
+
+	rt := k6ext.Runtime(ctx)
+	obj := opts.ToObject(rt)
+	for _, k := range obj.Keys() {
+		switch k {
+		case "maxScrolls":
+			o.MaxScrolls = obj.Get(k).ToInteger()
+			if o.MaxScrolls < 0 {
+				return fmt.Errorf("maxScrolls must be a non-negative number, got %d", o.MaxScrolls)
+			}
+		case "step":
+			o.Step = obj.Get(k).ToFloat()
+		case "delay":
+			o.Delay = time.Duration(obj.Get(k).ToInteger()) * time.Millisecond
 		}
 	}
 
@@ -0,0 +1,186 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grafana/xk6-browser/k6ext/k6test"
+
+	"github.com/chromedp/cdproto/page"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPagePDFOptionsParse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+
+		vu := k6test.NewVU(t)
+		opts := NewPagePDFOptions()
+		require.NoError(t, opts.Parse(vu.Context(), nil))
+
+		assert.Equal(t, 8.5, opts.Width)
+		assert.Equal(t, 11.0, opts.Height)
+		assert.Equal(t, 1.0, opts.Scale)
+		assert.Equal(t, PagePDFMargin{}, opts.Margin)
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+
+		vu := k6test.NewVU(t)
+		opts := NewPagePDFOptions()
+		err := opts.Parse(vu.Context(), vu.ToSobekValue(map[string]any{
+			"path":            "invoice.pdf",
+			"format":          "A4",
+			"landscape":       true,
+			"printBackground": true,
+			"scale":           0.5,
+			"pageRanges":      "1-2",
+			"headerTemplate":  "<span class=title></span>",
+			"footerTemplate":  "<span class=pageNumber></span>",
+			"margin": map[string]any{
+				"top":    "1in",
+				"right":  "2.54cm",
+				"bottom": "25.4mm",
+				"left":   96,
+			},
+		}))
+		require.NoError(t, err)
+
+		assert.Equal(t, "invoice.pdf", opts.Path)
+		assert.Equal(t, 8.27, opts.Width)
+		assert.Equal(t, 11.7, opts.Height)
+		assert.True(t, opts.Landscape)
+		assert.True(t, opts.PrintBackground)
+		assert.Equal(t, 0.5, opts.Scale)
+		assert.Equal(t, "1-2", opts.PageRanges)
+		assert.Equal(t, "<span class=title></span>", opts.HeaderTemplate)
+		assert.Equal(t, "<span class=pageNumber></span>", opts.FooterTemplate)
+		assert.Equal(t, PagePDFMargin{Top: 1, Right: 1, Bottom: 1, Left: 1}, opts.Margin)
+	})
+
+	t.Run("width_and_height", func(t *testing.T) {
+		t.Parallel()
+
+		vu := k6test.NewVU(t)
+		opts := NewPagePDFOptions()
+		err := opts.Parse(vu.Context(), vu.ToSobekValue(map[string]any{
+			"format": "A4",
+			"width":  "480px",
+			"height": "10in",
+		}))
+		require.NoError(t, err)
+
+		assert.Equal(t, 5.0, opts.Width)
+		assert.Equal(t, 10.0, opts.Height)
+	})
+
+	t.Run("err", func(t *testing.T) {
+		t.Parallel()
+
+		tests := map[string]map[string]any{
+			"unknown paper format": {"format": "A9"},
+			"invalid length":       {"margin": map[string]any{"top": "1ft"}},
+			"is not between":       {"scale": 3},
+		}
+		for want, o := range tests {
+			vu := k6test.NewVU(t)
+			opts := NewPagePDFOptions()
+			err := opts.Parse(vu.Context(), vu.ToSobekValue(o))
+			assert.ErrorContains(t, err, want)
+		}
+	})
+}
+
+func TestPageScreenshotOptionsParse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+
+		vu := k6test.NewVU(t)
+		opts := NewPageScreenshotOptions(time.Second)
+		require.NoError(t, opts.Parse(vu.Context(), nil))
+
+		assert.Equal(t, ImageFormatPNG, opts.Format)
+		assert.Equal(t, ScreenshotAnimationsAllow, opts.Animations)
+		assert.Equal(t, ScreenshotCaretHide, opts.Caret)
+		assert.Equal(t, ScreenshotScaleDevice, opts.Scale)
+		assert.Equal(t, time.Second, opts.Timeout)
+		assert.Nil(t, opts.Clip)
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+
+		vu := k6test.NewVU(t)
+		opts := NewPageScreenshotOptions(time.Second)
+		err := opts.Parse(vu.Context(), vu.ToSobekValue(map[string]any{
+			"path":       "screenshot.webp",
+			"animations": "disabled",
+			"caret":      "initial",
+			"scale":      "css",
+			"style":      "body { color: red; }",
+			"maskColor":  "#000",
+			"timeout":    500,
+		}))
+		require.NoError(t, err)
+
+		assert.Equal(t, ImageFormatWebP, opts.Format)
+		assert.Equal(t, ScreenshotAnimationsDisabled, opts.Animations)
+		assert.Equal(t, ScreenshotCaretInitial, opts.Caret)
+		assert.Equal(t, ScreenshotScaleCSS, opts.Scale)
+		assert.Equal(t, "body { color: red; }", opts.Style)
+		assert.Equal(t, "#000", opts.MaskColor)
+		assert.Equal(t, 500*time.Millisecond, opts.Timeout)
+	})
+
+	t.Run("clip", func(t *testing.T) {
+		t.Parallel()
+
+		vu := k6test.NewVU(t)
+		opts := NewPageScreenshotOptions(time.Second)
+		err := opts.Parse(vu.Context(), vu.ToSobekValue(map[string]any{
+			"clip": map[string]any{"x": 1, "y": 2, "width": 3, "height": 4},
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, &page.Viewport{X: 1, Y: 2, Width: 3, Height: 4, Scale: 1}, opts.Clip)
+
+		// a clip that isn't a rectangle is ignored.
+		opts = NewPageScreenshotOptions(time.Second)
+		err = opts.Parse(vu.Context(), vu.ToSobekValue(map[string]any{"clip": "top"}))
+		require.NoError(t, err)
+		assert.Nil(t, opts.Clip)
+	})
+
+	t.Run("type_over_path", func(t *testing.T) {
+		t.Parallel()
+
+		vu := k6test.NewVU(t)
+		opts := NewPageScreenshotOptions(time.Second)
+		err := opts.Parse(vu.Context(), vu.ToSobekValue(map[string]any{
+			"path": "screenshot.webp",
+			"type": "jpeg",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, ImageFormatJPEG, opts.Format)
+	})
+
+	for name, opts := range map[string]map[string]any{
+		"animations": {"animations": "paused"},
+		"caret":      {"caret": "blink"},
+		"scale":      {"scale": "2x"},
+	} {
+		name, opts := name, opts
+		t.Run("invalid_"+name, func(t *testing.T) {
+			t.Parallel()
+
+			vu := k6test.NewVU(t)
+			err := NewPageScreenshotOptions(time.Second).Parse(vu.Context(), vu.ToSobekValue(opts))
+			assert.ErrorContains(t, err, name)
+		})
+	}
+}
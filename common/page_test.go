@@ -2,9 +2,13 @@ package common
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/xk6-browser/log"
 )
 
 // TestPageLocator can be removed later on when we add integration
@@ -31,3 +35,90 @@ func TestPageLocator(t *testing.T) {
 
 	// other behavior will be tested via integration tests
 }
+
+func TestPageEventHandlers(t *testing.T) {
+	t.Parallel()
+
+	newPage := func() *Page {
+		return &Page{
+			eventHandlers: make(map[PageOnEventName][]*pageOnEventHandler),
+			logger:        log.NewNullLogger(),
+		}
+	}
+	// record returns a handler that appends its name to calls.
+	record := func(calls *[]string, name string) PageOnHandler {
+		return func(PageOnEvent) error {
+			*calls = append(*calls, name)
+			return nil
+		}
+	}
+
+	t.Run("on_and_off", func(t *testing.T) {
+		t.Parallel()
+
+		var calls []string
+		p := newPage()
+		require.NoError(t, p.AddEventHandler(EventPageLoaded, "a", record(&calls, "a1"), false))
+		require.NoError(t, p.AddEventHandler(EventPageLoaded, "b", record(&calls, "b"), false))
+		require.NoError(t, p.AddEventHandler(EventPageLoaded, "a", record(&calls, "a2"), false))
+		require.NoError(t, p.On(EventPageLoaded, record(&calls, "unkeyed")))
+		assert.Equal(t, 4, p.EventHandlerCount(EventPageLoaded))
+
+		// The most recently added handler of the key is removed.
+		assert.True(t, p.RemoveEventHandler(EventPageLoaded, "a"))
+		assert.False(t, p.RemoveEventHandler(EventPageLoaded, "c"))
+		assert.False(t, p.RemoveEventHandler(EventPageLoaded, nil))
+		assert.Equal(t, 3, p.EventHandlerCount(EventPageLoaded))
+
+		require.NoError(t, p.callPageOnHandlers(EventPageLoaded, PageOnEvent{}))
+		assert.Equal(t, []string{"a1", "b", "unkeyed"}, calls)
+	})
+
+	t.Run("once", func(t *testing.T) {
+		t.Parallel()
+
+		var calls []string
+		p := newPage()
+		require.NoError(t, p.AddEventHandler(EventPageLoaded, "a", record(&calls, "once"), true))
+		require.NoError(t, p.On(EventPageLoaded, record(&calls, "on")))
+
+		require.NoError(t, p.callPageOnHandlers(EventPageLoaded, PageOnEvent{}))
+		require.NoError(t, p.callPageOnHandlers(EventPageLoaded, PageOnEvent{}))
+		assert.Equal(t, []string{"once", "on", "on"}, calls)
+		assert.Equal(t, 1, p.EventHandlerCount(EventPageLoaded))
+	})
+
+	t.Run("remove_all", func(t *testing.T) {
+		t.Parallel()
+
+		var calls []string
+		p := newPage()
+		require.NoError(t, p.On(EventPageLoaded, record(&calls, "load")))
+		require.NoError(t, p.On(EventPageClosed, record(&calls, "close")))
+		require.NoError(t, p.On(EventPageClosed, record(&calls, "close")))
+		assert.Equal(t, 3, p.EventHandlerCount(""))
+
+		p.RemoveAllEventHandlers(EventPageClosed)
+		assert.Equal(t, 1, p.EventHandlerCount(""))
+		assert.False(t, hasPageOnHandler(p, EventPageClosed))
+
+		p.RemoveAllEventHandlers("")
+		assert.Equal(t, 0, p.EventHandlerCount(""))
+		assert.False(t, hasPageOnHandler(p, EventPageLoaded))
+	})
+
+	t.Run("remove_from_handler", func(t *testing.T) {
+		t.Parallel()
+
+		p := newPage()
+		errHandler := errors.New("handler error")
+		require.NoError(t, p.AddEventHandler(EventPageLoaded, "a", func(PageOnEvent) error {
+			// Handlers can remove handlers while they are called.
+			p.RemoveEventHandler(EventPageLoaded, "a")
+			return errHandler
+		}, false))
+
+		assert.ErrorIs(t, p.callPageOnHandlers(EventPageLoaded, PageOnEvent{}), errHandler)
+		assert.False(t, hasPageOnHandler(p, EventPageLoaded))
+	})
+}
@@ -0,0 +1,89 @@
+package common
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/chromedp/cdproto/cdp"
+	cdppage "github.com/chromedp/cdproto/page"
+	k6metrics "go.k6.io/k6/metrics"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/grafana/xk6-browser/k6ext"
+)
+
+// ErrPDFHeadful is returned when a PDF is generated in a headful browser.
+var ErrPDFHeadful = errors.New("generating a PDF is only supported in headless mode")
+
+// PDF generates a PDF of the page for printing, and persists it at
+// opts.Path with the file persister if the path is set. It returns
+// the bytes of the PDF.
+func (p *Page) PDF(opts *PagePDFOptions, fp ScreenshotPersister) ([]byte, error) {
+	p.logger.Debugf("Page:PDF", "sid:%v", p.sessionID())
+
+	spanCtx, span := tracePageAPICall(p.ctx, p, "page.pdf")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("pdf.path", opts.Path))
+
+	buf, err := p.printToPDF(opts)
+	if err == nil && opts.Path != "" {
+		if perr := fp.Persist(spanCtx, opts.Path, bytes.NewBuffer(buf)); perr != nil {
+			err = fmt.Errorf("persisting pdf to %q: %w", opts.Path, perr)
+		}
+	}
+	if err != nil {
+		err = fmt.Errorf("generating pdf of page: %w", err)
+		spanRecordError(span, err)
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+// printToPDF prints the page to a PDF, and measures how long it takes.
+func (p *Page) printToPDF(opts *PagePDFOptions) ([]byte, error) {
+	if !p.browserCtx.browser.browserOpts.Headless {
+		return nil, ErrPDFHeadful
+	}
+
+	action := cdppage.PrintToPDF().
+		WithPaperWidth(opts.Width).
+		WithPaperHeight(opts.Height).
+		WithLandscape(opts.Landscape).
+		WithMarginTop(opts.Margin.Top).
+		WithMarginRight(opts.Margin.Right).
+		WithMarginBottom(opts.Margin.Bottom).
+		WithMarginLeft(opts.Margin.Left).
+		WithPrintBackground(opts.PrintBackground).
+		WithScale(opts.Scale).
+		WithPageRanges(opts.PageRanges).
+		WithDisplayHeaderFooter(opts.DisplayHeaderFooter).
+		WithHeaderTemplate(opts.HeaderTemplate).
+		WithFooterTemplate(opts.FooterTemplate).
+		WithPreferCSSPageSize(opts.PreferCSSPageSize).
+		WithTransferMode(cdppage.PrintToPDFTransferModeReturnAsBase64)
+
+	start := time.Now()
+	buf, _, err := action.Do(cdp.WithExecutor(p.ctx, p.session))
+	if err != nil {
+		return nil, fmt.Errorf("printing to pdf: %w", err)
+	}
+	p.emitPDFMetric(time.Since(start))
+
+	return buf, nil
+}
+
+// emitPDFMetric emits the time it took to generate a PDF of the page.
+func (p *Page) emitPDFMetric(d time.Duration) {
+	pushCustomMetrics(p.ctx, p, p.MainFrame().URL(), func(cm *k6ext.CustomMetrics) []k6metrics.Sample {
+		return []k6metrics.Sample{
+			{
+				TimeSeries: k6metrics.TimeSeries{Metric: cm.BrowserPDFDuration},
+				Value:      k6metrics.D(d),
+			},
+		}
+	})
+}
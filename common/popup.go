@@ -0,0 +1,73 @@
+package common
+
+import (
+	"time"
+)
+
+// onNewPage reports a new page to the browserContext.waitForEvent('page')
+// waiters and, if the page is a popup, to the page.waitForEvent('popup')
+// waiters of its opener. The browserContext.on('page') and page.on('popup')
+// handlers are called once the initial navigation of the page commits, so
+// that they can use the page right away, as it's no longer on its initial
+// empty document.
+func (b *Browser) onNewPage(p *Page, url string) {
+	p.browserCtx.emit(EventBrowserContextPage, p)
+	if p.opener != nil {
+		p.opener.emit(EventPagePopup, p)
+	}
+
+	if !p.browserCtx.hasOnHandler(EventBrowserContextPageCreated) &&
+		(p.opener == nil || !hasPageOnHandler(p.opener, EventPagePopupOpened)) {
+		return
+	}
+	go func() {
+		p.waitForInitialNavigation(url)
+
+		p.browserCtx.onPage(p)
+		if p.opener != nil {
+			p.opener.onPopup(p)
+		}
+	}()
+}
+
+// waitForInitialNavigation waits for the main frame of a new page to
+// commit the navigation to the URL that the page was opened with. It
+// doesn't wait for pages that are opened without a URL.
+func (p *Page) waitForInitialNavigation(url string) {
+	if url == "" || url == "about:blank" {
+		return
+	}
+	frame := p.MainFrame()
+	if frame == nil {
+		return
+	}
+
+	ch, evCancelFn := createWaitForEventHandler(p.ctx, frame, []string{EventFrameNavigation}, nil)
+	defer evCancelFn() // Remove event handler
+
+	// The navigation might have been committed while the page
+	// was being created.
+	if u := frame.URL(); u != "" && u != "about:blank" {
+		return
+	}
+
+	timeout := p.frameManager.timeoutSettings.navigationTimeout()
+	select {
+	case <-ch:
+	case <-p.ctx.Done():
+	case <-time.After(timeout):
+		p.logger.Debugf("Page:waitForInitialNavigation",
+			"sid:%v tid:%v url:%q timed out after %v", p.sessionID(), p.targetID, url, timeout)
+	}
+}
+
+// onPopup emits the popup that is opened by the page to the
+// page.waitForEvent('popup') waiters, and calls the page.on('popup')
+// handlers with it.
+func (p *Page) onPopup(popup *Page) {
+	p.emit(EventPagePopup, popup)
+
+	_ = p.callPageOnHandlers(EventPagePopupOpened, PageOnEvent{
+		Popup: popup,
+	})
+}
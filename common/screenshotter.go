@@ -8,11 +8,16 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/chromedp/cdproto/cdp"
 	"github.com/chromedp/cdproto/emulation"
 	cdppage "github.com/chromedp/cdproto/page"
+	"github.com/grafana/sobek"
+
+	"github.com/grafana/xk6-browser/k6ext"
 	"github.com/grafana/xk6-browser/log"
 )
 
@@ -22,6 +27,22 @@ type ScreenshotPersister interface {
 	Persist(ctx context.Context, path string, data io.Reader) (err error)
 }
 
+// PathExpander is implemented by the file persisters that persist
+// files at other paths than the requested ones, such as the paths
+// expanded from a path template.
+type PathExpander interface {
+	ExpandPath(path string) string
+}
+
+// persistedPath returns the path that the persister persists
+// a file requested to be persisted at path at.
+func persistedPath(fp ScreenshotPersister, path string) string {
+	if pe, ok := fp.(PathExpander); ok {
+		return pe.ExpandPath(path)
+	}
+	return path
+}
+
 // ImageFormat represents an image file format.
 type ImageFormat string
 
@@ -29,6 +50,7 @@ type ImageFormat string
 const (
 	ImageFormatJPEG ImageFormat = "jpeg"
 	ImageFormatPNG  ImageFormat = "png"
+	ImageFormatWebP ImageFormat = "webp"
 )
 
 func (f ImageFormat) String() string {
@@ -38,11 +60,28 @@ func (f ImageFormat) String() string {
 var imageFormatToString = map[ImageFormat]string{ //nolint:gochecknoglobals
 	ImageFormatJPEG: "jpeg",
 	ImageFormatPNG:  "png",
+	ImageFormatWebP: "webp",
 }
 
 var imageFormatToID = map[string]ImageFormat{ //nolint:gochecknoglobals
 	"jpeg": ImageFormatJPEG,
 	"png":  ImageFormatPNG,
+	"webp": ImageFormatWebP,
+}
+
+// imageFormatFromPath infers the image format from the extension
+// of the path. It returns false if the extension is unknown.
+func imageFormatFromPath(path string) (ImageFormat, bool) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jpg", ".jpeg":
+		return ImageFormatJPEG, true
+	case ".png":
+		return ImageFormatPNG, true
+	case ".webp":
+		return ImageFormatWebP, true
+	default:
+		return "", false
+	}
 }
 
 // MarshalJSON marshals the enum as a quoted JSON string.
@@ -65,6 +104,83 @@ func (f *ImageFormat) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// Valid values of the screenshot options.
+const (
+	ScreenshotAnimationsAllow    = "allow"
+	ScreenshotAnimationsDisabled = "disabled"
+	ScreenshotCaretHide          = "hide"
+	ScreenshotCaretInitial       = "initial"
+	ScreenshotScaleCSS           = "css"
+	ScreenshotScaleDevice        = "device"
+)
+
+// ScreenshotBaseOptions are the options of page and element screenshots
+// that make screenshots deterministic.
+type ScreenshotBaseOptions struct {
+	// Animations finishes the finite animations and transitions, and
+	// cancels the infinite ones when it's "disabled".
+	Animations string `json:"animations"`
+	// Caret hides the text caret when it's "hide".
+	Caret string `json:"caret"`
+	// Mask are the locators of the elements that are covered with
+	// MaskColor boxes in the screenshot.
+	Mask      []*Locator `json:"-"`
+	MaskColor string     `json:"maskColor"`
+	// Scale is the scale of the screenshot. It's one pixel per CSS
+	// pixel when it's "css", and one pixel per device pixel otherwise.
+	Scale string `json:"scale"`
+	// Style is a stylesheet that is applied to the frames of the page
+	// while the screenshot is taken.
+	Style string `json:"style"`
+}
+
+// NewScreenshotBaseOptions returns the default screenshot options.
+func NewScreenshotBaseOptions() *ScreenshotBaseOptions {
+	return &ScreenshotBaseOptions{
+		Animations: ScreenshotAnimationsAllow,
+		Caret:      ScreenshotCaretHide,
+		MaskColor:  "#FF00FF",
+		Scale:      ScreenshotScaleDevice,
+	}
+}
+
+// Parse parses the screenshot options. The mask option is set
+// by the caller, since it holds locators.
+func (o *ScreenshotBaseOptions) Parse(ctx context.Context, opts sobek.Value) error {
+	if !sobekValueExists(opts) {
+		return nil
+	}
+
+	rt := k6ext.Runtime(ctx)
+	obj := opts.ToObject(rt)
+	for _, k := range obj.Keys() {
+		v := obj.Get(k).String()
+		switch k {
+		case "animations":
+			if v != ScreenshotAnimationsAllow && v != ScreenshotAnimationsDisabled {
+				return fmt.Errorf(`animations must be "allow" or "disabled", got %q`, v)
+			}
+			o.Animations = v
+		case "caret":
+			if v != ScreenshotCaretHide && v != ScreenshotCaretInitial {
+				return fmt.Errorf(`caret must be "hide" or "initial", got %q`, v)
+			}
+			o.Caret = v
+		case "maskColor":
+			o.MaskColor = v
+		case "scale":
+			if v != ScreenshotScaleCSS && v != ScreenshotScaleDevice {
+				return fmt.Errorf(`scale must be "css" or "device", got %q`, v)
+			}
+			o.Scale = v
+		case "style":
+			o.Style = v
+		}
+	}
+
+	return nil
+}
+
 type screenshotter struct {
 	ctx       context.Context
 	persister ScreenshotPersister
@@ -150,12 +266,15 @@ func (s *screenshotter) restoreViewport(p *Page, originalViewport *Size) error {
 	return p.resetViewport()
 }
 
+//nolint:funlen,cyclop
 func (s *screenshotter) screenshot(
-	sess session, doc, viewport *Rect, format ImageFormat, omitBackground bool, quality int64, path string,
+	p *Page, doc, viewport *Rect, format ImageFormat, omitBackground bool, quality int64, path string,
+	opts *ScreenshotBaseOptions,
 ) ([]byte, error) {
 	var (
 		buf  []byte
 		clip *cdppage.Viewport
+		sess = p.session
 	)
 	capture := cdppage.CaptureScreenshot()
 
@@ -173,6 +292,8 @@ func (s *screenshotter) screenshot(
 	switch format {
 	case ImageFormatJPEG:
 		capture.WithFormat(cdppage.CaptureScreenshotFormatJpeg)
+	case ImageFormatWebP:
+		capture.WithFormat(cdppage.CaptureScreenshotFormatWebp)
 	default:
 		capture.WithFormat(cdppage.CaptureScreenshotFormatPng)
 	}
@@ -199,6 +320,13 @@ func (s *screenshotter) screenshot(
 	if viewport != nil {
 		scale = visualViewportScale
 	}
+	if opts.Scale == ScreenshotScaleCSS {
+		dpr, err := s.devicePixelRatio(p)
+		if err != nil {
+			return nil, err
+		}
+		scale /= dpr
+	}
 	clip = &cdppage.Viewport{
 		X:      doc.X,
 		Y:      doc.Y,
@@ -210,6 +338,12 @@ func (s *screenshotter) screenshot(
 		capture = capture.WithClip(clip)
 	}
 
+	// The masks are added right before the capture, since resizing
+	// the viewport can move the masked elements.
+	if err := s.mask(opts); err != nil {
+		return nil, err
+	}
+
 	// Capture screenshot
 	buf, err = capture.Do(cdp.WithExecutor(s.ctx, sess))
 	if err != nil {
@@ -272,6 +406,12 @@ func getViewPortDimensions(ctx context.Context, sess session, logger *log.Logger
 //nolint:funlen
 func (s *screenshotter) screenshotElement(h *ElementHandle, opts *ElementHandleScreenshotOptions) ([]byte, error) {
 	format := opts.Format
+	restore, err := s.prepare(h.frame.page, &opts.ScreenshotBaseOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer restore()
+
 	viewportSize, originalViewportSize, err := s.originalViewportSize(h.frame.page)
 	if err != nil {
 		return nil, fmt.Errorf("getting original viewport size: %w", err)
@@ -335,13 +475,14 @@ func (s *screenshotter) screenshotElement(h *ElementHandle, opts *ElementHandleS
 	documentRect.Y += returnVal.Y
 
 	buf, err := s.screenshot(
-		h.frame.page.session,
+		h.frame.page,
 		documentRect.enclosingIntRect(),
 		nil, // viewportRect
 		format,
 		opts.OmitBackground,
 		opts.Quality,
 		opts.Path,
+		&opts.ScreenshotBaseOptions,
 	)
 	if err != nil {
 		return nil, err
@@ -359,18 +500,29 @@ func (s *screenshotter) screenshotPage(p *Page, opts *PageScreenshotOptions) ([]
 	format := opts.Format
 
 	// Infer file format by path
-	if opts.Path != "" && opts.Format != "png" && opts.Format != "jpeg" {
-		if strings.HasSuffix(opts.Path, ".jpg") || strings.HasSuffix(opts.Path, ".jpeg") {
-			format = "jpeg"
+	if _, ok := imageFormatToString[format]; !ok {
+		format = ImageFormatPNG
+		if f, ok := imageFormatFromPath(opts.Path); ok {
+			format = f
 		}
 	}
 
+	restore, err := s.prepare(p, &opts.ScreenshotBaseOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer restore()
+
 	viewportSize, originalViewportSize, err := s.originalViewportSize(p)
 	if err != nil {
 		return nil, fmt.Errorf("getting original viewport size: %w", err)
 	}
 
 	if opts.FullPage { //nolint:nestif
+		// Scrolling through the page triggers the lazily loaded content.
+		if err := s.scrollThrough(p); err != nil {
+			return nil, fmt.Errorf("scrolling through page: %w", err)
+		}
 		fullPageSize, err := s.fullPageSize(p)
 		if err != nil {
 			return nil, fmt.Errorf("getting full page size: %w", err)
@@ -402,7 +554,9 @@ func (s *screenshotter) screenshotPage(p *Page, opts *PageScreenshotOptions) ([]
 			}
 		}
 
-		buf, err := s.screenshot(p.session, documentRect, nil, format, opts.OmitBackground, opts.Quality, opts.Path)
+		buf, err := s.screenshot(
+			p, documentRect, nil, format, opts.OmitBackground, opts.Quality, opts.Path, &opts.ScreenshotBaseOptions,
+		)
 		if err != nil {
 			return nil, err
 		}
@@ -432,7 +586,9 @@ func (s *screenshotter) screenshotPage(p *Page, opts *PageScreenshotOptions) ([]
 			return nil, fmt.Errorf("trimming clip to size: %w", err)
 		}
 	}
-	return s.screenshot(p.session, nil, viewportRect, format, opts.OmitBackground, opts.Quality, opts.Path)
+	return s.screenshot(
+		p, nil, viewportRect, format, opts.OmitBackground, opts.Quality, opts.Path, &opts.ScreenshotBaseOptions,
+	)
 }
 
 func (s *screenshotter) trimClipToSize(clip *Rect, size *Size) (*Rect, error) {
@@ -455,3 +611,154 @@ func (s *screenshotter) trimClipToSize(clip *Rect, size *Size) (*Rect, error) {
 	}
 	return &result, nil
 }
+
+// screenshotAttr marks the elements that are added
+// to the page while a screenshot is taken.
+const screenshotAttr = "data-k6-browser-screenshot"
+
+// prepare applies the style, caret and animations options to the frames
+// of the page. The returned function removes the added styles and masks.
+func (s *screenshotter) prepare(p *Page, opts *ScreenshotBaseOptions) (func(), error) {
+	args := map[string]any{
+		"attr":              screenshotAttr,
+		"disableAnimations": opts.Animations == ScreenshotAnimationsDisabled,
+		"hideCaret":         opts.Caret == ScreenshotCaretHide,
+		"style":             opts.Style,
+	}
+	eopts := evalOptions{
+		forceCallable: true,
+		returnByValue: true,
+	}
+	restore := func() {
+		// The page is restored even if the screenshot timed out.
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(s.ctx), time.Second)
+		defer cancel()
+		for _, f := range p.Frames() {
+			if _, err := f.evaluate(ctx, mainWorld, eopts, screenshotRestoreScript, screenshotAttr); err != nil {
+				s.logger.Debugf("Screenshotter:restore", "fid:%s err:%v", f.ID(), err)
+			}
+		}
+	}
+
+	main := p.frameManager.MainFrame()
+	for _, f := range p.Frames() {
+		_, err := f.evaluate(s.ctx, mainWorld, eopts, screenshotPrepareScript, args)
+		if err == nil {
+			continue
+		}
+		// Detached and unloaded child frames are skipped.
+		if f == main {
+			restore()
+			return nil, fmt.Errorf("preparing page for screenshot: %w", err)
+		}
+		s.logger.Debugf("Screenshotter:prepare", "fid:%s err:%v", f.ID(), err)
+	}
+
+	return restore, nil
+}
+
+// mask covers the elements of the mask locators with boxes of the mask color.
+func (s *screenshotter) mask(opts *ScreenshotBaseOptions) error {
+	for _, l := range opts.Mask {
+		if _, err := l.evaluateAll(screenshotMaskScript, screenshotAttr, opts.MaskColor); err != nil {
+			return fmt.Errorf("masking %q: %w", l.selector, err)
+		}
+	}
+
+	return nil
+}
+
+// scrollThrough scrolls the page to its bottom a viewport at a
+// time, and scrolls it back to where it was.
+func (s *screenshotter) scrollThrough(p *Page) error {
+	opts := evalOptions{
+		forceCallable: true,
+		returnByValue: true,
+	}
+	_, err := p.frameManager.MainFrame().evaluate(s.ctx, mainWorld, opts, screenshotScrollScript)
+
+	return err
+}
+
+func (s *screenshotter) devicePixelRatio(p *Page) (float64, error) {
+	opts := evalOptions{
+		forceCallable: true,
+		returnByValue: true,
+	}
+	result, err := p.frameManager.MainFrame().evaluate(s.ctx, mainWorld, opts, `() => window.devicePixelRatio`)
+	if err != nil {
+		return 0, fmt.Errorf("getting device pixel ratio: %w", err)
+	}
+	var dpr float64
+	if err := convert(result, &dpr); err != nil || dpr <= 0 {
+		return 1, nil //nolint:nilerr
+	}
+
+	return dpr, nil
+}
+
+const screenshotPrepareScript = `
+(opts) => {
+	const css = [];
+	if (opts.hideCaret) {
+		css.push('* { caret-color: transparent !important; }');
+	}
+	if (opts.style) {
+		css.push(opts.style);
+	}
+	if (css.length > 0) {
+		const style = document.createElement('style');
+		style.setAttribute(opts.attr, '');
+		style.textContent = css.join('\n');
+		(document.head || document.documentElement).appendChild(style);
+	}
+	if (opts.disableAnimations && document.getAnimations) {
+		for (const animation of document.getAnimations()) {
+			const timing = animation.effect ? animation.effect.getComputedTiming() : {};
+			if (timing.endTime === Infinity) {
+				animation.cancel();
+			} else {
+				animation.finish();
+			}
+		}
+	}
+}`
+
+const screenshotRestoreScript = `
+(attr) => {
+	for (const el of document.querySelectorAll('[' + attr + ']')) {
+		el.remove();
+	}
+}`
+
+const screenshotMaskScript = `
+(elements, attr, color) => {
+	for (const el of elements) {
+		const rect = el.getBoundingClientRect();
+		const mask = document.createElement('div');
+		mask.setAttribute(attr, '');
+		Object.assign(mask.style, {
+			position: 'absolute',
+			left: (rect.left + window.scrollX) + 'px',
+			top: (rect.top + window.scrollY) + 'px',
+			width: rect.width + 'px',
+			height: rect.height + 'px',
+			background: color,
+			zIndex: '2147483647',
+			pointerEvents: 'none',
+		});
+		document.documentElement.appendChild(mask);
+	}
+}`
+
+const screenshotScrollScript = `
+async () => {
+	const { scrollX, scrollY } = window;
+	const step = window.innerHeight || 600;
+	const maxScrolls = 100;
+	for (let y = 0, i = 0; y < document.documentElement.scrollHeight && i < maxScrolls; y += step, i++) {
+		window.scrollTo(scrollX, y);
+		await new Promise((resolve) => setTimeout(resolve, 50));
+	}
+	window.scrollTo(scrollX, scrollY);
+}`
@@ -18,8 +18,11 @@ package common
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 )
 
 // Matches `name:body`, a query engine name and selector for that engine.
@@ -28,6 +31,158 @@ var reQueryEngine *regexp.Regexp = regexp.MustCompile(`^[a-zA-Z_0-9-+:*]+$`)
 // Matches start of XPath query.
 var reXPathSelector *regexp.Regexp = regexp.MustCompile(`^\(*//`)
 
+// Matches a valid custom selector engine name.
+var reSelectorEngineName *regexp.Regexp = regexp.MustCompile(`^[a-zA-Z_0-9-]+$`)
+
+// builtinSelectorEngines are the selector engines that the injected script
+// supports out of the box.
+var builtinSelectorEngines = map[string]bool{ //nolint:gochecknoglobals
+	"css":   true,
+	"text":  true,
+	"xpath": true,
+}
+
+// SelectorEngine is a custom selector engine.
+type SelectorEngine struct {
+	// Name is the prefix of the selectors that the engine handles,
+	// as in `name=body`.
+	Name string
+	// Source is a JS expression that evaluates to an object with
+	// `query(root, selector)` and/or `queryAll(root, selector)` methods.
+	Source string
+	// ContentScript is accepted for compatibility with Playwright.
+	// Selectors are always queried in the main world, so the engine
+	// has access to the page's globals either way.
+	ContentScript bool
+}
+
+// SelectorEngineOptions are the options for registering a custom
+// selector engine.
+type SelectorEngineOptions struct {
+	ContentScript bool `js:"contentScript"`
+}
+
+// Selectors is a registry of custom selector engines. Each VU has its own
+// registry, so that registering an engine doesn't affect the other VUs.
+type Selectors struct {
+	mu      sync.RWMutex
+	engines []*SelectorEngine
+}
+
+// registeredSelectorEngines are the selector engines that are
+// registered with RegisterSelectorEngine for all the VUs.
+var registeredSelectorEngines = &Selectors{} //nolint:gochecknoglobals
+
+// RegisterSelectorEngine registers a custom selector engine for all the VUs,
+// so that Go code, such as another extension, can provide an engine that the
+// scripts don't have to register with selectors.register. The engine is
+// available to the registries that NewSelectors returns afterwards, so it
+// should be registered before the VUs are created, e.g. in an init function.
+// A script can't register an engine with the same name and a different source.
+func RegisterSelectorEngine(name, source string, opts *SelectorEngineOptions) error {
+	return registeredSelectorEngines.Register(name, source, opts)
+}
+
+// NewSelectors returns a new selector engine registry with the engines
+// that are registered with RegisterSelectorEngine.
+func NewSelectors() *Selectors {
+	s := &Selectors{}
+	for _, e := range registeredSelectorEngines.Engines() {
+		e := e
+		s.engines = append(s.engines, &e)
+	}
+
+	return s
+}
+
+// Register registers a custom selector engine with the given name.
+// The engine is only available in the execution contexts that are
+// created after the registration. Registering the same engine twice
+// is a no-op, while registering a different engine with an already
+// registered name is an error.
+func (s *Selectors) Register(name, source string, opts *SelectorEngineOptions) error {
+	if !reSelectorEngineName.MatchString(name) {
+		return fmt.Errorf(
+			"selector engine name %q may only contain [a-zA-Z0-9_-] characters", name,
+		)
+	}
+	if builtinSelectorEngines[name] || pseudoSelectorEngines[name] {
+		return fmt.Errorf("%q is a predefined selector engine", name)
+	}
+	if strings.TrimSpace(source) == "" {
+		return fmt.Errorf("selector engine %q source is empty", name)
+	}
+	if opts == nil {
+		opts = &SelectorEngineOptions{}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, e := range s.engines {
+		if e.Name != name {
+			continue
+		}
+		if e.Source == source && e.ContentScript == opts.ContentScript {
+			return nil
+		}
+		return fmt.Errorf("selector engine %q has been already registered", name)
+	}
+	s.engines = append(s.engines, &SelectorEngine{
+		Name:          name,
+		Source:        source,
+		ContentScript: opts.ContentScript,
+	})
+
+	return nil
+}
+
+// Engines returns the registered selector engines in registration order.
+func (s *Selectors) Engines() []SelectorEngine {
+	if s == nil {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	engines := make([]SelectorEngine, 0, len(s.engines))
+	for _, e := range s.engines {
+		engines = append(engines, *e)
+	}
+
+	return engines
+}
+
+// has returns true if a selector engine with the given name is registered.
+func (s *Selectors) has(name string) bool {
+	if s == nil {
+		return false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, e := range s.engines {
+		if e.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// injectedScriptEngines returns the JS source of an array of the registered
+// engines that the injected script's constructor accepts.
+func (s *Selectors) injectedScriptEngines() string {
+	var b strings.Builder
+	b.WriteString("[")
+	for _, e := range s.Engines() {
+		fmt.Fprintf(&b, "\n{ name: %q, engine: (%s) },", e.Name, e.Source)
+	}
+	b.WriteString("]")
+
+	return b.String()
+}
+
 type SelectorPart struct {
 	Name string `json:"name"`
 	Body string `json:"body"`
@@ -41,19 +196,53 @@ type Selector struct {
 	// but a selector can be prefixed with `*` to capture elements resolved by
 	// an intermediate selector.
 	Capture *int `json:"capture"`
+
+	// custom are the custom selector engines that the selector can use.
+	custom *Selectors
 }
 
+// NewSelector parses a selector that can use the builtin selector engines
+// and the selector engines that are registered with RegisterSelectorEngine.
 func NewSelector(selector string) (*Selector, error) {
+	return newSelector(selector, registeredSelectorEngines)
+}
+
+// newSelector parses a selector that can use the builtin selector
+// engines and the custom selector engines in the registry.
+func newSelector(selector string, custom *Selectors) (*Selector, error) {
 	s := Selector{
 		Selector: selector,
 		Parts:    make([]*SelectorPart, 0, 1),
 		Capture:  nil,
+		custom:   custom,
 	}
 	err := s.parse()
 	return &s, err
 }
 
+// pseudoSelectorEngines are the selector engines of the nth and visibility
+// pseudo-classes, as in `li >> nth=1` and `button >> visible=true`.
+var pseudoSelectorEngines = map[string]bool{ //nolint:gochecknoglobals
+	"nth":     true,
+	"visible": true,
+}
+
 func (s *Selector) appendPart(p *SelectorPart, capture bool) error {
+	if !builtinSelectorEngines[p.Name] && !pseudoSelectorEngines[p.Name] && !s.custom.has(p.Name) {
+		return fmt.Errorf("unknown selector engine name %q while parsing selector %q", p.Name, s.Selector)
+	}
+	switch p.Name {
+	case "nth":
+		p.Body = strings.TrimSpace(p.Body)
+		if _, err := strconv.Atoi(p.Body); err != nil {
+			return fmt.Errorf("nth selector body %q must be an integer in selector %q", p.Body, s.Selector)
+		}
+	case "visible":
+		p.Body = strings.TrimSpace(p.Body)
+		if p.Body != "true" && p.Body != "false" {
+			return fmt.Errorf("visible selector body %q must be true or false in selector %q", p.Body, s.Selector)
+		}
+	}
 	s.Parts = append(s.Parts, p)
 	if capture {
 		if s.Capture != nil {
@@ -0,0 +1,184 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectorsRegister(t *testing.T) {
+	t.Parallel()
+
+	const source = `({ queryAll: (root, s) => root.querySelectorAll(s) })`
+
+	tests := []struct {
+		name    string
+		engine  string
+		source  string
+		wantErr string
+	}{
+		{name: "ok", engine: "tag", source: source},
+		{name: "same_engine_twice", engine: "dup", source: source},
+		{name: "invalid_name", engine: "my engine", source: source, wantErr: "may only contain"},
+		{name: "builtin", engine: "css", source: source, wantErr: "predefined selector engine"},
+		{name: "pseudo", engine: "nth", source: source, wantErr: "predefined selector engine"},
+		{name: "empty_source", engine: "empty", source: " ", wantErr: "source is empty"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewSelectors()
+			require.NoError(t, s.Register("dup", source, nil))
+
+			err := s.Register(tt.engine, tt.source, nil)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, s.has(tt.engine))
+		})
+	}
+
+	t.Run("different_engine_same_name", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewSelectors()
+		require.NoError(t, s.Register("tag", source, nil))
+		err := s.Register("tag", `({ query: () => null })`, nil)
+		assert.ErrorContains(t, err, "already registered")
+		assert.Len(t, s.Engines(), 1)
+	})
+
+	t.Run("injected_script_engines", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewSelectors()
+		assert.Equal(t, "[]", s.injectedScriptEngines())
+
+		require.NoError(t, s.Register("tag", source, &SelectorEngineOptions{ContentScript: true}))
+		assert.Equal(t, "[\n{ name: \"tag\", engine: ("+source+") },]", s.injectedScriptEngines())
+		assert.True(t, s.Engines()[0].ContentScript)
+	})
+}
+
+func TestNewSelector(t *testing.T) {
+	t.Parallel()
+
+	custom := NewSelectors()
+	require.NoError(t, custom.Register(
+		"test-engine", `({ query: (root, s) => root.querySelector(s) })`, nil,
+	))
+
+	tests := []struct {
+		name      string
+		selector  string
+		wantParts []*SelectorPart
+		wantErr   string
+	}{
+		{
+			name:      "css",
+			selector:  "div.a",
+			wantParts: []*SelectorPart{{Name: "css", Body: "div.a"}},
+		},
+		{
+			name:     "chained",
+			selector: `text="a" >> xpath=//b`,
+			wantParts: []*SelectorPart{
+				{Name: "text", Body: `"a"`},
+				{Name: "xpath", Body: "//b"},
+			},
+		},
+		{
+			name:     "custom",
+			selector: "css=div >> test-engine=span",
+			wantParts: []*SelectorPart{
+				{Name: "css", Body: "div"},
+				{Name: "test-engine", Body: "span"},
+			},
+		},
+		{
+			name:     "nth_and_visible",
+			selector: "li:has-text('a') >> nth= -1 >> visible=true",
+			wantParts: []*SelectorPart{
+				{Name: "css", Body: "li:has-text('a')"},
+				{Name: "nth", Body: "-1"},
+				{Name: "visible", Body: "true"},
+			},
+		},
+		{
+			name:     "layout",
+			selector: `button:right-of(:text-is("Name"), 10)`,
+			wantParts: []*SelectorPart{
+				{Name: "css", Body: `button:right-of(:text-is("Name"), 10)`},
+			},
+		},
+		{
+			name:     "invalid_nth",
+			selector: "li >> nth=first",
+			wantErr:  `nth selector body "first" must be an integer`,
+		},
+		{
+			name:     "invalid_visible",
+			selector: "li >> visible=yes",
+			wantErr:  `visible selector body "yes" must be true or false`,
+		},
+		{
+			name:     "unknown",
+			selector: "unknown-engine=span",
+			wantErr:  `unknown selector engine name "unknown-engine"`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, err := newSelector(tt.selector, custom)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantParts, s.Parts)
+		})
+	}
+
+	t.Run("other_registry", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newSelector("test-engine=span", NewSelectors())
+		assert.ErrorContains(t, err, `unknown selector engine name "test-engine"`)
+	})
+}
+
+//nolint:paralleltest // it changes the registered selector engines of all the VUs.
+func TestRegisterSelectorEngine(t *testing.T) {
+	registered := registeredSelectorEngines
+	registeredSelectorEngines = &Selectors{}
+	t.Cleanup(func() { registeredSelectorEngines = registered })
+
+	const source = `({ query: (root, s) => root.querySelector(s) })`
+	require.NoError(t, RegisterSelectorEngine("test-registered", source, nil))
+	require.ErrorContains(t, RegisterSelectorEngine("css", source, nil), "predefined")
+
+	s, err := NewSelector("test-registered=span")
+	require.NoError(t, err)
+	assert.Equal(t, []*SelectorPart{{Name: "test-registered", Body: "span"}}, s.Parts)
+
+	// The VU registries have the registered engine, and
+	// registering it again is a no-op.
+	vu := NewSelectors()
+	assert.True(t, vu.has("test-registered"))
+	require.NoError(t, vu.Register("test-registered", source, nil))
+	require.ErrorContains(t, vu.Register("test-registered", `({})`, nil), "already registered")
+
+	// Registering an engine in a VU registry doesn't affect the others.
+	require.NoError(t, vu.Register("test-vu", source, nil))
+	assert.False(t, NewSelectors().has("test-vu"))
+	_, err = NewSelector("test-vu=span")
+	assert.ErrorContains(t, err, `unknown selector engine name "test-vu"`)
+}
@@ -34,6 +34,15 @@ func TraceAPICall(
 	return ctx, browsertrace.NoopSpan{}
 }
 
+// tracePageAPICall calls TraceAPICall for the API call on the page,
+// and records the call into the trace of the page if the page is traced.
+func tracePageAPICall(
+	ctx context.Context, p *Page, spanName string, opts ...trace.SpanStartOption,
+) (context.Context, trace.Span) {
+	ctx, span := TraceAPICall(ctx, p.targetID.String(), spanName, opts...)
+	return ctx, recordAPICall(p, spanName, span)
+}
+
 // TraceNavigation is a helper method that retrieves the Tracer from the given ctx and
 // calls its TraceNavigation implementation. If the Tracer is not present in the given
 // ctx, it returns a noopSpan and the given context.
@@ -0,0 +1,569 @@
+package common
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha1" //nolint:gosec
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	goruntime "runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/runtime"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/grafana/xk6-browser/common/js"
+)
+
+const (
+	// traceVersion is the version of the trace format of the
+	// Playwright trace viewer that traces are recorded in.
+	traceVersion = 7
+	// traceSnapshotTimeout is how long to wait for a DOM snapshot.
+	// Snapshots are skipped when the page is busy, e.g. navigating.
+	traceSnapshotTimeout = time.Second
+)
+
+var (
+	// ErrTracingStarted is returned when tracing is started twice.
+	ErrTracingStarted = errors.New("tracing has been already started")
+	// ErrTracingNotStarted is returned when tracing is stopped before it starts.
+	ErrTracingNotStarted = errors.New("tracing must be started")
+)
+
+// traceMonotonicStart is the origin of the monotonic times of traces.
+var traceMonotonicStart = time.Now() //nolint:gochecknoglobals
+
+// Tracing records a trace of a browser context, which can be
+// opened in the Playwright trace viewer. A trace is a zip archive of
+// the API calls on the pages of the browser context, together with
+// DOM snapshots, screencast frames, console messages and network
+// requests of the pages.
+type Tracing struct {
+	bctx *BrowserContext
+
+	mu      sync.Mutex
+	opts    *TracingStartOptions
+	started bool
+	// pages are the traced pages, which are screencasted if the
+	// screenshots option is set.
+	pages     []*Page
+	callID    int
+	events    [][]byte
+	network   [][]byte
+	resources map[string][]byte
+}
+
+// NewTracing returns a new tracing of the browser context.
+func NewTracing(bctx *BrowserContext) *Tracing {
+	return &Tracing{bctx: bctx}
+}
+
+// Start starts recording a trace of the browser context. The sources are
+// the paths of the script files that are recorded when the sources
+// option is set.
+func (t *Tracing) Start(opts *TracingStartOptions, sources []string) error {
+	t.mu.Lock()
+	if t.started {
+		t.mu.Unlock()
+		return ErrTracingStarted
+	}
+	t.started = true
+	t.opts = opts
+	t.callID = 0
+	t.pages = nil
+	t.events, t.network = nil, nil
+	t.resources = make(map[string][]byte)
+
+	t.addEvent(t.contextOptions())
+	if opts.Sources {
+		t.addSources(sources)
+	}
+	t.mu.Unlock()
+
+	for _, p := range t.bctx.Pages() {
+		if p.browserCtx != t.bctx {
+			continue
+		}
+		if err := t.onPage(p); err != nil {
+			return fmt.Errorf("starting tracing: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Stop stops recording the trace, and persists the trace archive at the
+// path of the options with the file persister if the path is set.
+func (t *Tracing) Stop(opts *TracingStopOptions, fp ScreenshotPersister) error {
+	t.mu.Lock()
+	if !t.started {
+		t.mu.Unlock()
+		return ErrTracingNotStarted
+	}
+	t.started = false
+	pages, screenshots := t.pages, t.opts.Screenshots
+	t.pages = nil
+
+	var (
+		archive *bytes.Buffer
+		err     error
+	)
+	if opts.Path != "" {
+		archive, err = t.archive()
+	}
+	t.mu.Unlock()
+
+	// The screencasts are stopped without the lock, since the
+	// screencast frames are recorded with the lock.
+	if screenshots {
+		for _, p := range pages {
+			p.stopScreencast()
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("stopping tracing: %w", err)
+	}
+	if archive == nil {
+		return nil
+	}
+	if err := fp.Persist(t.bctx.ctx, opts.Path, archive); err != nil {
+		return fmt.Errorf("persisting trace to %q: %w", opts.Path, err)
+	}
+
+	return nil
+}
+
+// onPage traces the page of the browser context if tracing is started,
+// and starts its screencast if the screenshots option is set.
+func (t *Tracing) onPage(p *Page) error {
+	t.mu.Lock()
+	if !t.started {
+		t.mu.Unlock()
+		return nil
+	}
+	if slices.Contains(t.pages, p) {
+		t.mu.Unlock()
+		return nil
+	}
+	t.pages = append(t.pages, p)
+	screenshots := t.opts.Screenshots
+	t.mu.Unlock()
+
+	if !screenshots {
+		return nil
+	}
+
+	return p.startScreencast()
+}
+
+// addEvent adds an event to the trace. The caller must hold the lock.
+func (t *Tracing) addEvent(ev any) {
+	b, err := json.Marshal(ev)
+	if err != nil {
+		t.bctx.logger.Debugf("Tracing:addEvent", "bctxid:%v err:%v", t.bctx.id, err)
+		return
+	}
+	t.events = append(t.events, b)
+}
+
+// add adds an event to the trace if tracing is started.
+func (t *Tracing) add(ev any) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.started {
+		t.addEvent(ev)
+	}
+}
+
+// addResource adds a resource to the trace, and returns its
+// SHA-1 hash. The caller must hold the lock.
+func (t *Tracing) addResource(data []byte, ext string) string {
+	sum := sha1.Sum(data) //nolint:gosec
+	name := hex.EncodeToString(sum[:]) + ext
+	t.resources[name] = data
+
+	return name
+}
+
+// addSources adds the script files to the trace. The viewer looks up the
+// sources with the SHA-1 hashes of their paths. The caller must hold the lock.
+func (t *Tracing) addSources(paths []string) {
+	readFile := GetFileReader(t.bctx.ctx)
+	for _, path := range paths {
+		data, err := readFile(path)
+		if err != nil {
+			t.bctx.logger.Debugf("Tracing:addSources", "path:%q err:%v", path, err)
+			continue
+		}
+		sum := sha1.Sum([]byte(path)) //nolint:gosec
+		t.resources["src@"+hex.EncodeToString(sum[:])+".txt"] = data
+	}
+}
+
+// contextOptions returns the first event of the trace,
+// which describes the browser context.
+func (t *Tracing) contextOptions() map[string]any {
+	platform := goruntime.GOOS
+	if platform == "windows" {
+		platform = "win32"
+	}
+	title := t.opts.Title
+	if title == "" {
+		title = t.opts.Name
+	}
+	opts := t.bctx.opts
+
+	return map[string]any{
+		"version":       traceVersion,
+		"type":          "context-options",
+		"origin":        "library",
+		"browserName":   "chromium",
+		"platform":      platform,
+		"wallTime":      traceWallTime(time.Now()),
+		"monotonicTime": traceMonotonicTime(),
+		"sdkLanguage":   "javascript",
+		"title":         title,
+		"contextId":     "browser-context@" + string(t.bctx.id),
+		"options": map[string]any{
+			"deviceScaleFactor": opts.DeviceScaleFactor,
+			"isMobile":          opts.IsMobile,
+			"userAgent":         opts.UserAgent,
+			"viewport": map[string]any{
+				"width":  opts.Viewport.Width,
+				"height": opts.Viewport.Height,
+			},
+		},
+	}
+}
+
+// archive returns the zip archive of the trace.
+func (t *Tracing) archive() (*bytes.Buffer, error) {
+	var (
+		buf bytes.Buffer
+		zw  = zip.NewWriter(&buf)
+	)
+	files := map[string][]byte{
+		"trace.trace":   bytes.Join(append(t.events, nil), []byte("\n")),
+		"trace.network": bytes.Join(append(t.network, nil), []byte("\n")),
+	}
+	for name, data := range t.resources {
+		files["resources/"+name] = data
+	}
+	for name, data := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			return nil, fmt.Errorf("creating trace archive: %w", err)
+		}
+		if _, err := w.Write(data); err != nil {
+			return nil, fmt.Errorf("writing %s to trace archive: %w", name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("closing trace archive: %w", err)
+	}
+
+	return &buf, nil
+}
+
+// traces returns true if the page is traced.
+func (t *Tracing) traces(p *Page) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.started && slices.Contains(t.pages, p)
+}
+
+// recordAPICall records the API call of the span into the trace of the
+// page. It returns a span that records the end of the call, or the given
+// span if the page isn't traced.
+func recordAPICall(p *Page, spanName string, span trace.Span) trace.Span {
+	t := p.tracing()
+	if t == nil {
+		return span
+	}
+
+	t.mu.Lock()
+	t.callID++
+	callID := fmt.Sprintf("call@%d", t.callID)
+	snapshots := t.opts.Snapshots
+	t.mu.Unlock()
+
+	class, method, _ := strings.Cut(spanName, ".")
+	ev := map[string]any{
+		"type":      "before",
+		"callId":    callID,
+		"startTime": traceMonotonicTime(),
+		"apiName":   spanName,
+		"class":     strings.ToUpper(class[:1]) + class[1:],
+		"method":    method,
+		"params":    map[string]any{},
+		"pageId":    tracePageID(p),
+	}
+	if snapshots {
+		ev["beforeSnapshot"] = "before@" + callID
+		t.snapshot(p, callID, "before@"+callID)
+	}
+	t.add(ev)
+
+	return &tracingSpan{
+		Span:      span,
+		t:         t,
+		p:         p,
+		callID:    callID,
+		snapshots: snapshots,
+	}
+}
+
+// tracingSpan records the end of an API call into a trace.
+type tracingSpan struct {
+	trace.Span
+
+	t         *Tracing
+	p         *Page
+	callID    string
+	snapshots bool
+
+	mu  sync.Mutex
+	err error
+}
+
+// RecordError records the error of the API call.
+func (s *tracingSpan) RecordError(err error, opts ...trace.EventOption) {
+	s.Span.RecordError(err, opts...)
+
+	s.mu.Lock()
+	s.err = err
+	s.mu.Unlock()
+}
+
+// End records the end of the API call.
+func (s *tracingSpan) End(opts ...trace.SpanEndOption) {
+	s.Span.End(opts...)
+
+	ev := map[string]any{
+		"type":    "after",
+		"callId":  s.callID,
+		"endTime": traceMonotonicTime(),
+	}
+	if s.snapshots {
+		ev["afterSnapshot"] = "after@" + s.callID
+		s.t.snapshot(s.p, s.callID, "after@"+s.callID)
+	}
+	s.mu.Lock()
+	if s.err != nil {
+		ev["error"] = map[string]any{
+			"name":    "Error",
+			"message": s.err.Error(),
+		}
+	}
+	s.mu.Unlock()
+	s.t.add(ev)
+}
+
+// snapshot records a DOM snapshot of the main frame of the page.
+// Snapshots that can't be taken, e.g. of closed pages, are skipped.
+func (t *Tracing) snapshot(p *Page, callID, name string) {
+	f := p.frameManager.MainFrame()
+	if f == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(p.ctx, traceSnapshotTimeout)
+	defer cancel()
+
+	start := time.Now()
+	opts := evalOptions{
+		forceCallable: true,
+		returnByValue: true,
+	}
+	result, err := f.evaluate(ctx, utilityWorld, opts, js.SnapshotScript)
+	if err != nil {
+		p.logger.Debugf("Tracing:snapshot", "tid:%v name:%s err:%v", p.targetID, name, err)
+		return
+	}
+	snapshot, ok := result.(map[string]any)
+	if !ok {
+		return
+	}
+
+	t.add(map[string]any{
+		"type": "frame-snapshot",
+		"snapshot": map[string]any{
+			"callId":            callID,
+			"snapshotName":      name,
+			"pageId":            tracePageID(p),
+			"frameId":           f.ID(),
+			"frameUrl":          snapshot["url"],
+			"doctype":           snapshot["doctype"],
+			"html":              snapshot["html"],
+			"viewport":          snapshot["viewport"],
+			"timestamp":         traceMonotonicTime(),
+			"wallTime":          traceWallTime(start),
+			"collectionTime":    float64(time.Since(start).Microseconds()) / 1000,
+			"resourceOverrides": []any{},
+			"isMainFrame":       true,
+		},
+	})
+}
+
+// onScreencastFrame records the screencast frame of the page.
+func (t *Tracing) onScreencastFrame(p *Page, data []byte, width, height int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.started || !t.opts.Screenshots {
+		return
+	}
+	t.addEvent(map[string]any{
+		"type":              "screencast-frame",
+		"pageId":            tracePageID(p),
+		"sha1":              t.addResource(data, ".jpeg"),
+		"width":             width,
+		"height":            height,
+		"timestamp":         traceMonotonicTime(),
+		"frameSwapWallTime": traceWallTime(time.Now()),
+	})
+}
+
+// onConsoleAPICalled records the console message of the page.
+func (t *Tracing) onConsoleAPICalled(p *Page, event *runtime.EventConsoleAPICalled) {
+	objects := make([]string, 0, len(event.Args))
+	for _, robj := range event.Args {
+		s, err := parseConsoleRemoteObject(p.logger, robj)
+		if err != nil {
+			p.logger.Debugf("Tracing:onConsoleAPICalled", "parsing console message: %v", err)
+		}
+		objects = append(objects, s)
+	}
+	location := map[string]any{
+		"url":          "",
+		"lineNumber":   0,
+		"columnNumber": 0,
+	}
+	if st := event.StackTrace; st != nil && len(st.CallFrames) > 0 {
+		cf := st.CallFrames[0]
+		location["url"] = cf.URL
+		location["lineNumber"] = cf.LineNumber
+		location["columnNumber"] = cf.ColumnNumber
+	}
+
+	t.add(map[string]any{
+		"type":        "console",
+		"time":        traceMonotonicTime(),
+		"pageId":      tracePageID(p),
+		"messageType": event.Type.String(),
+		"text":        textForConsoleEvent(event, objects),
+		"args":        []any{},
+		"location":    location,
+	})
+}
+
+// onRequestDone records the finished or failed request of the page
+// as a HAR entry.
+func (t *Tracing) onRequestDone(p *Page, req *Request) {
+	var (
+		wallTime = req.wallTime
+		resp     = req.Response()
+		response = map[string]any{
+			"status":      -1,
+			"statusText":  "",
+			"httpVersion": "",
+			"cookies":     []any{},
+			"headers":     []HTTPHeader{},
+			"content":     map[string]any{"size": -1, "mimeType": ""},
+			"redirectURL": "",
+			"headersSize": -1,
+			"bodySize":    -1,
+		}
+	)
+	if resp != nil {
+		mimeType, _ := resp.HeaderValue("content-type")
+		location, _ := resp.HeaderValue("location")
+		response["status"] = resp.Status()
+		response["statusText"] = resp.StatusText()
+		response["httpVersion"] = resp.protocol
+		response["headers"] = resp.HeadersArray()
+		response["content"] = map[string]any{"size": -1, "mimeType": mimeType}
+		response["redirectURL"] = location
+	}
+	entry := map[string]any{
+		"pageref":         tracePageID(p),
+		"startedDateTime": wallTime.Format(time.RFC3339Nano),
+		"time":            float64(time.Since(wallTime).Microseconds()) / 1000,
+		"request": map[string]any{
+			"method":      req.Method(),
+			"url":         req.URL(),
+			"httpVersion": "",
+			"cookies":     []any{},
+			"headers":     req.HeadersArray(),
+			"queryString": []any{},
+			"headersSize": -1,
+			"bodySize":    -1,
+		},
+		"response":       response,
+		"cache":          map[string]any{},
+		"timings":        map[string]any{"send": -1, "wait": -1, "receive": -1},
+		"_monotonicTime": traceMonotonicTime(),
+	}
+	if f := req.getFrame(); f != nil {
+		entry["_frameref"] = f.ID()
+	}
+	if req.errorText != "" {
+		entry["_failureText"] = req.errorText
+	}
+
+	b, err := json.Marshal(map[string]any{
+		"type":     "resource-snapshot",
+		"snapshot": entry,
+	})
+	if err != nil {
+		p.logger.Debugf("Tracing:onRequestDone", "url:%s err:%v", req.URL(), err)
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.started {
+		t.network = append(t.network, b)
+	}
+}
+
+// Tracing returns the tracing of the browser context.
+func (b *BrowserContext) Tracing() *Tracing {
+	return b.tracing
+}
+
+// tracing returns the tracing of the page if the page is traced, or nil.
+func (p *Page) tracing() *Tracing {
+	if p.browserCtx == nil {
+		return nil
+	}
+	t := p.browserCtx.tracing
+	if t == nil || !t.traces(p) {
+		return nil
+	}
+
+	return t
+}
+
+func tracePageID(p *Page) string {
+	return "page@" + p.targetID.String()
+}
+
+// traceMonotonicTime returns the monotonic time in milliseconds.
+func traceMonotonicTime() float64 {
+	return float64(time.Since(traceMonotonicStart).Microseconds()) / 1000
+}
+
+// traceWallTime returns the time in milliseconds since the Unix epoch.
+func traceWallTime(t time.Time) float64 {
+	return float64(t.UnixMicro()) / 1000
+}
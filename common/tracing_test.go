@@ -0,0 +1,106 @@
+package common
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/xk6-browser/log"
+	"github.com/grafana/xk6-browser/storage"
+)
+
+func TestTracing(t *testing.T) {
+	t.Parallel()
+
+	// the test script is read through the file reader of the VU.
+	const script = "/scripts/script.js"
+	readFile := func(path string) ([]byte, error) {
+		if path != script {
+			return nil, os.ErrNotExist
+		}
+		return []byte("export default function() {}"), nil
+	}
+	newTracing := func() *Tracing {
+		bctx := &BrowserContext{
+			ctx:     WithFileReader(context.Background(), readFile),
+			browser: &Browser{},
+			id:      "bctx1",
+			opts:    DefaultBrowserContextOptions(),
+			logger:  log.NewNullLogger(),
+		}
+		bctx.tracing = NewTracing(bctx)
+		return bctx.tracing
+	}
+
+	t.Run("start_stop", func(t *testing.T) {
+		t.Parallel()
+
+		tr := newTracing()
+		fp := &storage.LocalFilePersister{}
+		assert.ErrorIs(t, tr.Stop(&TracingStopOptions{}, fp), ErrTracingNotStarted)
+		require.NoError(t, tr.Start(&TracingStartOptions{}, nil))
+		assert.ErrorIs(t, tr.Start(&TracingStartOptions{}, nil), ErrTracingStarted)
+		require.NoError(t, tr.Stop(&TracingStopOptions{}, fp))
+		require.NoError(t, tr.Start(&TracingStartOptions{}, nil))
+	})
+
+	t.Run("archive", func(t *testing.T) {
+		t.Parallel()
+
+		tr := newTracing()
+		require.NoError(t, tr.Start(&TracingStartOptions{
+			Title:       "trace",
+			Screenshots: true,
+			Sources:     true,
+		}, []string{script, "/scripts/missing.js"}))
+		tr.add(map[string]any{"type": "before", "callId": "call@1"})
+		tr.onScreencastFrame(&Page{targetID: "page1"}, []byte("frame"), 80, 60)
+
+		path := filepath.Join(t.TempDir(), "trace.zip")
+		require.NoError(t, tr.Stop(&TracingStopOptions{Path: path}, &storage.LocalFilePersister{}))
+
+		zr, err := zip.OpenReader(path)
+		require.NoError(t, err)
+		defer zr.Close() //nolint:errcheck
+
+		var (
+			events    []map[string]any
+			resources []string
+		)
+		for _, f := range zr.File {
+			switch f.Name {
+			case "trace.trace":
+				r, err := f.Open()
+				require.NoError(t, err)
+				s := bufio.NewScanner(r)
+				for s.Scan() {
+					var ev map[string]any
+					require.NoError(t, json.Unmarshal(s.Bytes(), &ev))
+					events = append(events, ev)
+				}
+				require.NoError(t, r.Close())
+			case "trace.network":
+			default:
+				resources = append(resources, f.Name)
+			}
+		}
+
+		require.Len(t, events, 3)
+		assert.Equal(t, "context-options", events[0]["type"])
+		assert.EqualValues(t, traceVersion, events[0]["version"])
+		assert.Equal(t, "trace", events[0]["title"])
+		assert.Equal(t, "browser-context@bctx1", events[0]["contextId"])
+		assert.Equal(t, "before", events[1]["type"])
+		assert.Equal(t, "screencast-frame", events[2]["type"])
+		assert.Equal(t, "page@page1", events[2]["pageId"])
+		assert.Contains(t, resources, "resources/"+events[2]["sha1"].(string)) //nolint:forcetypeassert
+		assert.Len(t, resources, 2, "want the frame and the source")
+	})
+}
@@ -0,0 +1,338 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image/jpeg"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/cdp"
+	cdppage "github.com/chromedp/cdproto/page"
+)
+
+const (
+	// videoFrameRate is the frame rate of the recorded videos.
+	videoFrameRate = 25
+	// videoMaxSize is the maximum width and height of the recorded videos.
+	// Larger viewports are scaled down to fit.
+	videoMaxSize = 800
+	// videoQuality is the JPEG quality of the frames of the videos.
+	videoQuality = 90
+	// videoPersistTimeout is how long to wait for a video to be persisted
+	// after the page closes.
+	videoPersistTimeout = 30 * time.Second
+)
+
+// ErrVideoEmpty is returned when a page closes before any frame of its
+// video was recorded.
+var ErrVideoEmpty = errors.New("video has no frames")
+
+// Video is the recording of a page. Pages are recorded when the
+// videosPath option of their browser context is set. The video is
+// recorded into a temporary file, and persisted at its path with the
+// file persister when the page closes.
+type Video struct {
+	ctx    context.Context
+	fp     ScreenshotPersister
+	path   string
+	tmpDir string
+
+	mu   sync.Mutex
+	file *os.File
+	aw   *aviWriter
+	// start is the time of the first frame.
+	start time.Time
+	// last is the last received frame, which is written until the
+	// time of the next frame.
+	last []byte
+
+	done       chan struct{}
+	finishOnce sync.Once
+	err        error
+}
+
+// NewVideo returns a new video, which is persisted at the given path.
+func NewVideo(ctx context.Context, path string) *Video {
+	return &Video{
+		ctx:    ctx,
+		fp:     GetFilePersister(ctx),
+		path:   path,
+		tmpDir: os.TempDir(), //nolint:forbidigo
+		done:   make(chan struct{}),
+	}
+}
+
+// Path returns the path at which the video is persisted when
+// the page closes.
+func (v *Video) Path() string {
+	return persistedPath(v.fp, v.path)
+}
+
+// SaveAs waits for the page to close and the video to be finished,
+// and persists the video at the given path with the file persister.
+func (v *Video) SaveAs(path string, fp ScreenshotPersister) error {
+	if err := v.wait(); err != nil {
+		return fmt.Errorf("saving video: %w", err)
+	}
+	f, err := os.Open(v.file.Name()) //nolint:forbidigo
+	if err != nil {
+		return fmt.Errorf("saving video: %w", err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	if err := fp.Persist(v.ctx, path, f); err != nil {
+		return fmt.Errorf("saving video to %q: %w", path, err)
+	}
+
+	return nil
+}
+
+// wait waits for the video to be finished and persisted.
+func (v *Video) wait() error {
+	select {
+	case <-v.done:
+	case <-v.ctx.Done():
+		return fmt.Errorf("waiting for video of page to finish: %w", v.ctx.Err())
+	}
+
+	return v.err
+}
+
+// onFrame writes the last frame until the time of the new frame,
+// and keeps the new frame as the last frame.
+func (v *Video) onFrame(data []byte, ts time.Time) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.aw == nil {
+		if err := v.create(data); err != nil {
+			return err
+		}
+		v.start = ts
+	}
+	if err := v.writeUntil(ts); err != nil {
+		return err
+	}
+	v.last = data
+
+	return nil
+}
+
+// create creates the video file with the size of the first frame.
+func (v *Video) create(frame []byte) error {
+	cfg, err := jpeg.DecodeConfig(bytes.NewReader(frame))
+	if err != nil {
+		return fmt.Errorf("decoding video frame: %w", err)
+	}
+	f, err := os.CreateTemp(v.tmpDir, artifactsDirectory+"video-*.avi") //nolint:forbidigo
+	if err != nil {
+		return fmt.Errorf("creating video file: %w", err)
+	}
+	aw, err := newAVIWriter(f, cfg.Width, cfg.Height, videoFrameRate)
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	v.file, v.aw = f, aw
+
+	return nil
+}
+
+// writeUntil writes the last frame until the given time. The first
+// frame of the video is always written. The last frame is written once,
+// and repeated without writing it again for the rest of the frames.
+func (v *Video) writeUntil(ts time.Time) error {
+	if v.last == nil {
+		return nil
+	}
+	frames := int(ts.Sub(v.start).Seconds() * videoFrameRate)
+	if v.aw.Frames() < frames || v.aw.Frames() == 0 {
+		if err := v.aw.WriteFrame(v.last); err != nil {
+			return err
+		}
+	}
+	for v.aw.Frames() < frames {
+		if err := v.aw.RepeatFrame(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// finish writes the remaining frames of the video, and persists it.
+// The temporary file of the video is removed when the iteration ends.
+func (v *Video) finish() {
+	v.finishOnce.Do(func() {
+		defer close(v.done)
+
+		if v.err = v.close(); v.err != nil {
+			return
+		}
+		// The file is opened before its removal is scheduled, as the
+		// iteration might already be ending.
+		f, err := os.Open(v.file.Name()) //nolint:forbidigo
+		if err != nil {
+			v.err = fmt.Errorf("persisting video: %w", err)
+			return
+		}
+		defer f.Close() //nolint:errcheck
+		go func() {
+			<-v.ctx.Done()
+			_ = os.Remove(v.file.Name()) //nolint:forbidigo
+		}()
+
+		// The page usually closes when the iteration ends, so the video
+		// is persisted even if the context of the iteration is done.
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(v.ctx), videoPersistTimeout)
+		defer cancel()
+		if err := v.fp.Persist(ctx, v.path, f); err != nil {
+			v.err = fmt.Errorf("persisting video to %q: %w", v.path, err)
+		}
+	})
+}
+
+// close writes the last frame until now, and closes the video file.
+func (v *Video) close() error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.aw == nil {
+		return ErrVideoEmpty
+	}
+	err := v.writeUntil(time.Now())
+	if err == nil {
+		err = v.aw.Close()
+	}
+	if cerr := v.file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("finishing video: %w", err)
+	}
+
+	return nil
+}
+
+// Video returns the video of the page, or nil if the page
+// isn't recorded.
+func (p *Page) Video() *Video {
+	return p.video
+}
+
+// startVideo starts recording the page into a video that is persisted in
+// the videos path of the browser context. The video is finished when the
+// page closes or the iteration ends.
+func (p *Page) startVideo() error {
+	v := NewVideo(p.ctx, filepath.Join(p.browserCtx.opts.VideosPath, p.targetID.String()+".avi"))
+	p.video = v
+
+	if err := p.startScreencast(); err != nil {
+		return fmt.Errorf("starting video of page: %w", err)
+	}
+
+	go func() {
+		select {
+		case <-p.ctx.Done():
+			v.finish()
+		case <-v.done:
+		}
+	}()
+
+	return nil
+}
+
+// startScreencast starts the screencast of the page, whose frames are
+// recorded into the video and the trace of the page. The screencast is
+// shared, and only started by the first caller.
+func (p *Page) startScreencast() error {
+	p.screencastMu.Lock()
+	defer p.screencastMu.Unlock()
+
+	if p.screencasts++; p.screencasts > 1 {
+		return nil
+	}
+
+	// The screencast keeps the aspect ratio of the viewport.
+	size := p.viewportSize()
+	width, height := int64(size.Width), int64(size.Height)
+	if width <= 0 || height <= 0 {
+		width, height = videoMaxSize, videoMaxSize
+	}
+	scale := min(1, float64(videoMaxSize)/float64(max(width, height)))
+	action := cdppage.StartScreencast().
+		WithFormat(cdppage.ScreencastFormatJpeg).
+		WithQuality(videoQuality).
+		WithMaxWidth(int64(float64(width) * scale)).
+		WithMaxHeight(int64(float64(height) * scale))
+	if err := action.Do(cdp.WithExecutor(p.ctx, p.session)); err != nil {
+		p.screencasts--
+		return fmt.Errorf("starting screencast: %w", err)
+	}
+
+	return nil
+}
+
+// stopScreencast stops the screencast of the page
+// when its last caller stops it.
+func (p *Page) stopScreencast() {
+	p.screencastMu.Lock()
+	defer p.screencastMu.Unlock()
+
+	if p.screencasts--; p.screencasts > 0 {
+		return
+	}
+	p.screencasts = 0
+	if err := cdppage.StopScreencast().Do(cdp.WithExecutor(p.ctx, p.session)); err != nil {
+		p.logger.Debugf("Page:stopScreencast", "sid:%v err:%v", p.sessionID(), err)
+	}
+}
+
+// onScreencastFrame adds the screencast frame to the video and the trace
+// of the page, and acknowledges it so that the browser sends the next frame.
+func (fs *FrameSession) onScreencastFrame(ev *cdppage.EventScreencastFrame) {
+	if err := fs.page.onScreencastFrame(ev); err != nil {
+		fs.logger.Debugf("FrameSession:onScreencastFrame", "sid:%v tid:%v err:%v",
+			fs.session.ID(), fs.targetID, err)
+	}
+
+	action := cdppage.ScreencastFrameAck(ev.SessionID)
+	if err := action.Do(cdp.WithExecutor(fs.ctx, fs.session)); err != nil {
+		fs.logger.Debugf("FrameSession:onScreencastFrame", "sid:%v tid:%v ack err:%v",
+			fs.session.ID(), fs.targetID, err)
+	}
+}
+
+func (p *Page) onScreencastFrame(ev *cdppage.EventScreencastFrame) error {
+	v, t := p.video, p.tracing()
+	if v == nil && t == nil {
+		return nil
+	}
+
+	data, err := base64.StdEncoding.DecodeString(ev.Data)
+	if err != nil {
+		return fmt.Errorf("decoding screencast frame: %w", err)
+	}
+	if v != nil {
+		// The frames are timed when they are received rather than with
+		// their timestamps, as the video is finished with the local time.
+		if err := v.onFrame(data, time.Now()); err != nil {
+			return err
+		}
+	}
+	if t != nil {
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("decoding screencast frame: %w", err)
+		}
+		t.onScreencastFrame(p, data, cfg.Width, cfg.Height)
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/jpeg"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/xk6-browser/storage"
+)
+
+func TestVideo(t *testing.T) {
+	t.Parallel()
+
+	var frame bytes.Buffer
+	require.NoError(t, jpeg.Encode(&frame, image.NewRGBA(image.Rect(0, 0, 80, 60)), nil))
+
+	t.Run("finish", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		dir := t.TempDir()
+		v := NewVideo(ctx, filepath.Join(dir, "videos", "page.avi"))
+		v.tmpDir = dir
+
+		// A frame is repeated until the time of the next frame,
+		// and the last frame until the video is finished.
+		start := time.Now().Add(-time.Second)
+		require.NoError(t, v.onFrame(frame.Bytes(), start))
+		require.NoError(t, v.onFrame(frame.Bytes(), start.Add(400*time.Millisecond)))
+		assert.Equal(t, 10, v.aw.Frames())
+
+		v.finish()
+		require.NoError(t, v.wait())
+
+		b, err := os.ReadFile(v.Path())
+		require.NoError(t, err)
+		frames := binary.LittleEndian.Uint32(b[48:])
+		assert.GreaterOrEqual(t, frames, uint32(25))
+		assert.Less(t, frames, uint32(50))
+		assert.Equal(t, uint32(80), binary.LittleEndian.Uint32(b[64:]), "width")
+		assert.Equal(t, uint32(60), binary.LittleEndian.Uint32(b[68:]), "height")
+		// The repeated frames are not written again.
+		chunk := frame.Len() + frame.Len()%2 + 8
+		assert.Less(t, len(b), aviHeaderSize+3*chunk+8+16*int(frames))
+
+		saved := filepath.Join(dir, "saved.avi")
+		require.NoError(t, v.SaveAs(saved, GetFilePersister(ctx)))
+		sb, err := os.ReadFile(saved)
+		require.NoError(t, err)
+		assert.Equal(t, b, sb)
+
+		// The temporary file is removed when the iteration ends.
+		cancel()
+		assert.Eventually(t, func() bool {
+			_, err := os.Stat(v.file.Name())
+			return os.IsNotExist(err)
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("finish_after_iteration", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		dir := t.TempDir()
+		v := NewVideo(ctx, filepath.Join(dir, "page.avi"))
+		v.tmpDir = dir
+		require.NoError(t, v.onFrame(frame.Bytes(), time.Now()))
+
+		// The video of a page that closes when the iteration
+		// ends is persisted before its temporary file is removed.
+		cancel()
+		v.finish()
+		require.NoError(t, v.err)
+		_, err := os.Stat(v.Path())
+		require.NoError(t, err)
+	})
+
+	t.Run("expanded_path", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		fp := &prefixPersister{dir: dir}
+		v := NewVideo(WithFilePersister(context.Background(), fp), "page.avi")
+		v.tmpDir = dir
+		require.NoError(t, v.onFrame(frame.Bytes(), time.Now()))
+
+		// The path is the one that the video is persisted at.
+		assert.Equal(t, filepath.Join(dir, "page.avi"), v.Path())
+		v.finish()
+		require.NoError(t, v.wait())
+		assert.FileExists(t, v.Path())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		v := NewVideo(context.Background(), filepath.Join(t.TempDir(), "page.avi"))
+		v.finish()
+		assert.ErrorIs(t, v.wait(), ErrVideoEmpty)
+		assert.ErrorIs(t, v.SaveAs("saved.avi", GetFilePersister(context.Background())), ErrVideoEmpty)
+	})
+}
+
+// prefixPersister persists files at their paths in dir.
+type prefixPersister struct {
+	dir string
+}
+
+func (p *prefixPersister) Persist(ctx context.Context, path string, data io.Reader) error {
+	return (&storage.LocalFilePersister{}).Persist(ctx, p.ExpandPath(path), data)
+}
+
+func (p *prefixPersister) ExpandPath(path string) string {
+	return filepath.Join(p.dir, path)
+}
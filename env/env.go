@@ -79,6 +79,26 @@ const (
 	// to upload screenshots to a remote location instead of saving
 	// to the local disk.
 	ScreenshotsOutput = "K6_BROWSER_SCREENSHOTS_OUTPUT"
+
+	// SnapshotsDir is the directory of the baseline screenshots
+	// of the toHaveScreenshot assertions.
+	SnapshotsDir = "K6_BROWSER_SNAPSHOTS_DIR"
+
+	// UpdateSnapshots makes the toHaveScreenshot assertions write
+	// the screenshots as the new baselines instead of comparing them.
+	UpdateSnapshots = "K6_BROWSER_UPDATE_SNAPSHOTS"
+)
+
+// Artifacts.
+const (
+	// ArtifactsPathTemplate is the template of the paths that the files,
+	// such as screenshots, are persisted at. For example:
+	// screens/{scenario}/{vu}-{iter}-{timestamp}-{name}.{ext}
+	ArtifactsPathTemplate = "K6_BROWSER_ARTIFACTS_PATH_TEMPLATE"
+
+	// ArtifactsManifest is the path of the JSON manifest that lists
+	// the files persisted during the test run.
+	ArtifactsManifest = "K6_BROWSER_ARTIFACTS_MANIFEST"
 )
 
 // Infrastructural.
@@ -0,0 +1,43 @@
+import { browser } from 'k6/x/browser/async';
+import { check } from 'https://jslib.k6.io/k6-utils/1.5.0/index.js';
+
+export const options = {
+  scenarios: {
+    ui: {
+      executor: 'shared-iterations',
+      options: {
+        browser: {
+            type: 'chromium',
+        },
+      },
+    },
+  },
+  thresholds: {
+    checks: ["rate==1.0"],
+    browser_download_duration: ["p(95)<5000"],
+  }
+}
+
+export default async function() {
+  const context = await browser.newContext({ acceptDownloads: true });
+  const page = await context.newPage();
+
+  try {
+    const csv = encodeURIComponent('id,name\n1,k6\n');
+    await page.setContent(`<a href="data:text/csv,${csv}" download="report.csv">export</a>`);
+
+    const [download] = await Promise.all([
+      page.waitForEvent('download'),
+      page.locator('a').click(),
+    ]);
+
+    check(download, {
+      'suggested filename': d => d.suggestedFilename() == 'report.csv',
+      'download succeeded': async d => await d.failure() == null,
+    });
+
+    await download.saveAs('downloads/report.csv');
+  } finally {
+    await page.close();
+  }
+}
@@ -0,0 +1,50 @@
+import { browser } from 'k6/x/browser/async';
+import { check } from 'https://jslib.k6.io/k6-utils/1.5.0/index.js';
+import crypto from 'k6/crypto';
+
+export const options = {
+  scenarios: {
+    ui: {
+      executor: 'shared-iterations',
+      options: {
+        browser: {
+            type: 'chromium',
+        },
+      },
+    },
+  },
+  thresholds: {
+    checks: ["rate==1.0"]
+  }
+}
+
+export default async function() {
+  const context = await browser.newContext();
+  const page = await context.newPage();
+
+  try {
+    // The exposed function runs in the k6 script, and its
+    // result is returned to the page as a promise.
+    await page.exposeFunction('sha256', text => crypto.sha256(text, 'hex'));
+    // Bindings also receive the page and the frame that called them.
+    await context.exposeBinding('pageURL', source => source.page.url());
+
+    await page.setContent(`
+      <button onclick="sha256(this.textContent).then(h => result.textContent = h)">k6</button>
+      <div id="result"></div>
+    `);
+    await page.locator('button').click();
+    await page.waitForFunction(() => result.textContent != '');
+
+    check(page, {
+      'hash': async p => await p.textContent('#result') ==
+        '1d92ad4b6987fa0347cc5d2fb6cf9e47c83f4f6caeb3b5ef6f629730528921c3',
+      'url': async p => await p.evaluate(() => pageURL()) == 'about:blank',
+    });
+  } finally {
+    // Closing the page and the context lets the iteration end, as
+    // their exposed functions keep waiting for calls until then.
+    await page.close();
+    await context.close();
+  }
+}
@@ -0,0 +1,44 @@
+import { browser } from 'k6/x/browser/async';
+import { check } from 'https://jslib.k6.io/k6-utils/1.5.0/index.js';
+
+export const options = {
+  scenarios: {
+    ui: {
+      executor: 'shared-iterations',
+      options: {
+        browser: {
+            type: 'chromium',
+        },
+      },
+    },
+  },
+  thresholds: {
+    checks: ["rate==1.0"]
+  }
+}
+
+export default async function() {
+  const page = await browser.newPage();
+
+  try {
+    await page.setContent(`
+      <button onclick="this.textContent = confirm('Delete the item?') ? 'deleted' : 'kept'">delete</button>
+    `);
+
+    page.on('dialog', async dialog => {
+      check(dialog, {
+        'dialog type': d => d.type() == 'confirm',
+        'dialog message': d => d.message() == 'Delete the item?',
+      });
+      await dialog.accept();
+    });
+
+    await page.locator('button').click();
+
+    check(await page.locator('button').textContent(), {
+      'item deleted': text => text == 'deleted',
+    });
+  } finally {
+    await page.close();
+  }
+}
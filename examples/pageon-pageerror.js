@@ -0,0 +1,45 @@
+import { browser } from 'k6/x/browser/async';
+import { check } from 'https://jslib.k6.io/k6-utils/1.5.0/index.js';
+
+export const options = {
+  scenarios: {
+    ui: {
+      executor: 'shared-iterations',
+      options: {
+        browser: {
+            type: 'chromium',
+        },
+      },
+    },
+  },
+  thresholds: {
+    checks: ["rate==1.0"],
+    browser_page_errors: ["count==1"],
+  }
+}
+
+export default async function() {
+  // Set failOnPageError to true to fail the iteration
+  // when the page throws an uncaught exception.
+  const context = await browser.newContext({ failOnPageError: false });
+  const page = await context.newPage();
+
+  try {
+    const errors = [];
+    page.on('pageerror', err => errors.push(err));
+
+    await page.setContent(`
+      <button onclick="item.remove()">delete</button>
+    `);
+    await page.locator('button').click();
+    await page.waitForTimeout(100);
+
+    check(errors, {
+      'one page error': e => e.length == 1,
+      'page error name': e => e[0].name == 'ReferenceError',
+      'page error message': e => e[0].message == 'item is not defined',
+    });
+  } finally {
+    await page.close();
+  }
+}
@@ -0,0 +1,45 @@
+import { browser } from 'k6/x/browser/async';
+import { check } from 'https://jslib.k6.io/k6-utils/1.5.0/index.js';
+
+export const options = {
+  scenarios: {
+    ui: {
+      executor: 'shared-iterations',
+      options: {
+        browser: {
+            type: 'chromium',
+        },
+      },
+    },
+  },
+  thresholds: {
+    checks: ["rate==1.0"],
+    browser_pdf_duration: ["p(95)<2000"],
+  }
+}
+
+export default async function() {
+  const page = await browser.newPage();
+
+  try {
+    await page.setContent(`
+      <h1>Invoice</h1>
+      <p>Total: 42</p>
+    `);
+
+    // PDFs are only generated in headless mode. The PDF is saved
+    // with the same file persister as the screenshots.
+    const pdf = await page.pdf({
+      path: 'pdfs/invoice.pdf',
+      format: 'A4',
+      margin: { top: '1cm', bottom: '1cm' },
+      printBackground: true,
+    });
+
+    check(pdf, {
+      'pdf': p => String.fromCharCode(...new Uint8Array(p, 0, 4)) == '%PDF',
+    });
+  } finally {
+    await page.close();
+  }
+}
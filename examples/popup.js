@@ -0,0 +1,52 @@
+import { browser } from 'k6/x/browser/async';
+import { check } from 'https://jslib.k6.io/k6-utils/1.5.0/index.js';
+
+export const options = {
+  scenarios: {
+    ui: {
+      executor: 'shared-iterations',
+      options: {
+        browser: {
+            type: 'chromium',
+        },
+      },
+    },
+  },
+  thresholds: {
+    checks: ["rate==1.0"]
+  }
+}
+
+export default async function() {
+  const context = await browser.newContext();
+  context.on('page', page => console.log(`new page: ${page.url()}`));
+
+  const page = await context.newPage();
+
+  try {
+    await page.goto('https://test.k6.io/');
+    await page.setContent(`
+      <a href="https://test.k6.io/my_messages.php" target="_blank">login</a>
+    `);
+
+    const [popup] = await Promise.all([
+      page.waitForEvent('popup'),
+      page.locator('a').click(),
+    ]);
+
+    // The popup has committed its initial navigation,
+    // so it can be used right away.
+    await popup.locator('input[name="login"]').type('admin');
+
+    check(popup, {
+      'popup url': p => p.url() == 'https://test.k6.io/my_messages.php',
+    });
+
+    await popup.close();
+  } finally {
+    await page.close();
+    // The context.on('page') handler keeps the
+    // iteration running until the context closes.
+    await context.close();
+  }
+}
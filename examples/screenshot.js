@@ -23,6 +23,20 @@ export default async function() {
   try {
     await page.goto('https://test.k6.io/');
     await page.screenshot({ path: 'screenshot.png' });
+    // The {run}, {scenario}, {vu}, {iter} and {timestamp} placeholders
+    // keep the screenshots of the VUs from overwriting each other. The
+    // K6_BROWSER_ARTIFACTS_PATH_TEMPLATE env var applies a template to
+    // all the paths instead, for example {scenario}/{vu}-{iter}-{name}.{ext}.
+    await page.screenshot({ path: 'screenshots/{scenario}/{vu}-{iter}.png' });
+    // Deterministic screenshot: animations are finished, the caret is
+    // hidden by default, and the dynamic parts of the page are masked.
+    await page.screenshot({
+      path: 'screenshot.webp',
+      fullPage: true,
+      animations: 'disabled',
+      mask: [page.locator('h2')],
+      scale: 'css',
+    });
     // TODO: Assert this somehow. Upload as CI artifact or just an external `ls`?
     // Maybe even do a fuzzy image comparison against a preset known good screenshot?
   } finally {
@@ -0,0 +1,31 @@
+import { browser } from 'k6/x/browser/async';
+
+export const options = {
+  scenarios: {
+    ui: {
+      executor: 'shared-iterations',
+      options: {
+        browser: {
+            type: 'chromium',
+        },
+      },
+    },
+  },
+}
+
+export default async function() {
+  const context = await browser.newContext();
+  // The trace can be opened with: npx playwright show-trace trace.zip
+  await context.tracing.start({ screenshots: true, snapshots: true, sources: true });
+  const page = await context.newPage();
+
+  try {
+    await page.goto('https://test.k6.io/my_messages.php');
+    await page.locator('input[name="login"]').type('admin');
+    await page.locator('input[name="password"]').type('123');
+    await page.locator('input[type="submit"]').click();
+  } finally {
+    await context.tracing.stop({ path: 'trace.zip' });
+    await page.close();
+  }
+}
@@ -0,0 +1,41 @@
+import { browser } from 'k6/x/browser/async';
+import { check } from 'https://jslib.k6.io/k6-utils/1.5.0/index.js';
+
+export const options = {
+  scenarios: {
+    ui: {
+      executor: 'shared-iterations',
+      options: {
+        browser: {
+            type: 'chromium',
+        },
+      },
+    },
+  },
+  thresholds: {
+    checks: ["rate==1.0"]
+  }
+}
+
+export default async function() {
+  // The pages of the context are recorded into the videos path,
+  // and the videos are saved when the pages close.
+  const context = await browser.newContext({ videosPath: 'videos/' });
+  const page = await context.newPage();
+
+  try {
+    await page.goto('https://test.k6.io/my_messages.php');
+    await page.locator('input[name="login"]').type('admin');
+    await page.locator('input[name="password"]').type('123');
+    await page.locator('input[type="submit"]').click();
+
+    check(page, {
+      'video': p => p.video().path().endsWith('.avi'),
+    });
+  } finally {
+    await page.close();
+  }
+
+  // Save a copy of the video with a known name.
+  await page.video().saveAs('videos/login.avi');
+}
@@ -0,0 +1,38 @@
+import { browser, expect } from 'k6/x/browser/async';
+
+// Compares the screenshots with the baselines in the __screenshots__
+// directory, or in K6_BROWSER_SNAPSHOTS_DIR. The missing baselines are
+// written on the first run. Run with K6_BROWSER_UPDATE_SNAPSHOTS=true
+// to update the baselines.
+export const options = {
+  scenarios: {
+    ui: {
+      executor: 'shared-iterations',
+      options: {
+        browser: {
+            type: 'chromium',
+        },
+      },
+    },
+  },
+  thresholds: {
+    checks: ["rate==1.0"]
+  }
+}
+
+export default async function() {
+  const page = await browser.newPage();
+
+  try {
+    await page.goto('https://test.k6.io/');
+    await expect(page).toHaveScreenshot('home', {
+      fullPage: true,
+      maxDiffPixelRatio: 0.01,
+    });
+    await expect(page.locator('header')).toHaveScreenshot('header.png', {
+      threshold: 0.1,
+    });
+  } finally {
+    await page.close();
+  }
+}
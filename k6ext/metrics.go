@@ -19,10 +19,14 @@ const (
 	inpName  = "browser_web_vital_inp"
 	fcpName  = "browser_web_vital_fcp"
 
-	browserDataSentName        = "browser_data_sent"
-	browserDataReceivedName    = "browser_data_received"
-	browserHTTPReqDurationName = "browser_http_req_duration"
-	browserHTTPReqFailedName   = "browser_http_req_failed"
+	browserDataSentName         = "browser_data_sent"
+	browserDataReceivedName     = "browser_data_received"
+	browserHTTPReqDurationName  = "browser_http_req_duration"
+	browserHTTPReqFailedName    = "browser_http_req_failed"
+	browserDownloadSizeName     = "browser_download_size"
+	browserDownloadDurationName = "browser_download_duration"
+	browserPageErrorsName       = "browser_page_errors"
+	browserPDFDurationName      = "browser_pdf_duration"
 )
 
 // CustomMetrics are the custom k6 metrics used by xk6-browser.
@@ -33,6 +37,13 @@ type CustomMetrics struct {
 	BrowserDataReceived    *k6metrics.Metric
 	BrowserHTTPReqDuration *k6metrics.Metric
 	BrowserHTTPReqFailed   *k6metrics.Metric
+
+	BrowserDownloadSize     *k6metrics.Metric
+	BrowserDownloadDuration *k6metrics.Metric
+
+	BrowserPageErrors *k6metrics.Metric
+
+	BrowserPDFDuration *k6metrics.Metric
 }
 
 // RegisterCustomMetrics creates and registers our custom metrics with the k6
@@ -65,5 +76,11 @@ func RegisterCustomMetrics(registry *k6metrics.Registry) *CustomMetrics {
 		BrowserDataReceived:    registry.MustNewMetric(browserDataReceivedName, k6metrics.Counter, k6metrics.Data),
 		BrowserHTTPReqDuration: registry.MustNewMetric(browserHTTPReqDurationName, k6metrics.Trend, k6metrics.Time),
 		BrowserHTTPReqFailed:   registry.MustNewMetric(browserHTTPReqFailedName, k6metrics.Rate),
+		BrowserDownloadSize:    registry.MustNewMetric(browserDownloadSizeName, k6metrics.Trend, k6metrics.Data),
+		BrowserDownloadDuration: registry.MustNewMetric(
+			browserDownloadDurationName, k6metrics.Trend, k6metrics.Time,
+		),
+		BrowserPageErrors:  registry.MustNewMetric(browserPageErrorsName, k6metrics.Counter),
+		BrowserPDFDuration: registry.MustNewMetric(browserPDFDurationName, k6metrics.Trend, k6metrics.Time),
 	}
 }
@@ -0,0 +1,161 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ArtifactTags describe the test run, scenario, VU and iteration
+// that an artifact, such as a screenshot, is persisted from.
+type ArtifactTags struct {
+	RunID     string
+	Scenario  string
+	VU        uint64
+	Iteration int64
+	Timestamp time.Time
+}
+
+// ExpandPathTemplate returns the path of an artifact by replacing the
+// placeholders of the template with the tags and the path that the
+// artifact was requested to be persisted at:
+//
+//	{run}       the test run id.
+//	{scenario}  the scenario name.
+//	{vu}        the VU id.
+//	{iter}      the iteration number of the VU.
+//	{timestamp} the Unix time in milliseconds.
+//	{dir}       the directory of the path.
+//	{name}      the file name of the path without its extension.
+//	{ext}       the extension of the path without the dot.
+//
+// For example, screens/{scenario}/{vu}-{iter}-{name}.{ext}. If the
+// template is empty, the placeholders of the path are replaced instead,
+// except {dir}, {name} and {ext}.
+func ExpandPathTemplate(template, path string, tags ArtifactTags) string {
+	kv := []string{
+		"{run}", tags.RunID,
+		"{scenario}", tags.Scenario,
+		"{vu}", strconv.FormatUint(tags.VU, 10),
+		"{iter}", strconv.FormatInt(tags.Iteration, 10),
+		"{timestamp}", strconv.FormatInt(tags.Timestamp.UnixMilli(), 10),
+	}
+	if template == "" {
+		return strings.NewReplacer(kv...).Replace(path)
+	}
+
+	ext := filepath.Ext(path)
+	kv = append(kv,
+		"{dir}", filepath.Dir(path),
+		"{name}", strings.TrimSuffix(filepath.Base(path), ext),
+		"{ext}", strings.TrimPrefix(ext, "."),
+	)
+
+	ep := filepath.Clean(strings.NewReplacer(kv...).Replace(template))
+	// An empty placeholder, such as {run} outside of the cloud,
+	// must not turn a relative template into an absolute path.
+	if !filepath.IsAbs(template) {
+		ep = strings.TrimLeft(ep, string(filepath.Separator))
+	}
+
+	return ep
+}
+
+// persister persists files, such as the [LocalFilePersister].
+type persister interface {
+	Persist(ctx context.Context, path string, data io.Reader) error
+}
+
+// Artifact is a persisted file in the artifact manifest.
+type Artifact struct {
+	// Path is the path that the file is persisted at.
+	Path string `json:"path"`
+	// Name is the path that the file was requested to be persisted at.
+	Name      string            `json:"name"`
+	Timestamp time.Time         `json:"timestamp"`
+	Tags      map[string]string `json:"tags"`
+}
+
+// ArtifactManifest lists the files persisted during a test run.
+// It is safe for concurrent use.
+type ArtifactManifest struct {
+	path      string
+	runID     string
+	persister persister
+
+	mu        sync.Mutex
+	artifacts []Artifact
+	written   bool
+}
+
+// NewArtifactManifest returns a new artifact manifest that is
+// persisted at path with the persister.
+func NewArtifactManifest(path, runID string, persister persister) *ArtifactManifest {
+	return &ArtifactManifest{
+		path:      path,
+		runID:     runID,
+		persister: persister,
+	}
+}
+
+// Add adds a persisted file to the manifest. The manifest is persisted
+// again if it was already persisted, so that the files persisted after
+// the end of the test, such as videos, are also listed.
+func (m *ArtifactManifest) Add(ctx context.Context, path, name string, tags ArtifactTags) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.artifacts = append(m.artifacts, Artifact{
+		Path:      path,
+		Name:      name,
+		Timestamp: tags.Timestamp,
+		Tags: map[string]string{
+			"scenario": tags.Scenario,
+			"vu":       strconv.FormatUint(tags.VU, 10),
+			"iter":     strconv.FormatInt(tags.Iteration, 10),
+		},
+	})
+	if !m.written {
+		return nil
+	}
+
+	return m.write(ctx)
+}
+
+// Write persists the manifest as JSON.
+func (m *ArtifactManifest) Write(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.write(ctx)
+}
+
+func (m *ArtifactManifest) write(ctx context.Context) error {
+	artifacts := m.artifacts
+	if artifacts == nil {
+		artifacts = []Artifact{}
+	}
+	b, err := json.MarshalIndent(struct {
+		TestRunID string     `json:"testRunId"`
+		Artifacts []Artifact `json:"artifacts"`
+	}{
+		TestRunID: m.runID,
+		Artifacts: artifacts,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling artifact manifest: %w", err)
+	}
+	if err := m.persister.Persist(ctx, m.path, bytes.NewReader(b)); err != nil {
+		return fmt.Errorf("persisting artifact manifest to %q: %w", m.path, err)
+	}
+	m.written = true
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandPathTemplate(t *testing.T) {
+	t.Parallel()
+
+	tags := ArtifactTags{
+		RunID:     "run1",
+		Scenario:  "ui",
+		VU:        3,
+		Iteration: 7,
+		Timestamp: time.UnixMilli(1700000000000),
+	}
+	tests := []struct {
+		name     string
+		template string
+		path     string
+		tags     *ArtifactTags
+		want     string
+	}{
+		{
+			name: "no_template",
+			path: "screenshot.png",
+			want: "screenshot.png",
+		},
+		{
+			name: "path_placeholders",
+			path: "screens/{run}/{scenario}-{vu}-{iter}.png",
+			want: "screens/run1/ui-3-7.png",
+		},
+		{
+			name:     "template",
+			template: "screens/{scenario}/{vu}-{iter}-{timestamp}-{name}.png",
+			path:     "shots/screenshot.png",
+			want:     "screens/ui/3-7-1700000000000-screenshot.png",
+		},
+		{
+			name:     "template_dir_ext",
+			template: "{run}/{dir}/{name}-{vu}.{ext}",
+			path:     "shots/report.pdf",
+			want:     "run1/shots/report-3.pdf",
+		},
+		{
+			name:     "template_empty_tags",
+			template: "{run}/{scenario}/{name}.{ext}",
+			path:     "screenshot.png",
+			tags:     &ArtifactTags{},
+			want:     "screenshot.png",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tags := tags
+			if tt.tags != nil {
+				tags = *tt.tags
+			}
+			assert.Equal(t, tt.want, ExpandPathTemplate(tt.template, tt.path, tags))
+		})
+	}
+}
+
+func TestArtifactManifest(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "manifest.json")
+	m := NewArtifactManifest(path, "run1", &LocalFilePersister{})
+
+	read := func() (manifest struct {
+		TestRunID string     `json:"testRunId"`
+		Artifacts []Artifact `json:"artifacts"`
+	},
+	) {
+		b, err := os.ReadFile(path) //nolint:forbidigo
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(b, &manifest))
+		return manifest
+	}
+
+	ctx := context.Background()
+	ts := time.UnixMilli(1700000000000).UTC()
+	require.NoError(t, m.Add(ctx, "ui/1-0.png", "screenshot.png", ArtifactTags{
+		Scenario: "ui", VU: 1, Timestamp: ts,
+	}))
+	assert.NoFileExists(t, path, "want the manifest written on Write")
+
+	require.NoError(t, m.Write(ctx))
+	got := read()
+	assert.Equal(t, "run1", got.TestRunID)
+	require.Len(t, got.Artifacts, 1)
+	assert.Equal(t, Artifact{
+		Path:      "ui/1-0.png",
+		Name:      "screenshot.png",
+		Timestamp: ts,
+		Tags:      map[string]string{"scenario": "ui", "vu": "1", "iter": "0"},
+	}, got.Artifacts[0])
+
+	// The files persisted after the manifest is written update it.
+	require.NoError(t, m.Add(ctx, "ui/2-5.webm", "video.webm", ArtifactTags{
+		Scenario: "ui", VU: 2, Iteration: 5, Timestamp: ts,
+	}))
+	got = read()
+	require.Len(t, got.Artifacts, 2)
+	assert.Equal(t, "ui/2-5.webm", got.Artifacts[1].Path)
+}
@@ -0,0 +1,123 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/xk6-browser/common"
+)
+
+func TestPageExposeBinding(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t, withFileServer())
+	p := tb.NewPage(nil)
+
+	var source common.BindingSource
+	err := p.ExposeBinding("add", func(src common.BindingSource, args []any) (any, error) {
+		source = src
+		a, _ := args[0].(float64)
+		b, _ := args[1].(float64)
+		return a + b, nil
+	})
+	require.NoError(t, err)
+	err = p.ExposeBinding("fail", func(common.BindingSource, []any) (any, error) {
+		return nil, errors.New("binding failed")
+	})
+	require.NoError(t, err)
+
+	// The binding is exposed to the current document and to the
+	// documents of the later navigations.
+	for _, url := range []string{"about:blank", tb.staticURL("empty.html")} {
+		opts := &common.FrameGotoOptions{Timeout: common.DefaultTimeout}
+		_, err := p.Goto(url, opts)
+		require.NoError(t, err)
+
+		got, err := p.Evaluate(`() => add(2, 3)`)
+		require.NoError(t, err)
+		assert.EqualValues(t, 5, got)
+		assert.Same(t, p, source.Page)
+		assert.Same(t, p.MainFrame(), source.Frame)
+
+		got, err = p.Evaluate(`() => fail().catch(e => e.message)`)
+		require.NoError(t, err)
+		assert.Equal(t, "binding failed", got)
+	}
+
+	err = p.ExposeBinding("add", func(common.BindingSource, []any) (any, error) { return nil, nil })
+	assert.ErrorIs(t, err, common.ErrBindingExists)
+}
+
+func TestBrowserContextExposeBinding(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t)
+	bc, err := tb.NewContext(nil)
+	require.NoError(t, err)
+	p1, err := bc.NewPage()
+	require.NoError(t, err)
+
+	err = bc.ExposeBinding("pageURL", func(src common.BindingSource, _ []any) (any, error) {
+		return src.Page.URL()
+	})
+	require.NoError(t, err)
+
+	// The binding is exposed to the existing and the new pages.
+	p2, err := bc.NewPage()
+	require.NoError(t, err)
+	for _, p := range []*common.Page{p1, p2} {
+		got, err := p.Evaluate(`() => pageURL()`)
+		require.NoError(t, err)
+		assert.Equal(t, "about:blank", got)
+	}
+
+	err = p1.ExposeBinding("pageURL", func(common.BindingSource, []any) (any, error) { return nil, nil })
+	assert.ErrorIs(t, err, common.ErrBindingExists)
+}
+
+func TestPageExposeFunctionMapping(t *testing.T) {
+	t.Parallel()
+
+	vu, _, _, cleanUp := startIteration(t)
+	defer cleanUp()
+
+	got := vu.RunPromise(t, `
+		const p = await browser.newPage();
+		await p.exposeFunction("sum", (...nums) => nums.reduce((a, b) => a + b, 0));
+		await p.exposeFunction("sumAsync", async (a, b) => a + b);
+		await p.exposeFunction("fail", () => { throw new Error("boom"); });
+		await p.exposeBinding("frameURL", (source) => source.frame.url());
+
+		const results = await p.evaluate(async () => [
+			await sum(1, 2, 3),
+			await sumAsync(4, 5),
+			await fail().catch(e => e.message),
+			await frameURL(),
+		]);
+		await p.close();
+		return JSON.stringify(results);
+	`)
+	assert.Equal(t, `[6,9,"boom","about:blank"]`, got.Result().String())
+}
+
+func TestBrowserContextExposeBindingMapping(t *testing.T) {
+	t.Parallel()
+
+	vu, _, _, cleanUp := startIteration(t)
+	defer cleanUp()
+
+	// The iteration ends once the context that the binding
+	// is exposed to is closed.
+	got := vu.RunPromise(t, `
+		const context = await browser.newContext();
+		await context.exposeBinding("pageURL", (source) => source.page.url());
+		const p = await context.newPage();
+		const url = await p.evaluate(() => pageURL());
+		await context.close();
+		return url;
+	`)
+	assert.Equal(t, "about:blank", got.Result().String())
+}
@@ -33,6 +33,7 @@ func TestBrowserContextOptionsDefaultValues(t *testing.T) {
 	assert.Empty(t, opts.Permissions)
 	assert.Equal(t, common.ReducedMotionNoPreference, opts.ReducedMotion)
 	assert.Equal(t, common.Screen{Width: common.DefaultScreenWidth, Height: common.DefaultScreenHeight}, opts.Screen)
+	assert.False(t, opts.StrictSelectors)
 	assert.Equal(t, "", opts.TimezoneID)
 	assert.Equal(t, "", opts.UserAgent)
 	assert.Equal(t, common.Viewport{Width: common.DefaultScreenWidth, Height: common.DefaultScreenHeight}, opts.Viewport)
@@ -119,3 +120,64 @@ func TestBrowserContextOptionsExtraHTTPHeaders(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestBrowserContextOptionsHumanize(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t)
+	always := 1.0
+	opts := common.DefaultBrowserContextOptions()
+	opts.Humanize = &common.Humanize{
+		MouseSpeed: 5000,
+		KeystrokeDelay: common.DelayDistribution{
+			Mean: 10, StdDev: 5, Min: 1, Max: 20,
+		},
+		TypoRate: &always,
+		Seed:     1,
+	}
+	bctx, err := tb.NewContext(opts)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := bctx.Close(); err != nil {
+			t.Log("closing browser context:", err)
+		}
+	})
+	p, err := bctx.NewPage()
+	require.NoError(t, err)
+
+	err = p.SetContent(`
+		<input>
+		<script>
+			window.keys = [];
+			window.moves = [];
+			document.addEventListener('keydown', e => window.keys.push(e.key));
+			document.addEventListener('mousemove', e => window.moves.push([e.clientX, e.clientY]));
+		</script>
+	`, nil)
+	require.NoError(t, err)
+
+	t.Run("typing", func(t *testing.T) {
+		require.NoError(t, p.Focus("input", nil))
+		require.NoError(t, p.GetKeyboard().Type("hello", common.KeyboardOptions{}))
+
+		v, err := p.InputValue("input", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", v, "typos should be corrected")
+
+		keys, err := p.Evaluate(`() => window.keys.filter(k => k === 'Backspace').length`)
+		require.NoError(t, err)
+		assert.EqualValues(t, 5, keys, "every character should be mistyped once")
+	})
+
+	t.Run("mouse", func(t *testing.T) {
+		require.NoError(t, p.GetMouse().Move(400, 300, nil))
+
+		moves, err := p.Evaluate(`() => window.moves.length`)
+		require.NoError(t, err)
+		assert.Greater(t, *convert(t, moves, new(float64)), 5.0, "the mouse should move along a path")
+
+		last, err := p.Evaluate(`() => window.moves[window.moves.length - 1].join(',')`)
+		require.NoError(t, err)
+		assert.Equal(t, "400,300", last)
+	})
+}
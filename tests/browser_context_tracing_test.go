@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/xk6-browser/common"
+	"github.com/grafana/xk6-browser/storage"
+)
+
+func TestBrowserContextTracing(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t, withHTTPServer())
+	bctx, err := tb.NewContext(nil)
+	require.NoError(t, err)
+	page, err := bctx.NewPage()
+	require.NoError(t, err)
+
+	tracing := bctx.Tracing()
+	require.NoError(t, tracing.Start(&common.TracingStartOptions{
+		Screenshots: true,
+		Snapshots:   true,
+	}, nil))
+
+	opts := &common.FrameGotoOptions{
+		Timeout: common.DefaultTimeout,
+	}
+	_, err = page.Goto(tb.staticURL("empty.html"), opts)
+	require.NoError(t, err)
+	_, err = page.Evaluate(`() => console.log('traced')`)
+	require.NoError(t, err)
+	page.WaitForTimeout(200)
+
+	path := filepath.Join(t.TempDir(), "trace.zip")
+	require.NoError(t, tracing.Stop(&common.TracingStopOptions{Path: path}, &storage.LocalFilePersister{}))
+
+	zr, err := zip.OpenReader(path)
+	require.NoError(t, err)
+	defer zr.Close() //nolint:errcheck
+
+	var (
+		events   = make(map[string][]map[string]any)
+		requests int
+	)
+	for _, f := range zr.File {
+		if f.Name != "trace.trace" && f.Name != "trace.network" {
+			continue
+		}
+		r, err := f.Open()
+		require.NoError(t, err)
+		s := bufio.NewScanner(r)
+		s.Buffer(nil, 1<<20)
+		for s.Scan() {
+			var ev map[string]any
+			require.NoError(t, json.Unmarshal(s.Bytes(), &ev))
+			if ev["type"] == "resource-snapshot" {
+				requests++
+				continue
+			}
+			typ, _ := ev["type"].(string)
+			events[typ] = append(events[typ], ev)
+		}
+		require.NoError(t, s.Err())
+		require.NoError(t, r.Close())
+	}
+
+	require.Len(t, events["context-options"], 1)
+	require.NotEmpty(t, events["before"])
+	assert.Equal(t, "page.goto", events["before"][0]["apiName"])
+	assert.Len(t, events["after"], len(events["before"]))
+	assert.NotEmpty(t, events["frame-snapshot"])
+	assert.NotEmpty(t, events["screencast-frame"])
+	require.Len(t, events["console"], 1)
+	assert.Equal(t, "traced", events["console"][0]["text"])
+	assert.Positive(t, requests)
+}
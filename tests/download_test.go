@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/xk6-browser/common"
+	"github.com/grafana/xk6-browser/storage"
+)
+
+func TestPageDownload(t *testing.T) {
+	t.Parallel()
+
+	const report = "id,name\n1,k6\n"
+
+	tb := newTestBrowser(t, withHTTPServer())
+	tb.withHandler("/report", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", `attachment; filename="report.csv"`)
+		_, _ = fmt.Fprint(w, report)
+	})
+
+	opts := common.DefaultBrowserContextOptions()
+	opts.AcceptDownloads = true
+	opts.DownloadsPath = t.TempDir()
+	p := tb.NewPage(opts)
+
+	err := p.SetContent(fmt.Sprintf(`<a href="%s">export</a>`, tb.url("/report")), nil)
+	require.NoError(t, err)
+
+	var (
+		onDownload = make(chan *common.Download, 1)
+		download   *common.Download
+	)
+	err = p.On(common.EventPageDownloadStarted, func(e common.PageOnEvent) error {
+		onDownload <- e.Download
+		return nil
+	})
+	require.NoError(t, err)
+
+	err = tb.run(
+		tb.context(),
+		func() error {
+			v, err := p.WaitForEvent(common.EventPageDownload, nil, p.Timeout())
+			if err != nil {
+				return err
+			}
+			download, _ = v.(*common.Download)
+			return nil
+		},
+		func() error { return p.Click("a", common.NewFrameClickOptions(p.Timeout())) },
+	)
+	require.NoError(t, err)
+	require.NotNil(t, download)
+	assert.Same(t, download, <-onDownload)
+
+	assert.Equal(t, tb.url("/report"), download.URL())
+	assert.Equal(t, "report.csv", download.SuggestedFilename())
+
+	failure, err := download.Failure()
+	require.NoError(t, err)
+	assert.Empty(t, failure)
+
+	path, err := download.Path()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(path, opts.DownloadsPath), "%q should be in the downloads path", path)
+	got, err := os.ReadFile(path) //nolint:forbidigo
+	require.NoError(t, err)
+	assert.Equal(t, report, string(got))
+
+	saveAs := filepath.Join(t.TempDir(), "saved.csv")
+	require.NoError(t, download.SaveAs(saveAs, &storage.LocalFilePersister{}))
+	got, err = os.ReadFile(saveAs) //nolint:forbidigo
+	require.NoError(t, err)
+	assert.Equal(t, report, string(got))
+}
@@ -0,0 +1,132 @@
+package tests
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/xk6-browser/common"
+)
+
+func TestDragAndDrop(t *testing.T) {
+	t.Parallel()
+
+	const html = `
+		<style>
+			div { width: 100px; height: 100px; margin: 20px; }
+		</style>
+		<div id="card" draggable="true">card</div>
+		<div id="column"></div>
+		<div id="handle" style="position: relative">handle</div>
+		<div id="zone"></div>
+		<script>
+			window.events = [];
+			const card = document.getElementById('card');
+			const column = document.getElementById('column');
+			card.addEventListener('dragstart', e => e.dataTransfer.setData('text/plain', card.id));
+			column.addEventListener('dragover', e => e.preventDefault());
+			column.addEventListener('drop', e => {
+				e.preventDefault();
+				column.appendChild(document.getElementById(e.dataTransfer.getData('text/plain')));
+			});
+
+			const handle = document.getElementById('handle');
+			let dragging = false;
+			handle.addEventListener('mousedown', () => { dragging = true; window.events.push('down'); });
+			document.addEventListener('mousemove', () => { if (dragging) window.events.push('move'); });
+			document.addEventListener('mouseup', e => {
+				if (!dragging) return;
+				dragging = false;
+				window.events.push('up:' + document.elementFromPoint(e.clientX, e.clientY).id);
+			});
+
+			const zone = document.getElementById('zone');
+			zone.addEventListener('dragover', e => e.preventDefault());
+			zone.addEventListener('drop', async e => {
+				e.preventDefault();
+				const f = e.dataTransfer.files[0];
+				window.dropped = f.name + ':' + await f.text();
+			});
+		</script>
+	`
+
+	setup := func(t *testing.T) *common.Page {
+		t.Helper()
+
+		p := newTestBrowser(t).NewPage(nil)
+		require.NoError(t, p.SetContent(html, nil))
+
+		return p
+	}
+
+	t.Run("html5_locator_drag_to", func(t *testing.T) {
+		t.Parallel()
+
+		p := setup(t)
+		card := p.Locator("#card", nil)
+		column := p.Locator("#column", nil)
+		require.NoError(t, card.DragTo(column, common.NewFrameDragAndDropOptions(card.Timeout())))
+
+		parent, err := p.Evaluate(`() => document.getElementById('card').parentElement.id`)
+		require.NoError(t, err)
+		assert.Equal(t, "column", parent)
+	})
+	t.Run("html5_page_drag_and_drop", func(t *testing.T) {
+		t.Parallel()
+
+		p := setup(t)
+		opts := common.NewFrameDragAndDropOptions(p.Timeout())
+		opts.SourcePosition = &common.Position{X: 10, Y: 10}
+		opts.TargetPosition = &common.Position{X: 90, Y: 90}
+		require.NoError(t, p.DragAndDrop("#card", "#column", opts))
+
+		parent, err := p.Evaluate(`() => document.getElementById('card').parentElement.id`)
+		require.NoError(t, err)
+		assert.Equal(t, "column", parent)
+	})
+	t.Run("mouse_events", func(t *testing.T) {
+		t.Parallel()
+
+		p := setup(t)
+		handle := p.Locator("#handle", nil)
+		zone := p.Locator("#zone", nil)
+		require.NoError(t, handle.DragTo(zone, common.NewFrameDragAndDropOptions(handle.Timeout())))
+
+		events, err := p.Evaluate(`() => window.events`)
+		require.NoError(t, err)
+		assert.Equal(t, []any{"down", "move", "up:zone"}, events)
+	})
+	t.Run("missing_target_releases_button", func(t *testing.T) {
+		t.Parallel()
+
+		p := setup(t)
+		handle := p.Locator("#handle", nil)
+		missing := p.Locator("#missing", nil)
+		require.Error(t, handle.DragTo(missing, common.NewFrameDragAndDropOptions(500*time.Millisecond)))
+
+		events, err := p.Evaluate(`() => window.events`)
+		require.NoError(t, err)
+		assert.Equal(t, []any{"down", "up:handle"}, events)
+	})
+	t.Run("drop_files", func(t *testing.T) {
+		t.Parallel()
+
+		p := setup(t)
+		zone := p.Locator("#zone", nil)
+		files := &common.Files{Payload: []*common.File{
+			{
+				Name:     "notes.txt",
+				Mimetype: "text/plain",
+				Buffer:   base64.StdEncoding.EncodeToString([]byte("hello")),
+			},
+		}}
+		require.NoError(t, zone.DropFiles(files, common.NewElementHandleBaseOptions(zone.Timeout())))
+
+		dropped, err := p.WaitForFunction(`() => window.dropped`, common.NewFrameWaitForFunctionOptions(p.Timeout()))
+		require.NoError(t, err)
+		assert.Equal(t, "notes.txt:hello", dropped)
+	})
+}
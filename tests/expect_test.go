@@ -0,0 +1,124 @@
+package tests
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/xk6-browser/common"
+	"github.com/grafana/xk6-browser/storage"
+)
+
+func TestLocatorAssertions(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t)
+	p := tb.NewPage(nil)
+	err := p.SetContent(`
+		<div id="late" hidden>  Hello
+			world </div>
+		<input id="name" value="k6">
+		<ul><li>1</li><li>2</li><li>3</li></ul>
+		<a href="/home" data-test="link">home</a>
+		<script>
+			setTimeout(() => document.getElementById('late').hidden = false, 300);
+		</script>
+	`, nil)
+	require.NoError(t, err)
+
+	locator := func(sel string) *common.LocatorAssertions {
+		return common.NewLocatorAssertions(tb.context(), p.Locator(sel, nil))
+	}
+	opts := common.NewExpectOptions()
+	re, err := common.NewRegExpMatcher(`^hel+o`, "i")
+	require.NoError(t, err)
+
+	require.NoError(t, locator("#late").ToBeVisible(opts))
+	require.NoError(t, locator("#late").ToHaveText(common.NewStringMatcher("Hello world"), opts))
+	require.NoError(t, locator("#late").ToHaveText(re, opts))
+	require.NoError(t, locator("#name").ToHaveValue(common.NewStringMatcher("k6"), opts))
+	require.NoError(t, locator("li").ToHaveCount(3, opts))
+	require.NoError(t, locator("a").ToHaveAttribute("data-test", nil, opts))
+	require.NoError(t, locator("a").ToHaveAttribute("href", common.NewStringMatcher("/home"), opts))
+	require.NoError(t, locator("#missing").ToBeHidden(opts))
+
+	short := &common.ExpectOptions{Timeout: 200 * time.Millisecond}
+	err = locator("li").ToHaveCount(2, short)
+	require.ErrorIs(t, err, common.ErrExpectationFailed)
+	assert.ErrorContains(t, err, `expect(locator("li")).toHaveCount(2): timed out after 200ms, got 3`)
+
+	not := &common.ExpectOptions{Timeout: 200 * time.Millisecond, Not: true}
+	require.NoError(t, locator("#name").ToHaveValue(common.NewStringMatcher("k7"), not))
+	err = locator("#missing").ToHaveText(common.NewStringMatcher("x"), short)
+	require.ErrorIs(t, err, common.ErrExpectationFailed)
+
+	soft := &common.ExpectOptions{Timeout: 200 * time.Millisecond, Soft: true}
+	require.NoError(t, locator("li").ToHaveCount(5, soft))
+}
+
+func TestPageAssertions(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t, withFileServer())
+	p := tb.NewPage(nil)
+	opts := &common.FrameGotoOptions{
+		Timeout: common.DefaultTimeout,
+	}
+	resp, err := p.Goto(tb.staticURL("page1.html"), opts)
+	require.NoError(t, err)
+
+	eopts := common.NewExpectOptions()
+	pa := common.NewPageAssertions(tb.context(), p)
+	re, err := common.NewRegExpMatcher(`/page1\.html$`, "")
+	require.NoError(t, err)
+	require.NoError(t, pa.ToHaveURL(re, eopts))
+	require.NoError(t, pa.ToHaveURL(common.NewStringMatcher(tb.staticURL("page1.html")), eopts))
+
+	_, err = p.Evaluate(`() => setTimeout(() => document.title = "Late title", 200)`)
+	require.NoError(t, err)
+	require.NoError(t, pa.ToHaveTitle(common.NewStringMatcher("Late title"), eopts))
+
+	ra := common.NewResponseAssertions(tb.context(), resp)
+	require.NoError(t, ra.ToBeOK(eopts))
+}
+
+func TestScreenshotAssertions(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t)
+	p := tb.NewPage(nil)
+	err := p.SetContent(`
+		<style>#box { width: 50px; height: 50px; background: blue; }</style>
+		<div id="box"></div>
+	`, nil)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	sopts := common.NewScreenshotAssertionOptions()
+	sopts.Dir = dir
+	eopts := &common.ExpectOptions{Timeout: time.Second}
+	fp := &storage.LocalFilePersister{}
+	la := common.NewLocatorAssertions(tb.context(), p.Locator("#box", nil))
+
+	err = la.ToHaveScreenshot("box", sopts, fp, eopts)
+	require.ErrorIs(t, err, common.ErrExpectationFailed)
+	assert.FileExists(t, filepath.Join(dir, "box.png"))
+	require.NoError(t, la.ToHaveScreenshot("box", sopts, fp, eopts))
+
+	_, err = p.Evaluate(`() => document.getElementById('box').style.background = 'red'`)
+	require.NoError(t, err)
+	err = la.ToHaveScreenshot("box", sopts, fp, eopts)
+	require.ErrorIs(t, err, common.ErrExpectationFailed)
+	assert.FileExists(t, filepath.Join(dir, "box-actual.png"))
+	assert.FileExists(t, filepath.Join(dir, "box-diff.png"))
+
+	pa := common.NewPageAssertions(tb.context(), p)
+	update := common.NewScreenshotAssertionOptions()
+	update.Dir = dir
+	update.Update = true
+	require.NoError(t, pa.ToHaveScreenshot("page", update, fp, eopts))
+	require.NoError(t, pa.ToHaveScreenshot("page", sopts, fp, eopts))
+}
@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/xk6-browser/common"
+)
+
+func TestPageFileChooser(t *testing.T) {
+	t.Parallel()
+
+	// The upload button creates a hidden file input on the fly,
+	// which can't be targeted by setInputFiles.
+	const uploadHTML = `
+		<button onclick="upload()">upload</button>
+		<script>
+			function upload() {
+				const input = document.createElement('input');
+				input.type = 'file';
+				input.multiple = true;
+				input.style.display = 'none';
+				input.onchange = () => {
+					window.uploaded = [...input.files].map(f => f.name).join(',');
+				};
+				document.body.appendChild(input);
+				input.click();
+			}
+		</script>
+	`
+	files := &common.Files{Payload: []*common.File{
+		{Name: "a.txt", Mimetype: "text/plain", Buffer: base64.StdEncoding.EncodeToString([]byte("a"))},
+		{Name: "b.txt", Mimetype: "text/plain", Buffer: base64.StdEncoding.EncodeToString([]byte("b"))},
+	}}
+
+	assertUploaded := func(t *testing.T, p *common.Page, fc *common.FileChooser) {
+		t.Helper()
+
+		require.NotNil(t, fc)
+		assert.True(t, fc.IsMultiple())
+		assert.Same(t, p, fc.Page())
+		typ, ok, err := fc.Element().GetAttribute("type")
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, "file", typ)
+
+		err = fc.SetFiles(files, common.NewElementHandleSetInputFilesOptions(p.Timeout()))
+		require.NoError(t, err)
+		uploaded, err := p.WaitForFunction(`() => window.uploaded`, common.NewFrameWaitForFunctionOptions(p.Timeout()))
+		require.NoError(t, err)
+		assert.Equal(t, "a.txt,b.txt", uploaded)
+	}
+
+	t.Run("on", func(t *testing.T) {
+		t.Parallel()
+
+		tb := newTestBrowser(t)
+		p := tb.NewPage(nil)
+		require.NoError(t, p.SetContent(uploadHTML, nil))
+
+		choosers := make(chan *common.FileChooser, 1)
+		err := p.On(common.EventPageFileChooserOpened, func(e common.PageOnEvent) error {
+			choosers <- e.FileChooser
+			return nil
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, p.Click("button", common.NewFrameClickOptions(p.Timeout())))
+		assertUploaded(t, p, <-choosers)
+	})
+
+	t.Run("wait_for_event", func(t *testing.T) {
+		t.Parallel()
+
+		tb := newTestBrowser(t)
+		p := tb.NewPage(nil)
+		require.NoError(t, p.SetContent(uploadHTML, nil))
+
+		wait, err := p.ExpectEvent(common.EventPageFilechooser, nil, p.Timeout())
+		require.NoError(t, err)
+		require.NoError(t, p.Click("button", common.NewFrameClickOptions(p.Timeout())))
+		v, err := wait()
+		require.NoError(t, err)
+		fc, _ := v.(*common.FileChooser)
+		assertUploaded(t, p, fc)
+	})
+}
@@ -0,0 +1,111 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/xk6-browser/common"
+)
+
+func TestFrameLocator(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		locator func(*common.Page) *common.Locator
+	}{
+		{
+			"nested_frame_locators", func(p *common.Page) *common.Locator {
+				return p.FrameLocator("#iframe1").FrameLocator("#iframe2").Locator("#button1", nil)
+			},
+		},
+		{
+			"locator_content_frame", func(p *common.Page) *common.Locator {
+				return p.Locator("#iframe1", nil).
+					ContentFrame().
+					Locator("#iframe2", nil).
+					ContentFrame().
+					Locator("#button1", nil)
+			},
+		},
+		{
+			"frame_frame_locator", func(p *common.Page) *common.Locator {
+				return p.MainFrame().FrameLocator("#iframe1").FrameLocator("#iframe2").Locator("#button1", nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tb := newTestBrowser(t, withFileServer())
+			p := tb.NewPage(nil)
+			opts := &common.FrameGotoOptions{
+				Timeout: common.DefaultTimeout,
+			}
+			_, err := p.Goto(tb.staticURL("iframe_test_main.html"), opts)
+			require.NoError(t, err)
+
+			l := tt.locator(p)
+			require.NoError(t, l.Click(common.NewFrameClickOptions(l.Timeout())))
+
+			v, err := l.IsVisible()
+			require.NoError(t, err)
+			assert.True(t, v, "button inside the nested frame should be visible")
+
+			clicked, err := p.Evaluate(`() => {
+				const f1 = document.querySelector('#iframe1').contentDocument;
+				return f1.querySelector('#iframe2').contentWindow.buttonClicked;
+			}`)
+			require.NoError(t, err)
+			assert.True(t, asBool(t, clicked), "button hasn't been clicked")
+		})
+	}
+}
+
+func TestFrameLocatorSurvivesReload(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t, withFileServer())
+	p := tb.NewPage(nil)
+	opts := &common.FrameGotoOptions{
+		Timeout: common.DefaultTimeout,
+	}
+	_, err := p.Goto(tb.staticURL("iframe_test_main.html"), opts)
+	require.NoError(t, err)
+
+	l := p.FrameLocator("#iframe1").FrameLocator("#iframe2").Locator("#button1", nil)
+	require.NoError(t, l.WaitFor(nil))
+
+	// Reloading the outer iframe detaches all of its child frames.
+	// The locator should re-resolve them on the next action.
+	_, err = p.Evaluate(`() => {
+		const f = document.querySelector('#iframe1');
+		f.src = f.src;
+	}`)
+	require.NoError(t, err)
+
+	require.NoError(t, l.Click(common.NewFrameClickOptions(l.Timeout())))
+}
+
+func TestFrameLocatorTimeout(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t)
+	p := tb.NewPage(nil)
+	err := p.SetContent(`<iframe id="iframe1" srcdoc="<p>no nested frame</p>"></iframe>`, nil)
+	require.NoError(t, err)
+
+	// Waiting for the missing frame must use up the click's timeout
+	// instead of waiting for the default timeout first.
+	l := p.FrameLocator("#iframe1").FrameLocator("#missing").Locator("button", nil)
+	opts := common.NewFrameClickOptions(500 * time.Millisecond)
+	start := time.Now()
+	err = l.Click(opts)
+	require.ErrorContains(t, err, "timed out")
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
@@ -666,6 +666,227 @@ func TestLocatorShadowDOM(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestLocatorEvaluate(t *testing.T) {
+	t.Parallel()
+
+	const html = `
+		<ul>
+			<li data-price="3" style="color: rgb(255, 0, 0)">a</li>
+			<li data-price="4">b</li>
+		</ul>
+		<div id="late"></div>
+		<script>
+			setTimeout(() => {
+				const e = document.createElement("span");
+				e.id = "added";
+				e.textContent = "added later";
+				document.body.appendChild(e);
+			}, 100);
+		</script>
+	`
+
+	tb := newTestBrowser(t)
+	p := tb.NewPage(nil)
+	require.NoError(t, p.SetContent(html, nil))
+
+	t.Run("evaluate", func(t *testing.T) {
+		t.Parallel()
+
+		v, err := p.Locator("li >> nth=0", nil).Evaluate(
+			`(e, prop) => getComputedStyle(e)[prop] + " " + e.dataset.price`, "color",
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "rgb(255, 0, 0) 3", v)
+	})
+	t.Run("evaluate_waits_for_element", func(t *testing.T) {
+		t.Parallel()
+
+		v, err := p.Locator("#added", nil).Evaluate(`e => e.textContent`)
+		require.NoError(t, err)
+		assert.Equal(t, "added later", v)
+	})
+	t.Run("evaluate_strict", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := p.Locator("li", nil).Evaluate(`e => e.textContent`)
+		assert.ErrorContains(t, err, "strict mode violation")
+	})
+	t.Run("evaluate_all", func(t *testing.T) {
+		t.Parallel()
+
+		v, err := p.Locator("li", nil).EvaluateAll(
+			`(es, sum) => es.reduce((acc, e) => acc + Number(e.dataset.price), sum)`, 1,
+		)
+		require.NoError(t, err)
+		assert.EqualValues(t, 8, v)
+
+		v, err = p.Locator("p", nil).EvaluateAll(`es => es.length`)
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, v)
+	})
+	t.Run("evaluate_handle", func(t *testing.T) {
+		t.Parallel()
+
+		h, err := p.Locator("#late", nil).EvaluateHandle(`e => e.parentElement`)
+		require.NoError(t, err)
+		require.NotNil(t, h.AsElement())
+
+		v, err := h.Evaluate(`e => e.tagName`)
+		require.NoError(t, err)
+		assert.Equal(t, "BODY", v)
+	})
+}
+
+func TestLocatorUtilities(t *testing.T) {
+	t.Parallel()
+
+	const html = `
+		<div style="height: 2000px"></div>
+		<input id="text" value="hello world" style="width: 100px; height: 20px">
+		<input id="file" type="file">
+		<div id="hidden" style="display: none">hidden</div>
+	`
+
+	setup := func(t *testing.T) *common.Page {
+		t.Helper()
+
+		p := newTestBrowser(t).NewPage(nil)
+		require.NoError(t, p.SetContent(html, nil))
+
+		return p
+	}
+	timeout := func(l *common.Locator) *common.FrameBaseOptions {
+		return common.NewFrameBaseOptions(l.Timeout())
+	}
+
+	t.Run("scroll_into_view_and_bounding_box", func(t *testing.T) {
+		t.Parallel()
+
+		p := setup(t)
+		l := p.Locator("#text", nil)
+		require.NoError(t, l.ScrollIntoViewIfNeeded(common.NewElementHandleBaseOptions(l.Timeout())))
+
+		box, err := l.BoundingBox(timeout(l))
+		require.NoError(t, err)
+		require.NotNil(t, box)
+		assert.Greater(t, box.Width, 0.0)
+		assert.Less(t, box.Y, float64(p.ViewportSize()["height"]))
+
+		hidden := p.Locator("#hidden", nil)
+		box, err = hidden.BoundingBox(timeout(hidden))
+		require.NoError(t, err)
+		assert.Nil(t, box)
+	})
+	t.Run("focus_blur_and_select_text", func(t *testing.T) {
+		t.Parallel()
+
+		p := setup(t)
+		l := p.Locator("#text", nil)
+		require.NoError(t, l.SelectText(common.NewElementHandleBaseOptions(l.Timeout())))
+
+		v, err := p.Evaluate(`() => {
+			const e = document.querySelector("#text");
+			return e.value.substring(e.selectionStart, e.selectionEnd);
+		}`)
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", v)
+
+		require.NoError(t, l.Blur(timeout(l)))
+		v, err = p.Evaluate(`() => document.activeElement.id`)
+		require.NoError(t, err)
+		assert.Equal(t, "", v)
+	})
+	t.Run("set_input_files", func(t *testing.T) {
+		t.Parallel()
+
+		p := setup(t)
+		l := p.Locator("#file", nil)
+		files := &common.Files{Payload: []*common.File{
+			{Name: "test.txt", Mimetype: "text/plain", Buffer: "aGVsbG8="},
+		}}
+		require.NoError(t, l.SetInputFiles(files, common.NewFrameSetInputFilesOptions(l.Timeout())))
+
+		v, err := l.Evaluate(`e => e.files[0].name`)
+		require.NoError(t, err)
+		assert.Equal(t, "test.txt", v)
+	})
+	t.Run("screenshot_and_highlight", func(t *testing.T) {
+		t.Parallel()
+
+		p := setup(t)
+		l := p.Locator("input", nil)
+		require.NoError(t, l.Highlight())
+
+		v, err := p.Evaluate(`() => document.querySelectorAll("x-k6-browser-highlight-box").length`)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, v)
+
+		text := p.Locator("#text", nil)
+		buf, err := text.Screenshot(
+			common.NewElementHandleScreenshotOptions(text.Timeout()),
+			&mockPersister{},
+		)
+		require.NoError(t, err)
+		assert.NotEmpty(t, buf)
+	})
+}
+
+func TestLocatorStrictMode(t *testing.T) {
+	t.Parallel()
+
+	const html = `<button>one</button><button id="two">two</button>`
+
+	t.Run("locator", func(t *testing.T) {
+		t.Parallel()
+
+		tb := newTestBrowser(t)
+		p := tb.NewPage(nil)
+		require.NoError(t, p.SetContent(html, nil))
+
+		_, err := p.Locator("button", nil).InnerText(nil)
+		require.ErrorContains(t, err, "strict mode violation")
+		assert.ErrorContains(t, err, `resolved to 2 elements`)
+		assert.ErrorContains(t, err, `1) <button>one</button>`)
+		assert.ErrorContains(t, err, `2) <button id="two">two</button>`)
+
+		text, err := p.Locator("button", tb.toSobekValue(map[string]any{
+			"strict": false,
+		})).InnerText(nil)
+		require.NoError(t, err)
+		assert.Equal(t, "one", text)
+	})
+
+	t.Run("browser_context", func(t *testing.T) {
+		t.Parallel()
+
+		tb := newTestBrowser(t)
+		opts := common.DefaultBrowserContextOptions()
+		opts.StrictSelectors = true
+		p := tb.NewPage(opts)
+		require.NoError(t, p.SetContent(html, nil))
+
+		_, err := p.InnerText("button", nil)
+		assert.ErrorContains(t, err, "strict mode violation")
+
+		_, err = p.Query("button")
+		assert.ErrorContains(t, err, "strict mode violation")
+
+		text, err := p.InnerText("#two", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "two", text)
+
+		// An explicit strict option overrides the browser context's.
+		notStrict := tb.toSobekValue(map[string]any{"strict": false})
+		text, err = p.InnerText("button", notStrict)
+		require.NoError(t, err)
+		assert.Equal(t, "one", text)
+
+		text, err = p.Locator("button", notStrict).InnerText(nil)
+		require.NoError(t, err)
+		assert.Equal(t, "one", text)
+	})
+}
+
 func TestSelectOption(t *testing.T) {
 	t.Parallel()
 
@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/xk6-browser/common"
 )
 
 func TestMouseActions(t *testing.T) {
@@ -138,4 +140,33 @@ func TestMouseActions(t *testing.T) {
 		require.True(t, ok)
 		assert.Equal(t, "Mouse Up", text)
 	})
+
+	t.Run("wheel", func(t *testing.T) {
+		t.Parallel()
+
+		tb := newTestBrowser(t)
+		p := tb.NewPage(nil)
+		m := p.GetMouse()
+
+		// Set up a tall page that records wheel events
+		pageHTML := `
+			<script>
+				window.deltaY = 0;
+				window.addEventListener('wheel', e => window.deltaY += e.deltaY);
+			</script>
+			<div style="height: 5000px"></div>
+		`
+		err := p.SetContent(pageHTML, nil)
+		require.NoError(t, err)
+
+		require.NoError(t, m.Move(100, 100, nil))
+		require.NoError(t, m.Wheel(0, 300))
+
+		deltaY, err := p.WaitForFunction(
+			`() => window.scrollY > 0 && window.deltaY`,
+			common.NewFrameWaitForFunctionOptions(p.Timeout()),
+		)
+		require.NoError(t, err)
+		assert.EqualValues(t, 300, deltaY)
+	})
 }
@@ -2,6 +2,7 @@ package tests
 
 import (
 	"bytes"
+	"context"
 	_ "embed"
 	"encoding/json"
 	"fmt"
@@ -671,7 +672,7 @@ func TestPageScreenshotFullpage(t *testing.T) {
 	}`)
 	require.NoError(t, err)
 
-	opts := common.NewPageScreenshotOptions()
+	opts := common.NewPageScreenshotOptions(p.Timeout())
 	opts.FullPage = true
 	buf, err := p.Screenshot(opts, &mockPersister{})
 	require.NoError(t, err)
@@ -691,6 +692,90 @@ func TestPageScreenshotFullpage(t *testing.T) {
 	assert.Greater(t, b, uint32(128))
 }
 
+func TestPageScreenshotOptions(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t)
+	p := tb.NewPage(nil)
+
+	err := p.SetContent(`
+		<style>
+			body { margin: 0; }
+			#box { width: 100px; height: 100px; background: rgb(0, 0, 255); }
+			#spinner { width: 100px; height: 100px; background: rgb(0, 0, 0); animation: fade 10s forwards; }
+			@keyframes fade { to { background: rgb(255, 255, 255); } }
+		</style>
+		<div id="box"></div>
+		<div id="spinner"></div>
+	`, nil)
+	require.NoError(t, err)
+
+	opts := common.NewPageScreenshotOptions(p.Timeout())
+	opts.Animations = common.ScreenshotAnimationsDisabled
+	opts.Mask = []*common.Locator{p.Locator("#box", nil)}
+	opts.MaskColor = "rgb(255, 0, 0)"
+	buf, err := p.Screenshot(opts, &mockPersister{})
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(buf))
+	require.NoError(t, err)
+
+	// The box is masked with the mask color.
+	r, g, b, _ := img.At(50, 50).RGBA()
+	assert.Equal(t, []uint32{0xffff, 0, 0}, []uint32{r, g, b})
+	// The animation is finished.
+	r, g, b, _ = img.At(50, 150).RGBA()
+	assert.Equal(t, []uint32{0xffff, 0xffff, 0xffff}, []uint32{r, g, b})
+
+	// The mask is removed after the screenshot.
+	n, err := p.Locator("[data-k6-browser-screenshot]", nil).Count()
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	opts = common.NewPageScreenshotOptions(p.Timeout())
+	opts.Format = common.ImageFormatWebP
+	buf, err = p.Screenshot(opts, &mockPersister{})
+	require.NoError(t, err)
+	require.Greater(t, len(buf), 12)
+	assert.Equal(t, "WEBP", string(buf[8:12]))
+}
+
+// recordingPersister records the files that it persists.
+type recordingPersister struct {
+	files map[string][]byte
+}
+
+func (r *recordingPersister) Persist(_ context.Context, path string, data io.Reader) error {
+	b, err := io.ReadAll(data)
+	if err != nil {
+		return err
+	}
+	if r.files == nil {
+		r.files = make(map[string][]byte)
+	}
+	r.files[path] = b
+
+	return nil
+}
+
+func TestPagePDF(t *testing.T) {
+	t.Parallel()
+
+	p := newTestBrowser(t).NewPage(nil)
+	err := p.SetContent(`<h1>Invoice</h1><p>Total: 42</p>`, nil)
+	require.NoError(t, err)
+
+	opts := common.NewPagePDFOptions()
+	opts.Path = "invoice.pdf"
+	opts.PrintBackground = true
+	fp := &recordingPersister{}
+	buf, err := p.PDF(opts, fp)
+	require.NoError(t, err)
+
+	assert.True(t, bytes.HasPrefix(buf, []byte("%PDF-")), "want a PDF, got %q", buf[:min(len(buf), 8)])
+	assert.Equal(t, buf, fp.files["invoice.pdf"])
+}
+
 func TestPageTitle(t *testing.T) {
 	t.Parallel()
 
@@ -2165,3 +2250,472 @@ func TestPageOnMetric(t *testing.T) {
 		})
 	}
 }
+
+func TestPageScrollUntil(t *testing.T) {
+	t.Parallel()
+
+	// An infinite feed that appends ten items whenever
+	// the sentinel at its end scrolls into view.
+	const feedHTML = `
+		<style>.item { height: 200px; }</style>
+		<div id="feed"></div>
+		<div id="sentinel">loading...</div>
+		<script>
+			const feed = document.getElementById('feed');
+			let count = 0;
+			const load = () => {
+				for (let i = 0; i < 10; i++) {
+					const item = document.createElement('div');
+					item.className = 'item';
+					item.id = 'item-' + (++count);
+					item.textContent = 'item ' + count;
+					feed.appendChild(item);
+				}
+			};
+			load();
+			new IntersectionObserver(entries => {
+				if (entries.some(e => e.isIntersecting)) load();
+			}).observe(document.getElementById('sentinel'));
+		</script>
+	`
+
+	setup := func(t *testing.T) *common.Page {
+		t.Helper()
+
+		p := newTestBrowser(t).NewPage(nil)
+		require.NoError(t, p.SetContent(feedHTML, nil))
+
+		return p
+	}
+
+	t.Run("locator", func(t *testing.T) {
+		t.Parallel()
+
+		p := setup(t)
+		item := p.Locator("#item-35", nil)
+		opts := common.NewPageScrollUntilOptions()
+		opts.MaxScrolls = 50
+
+		scrolls, err := p.ScrollUntil(item.IsVisible, opts)
+		require.NoError(t, err)
+		assert.Positive(t, scrolls)
+	})
+
+	t.Run("max_scrolls", func(t *testing.T) {
+		t.Parallel()
+
+		p := setup(t)
+		never := func() (bool, error) { return false, nil }
+		opts := common.NewPageScrollUntilOptions()
+		opts.MaxScrolls = 3
+
+		scrolls, err := p.ScrollUntil(never, opts)
+		require.ErrorContains(t, err, "condition not met after 3 scrolls")
+		assert.EqualValues(t, 3, scrolls)
+	})
+}
+
+func TestPageOnDialog(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		pageFunc   string
+		handle     func(*common.Dialog) error
+		wantType   string
+		wantMsg    string
+		wantDefVal string
+		want       any
+	}{
+		{
+			name:     "accept_confirm",
+			pageFunc: `() => confirm("Delete the item?")`,
+			handle:   func(d *common.Dialog) error { return d.Accept("") },
+			wantType: "confirm",
+			wantMsg:  "Delete the item?",
+			want:     true,
+		},
+		{
+			name:     "dismiss_confirm",
+			pageFunc: `() => confirm("Delete the item?")`,
+			handle:   (*common.Dialog).Dismiss,
+			wantType: "confirm",
+			wantMsg:  "Delete the item?",
+			want:     false,
+		},
+		{
+			name:       "accept_prompt",
+			pageFunc:   `() => prompt("Your name?", "anonymous")`,
+			handle:     func(d *common.Dialog) error { return d.Accept("k6") },
+			wantType:   "prompt",
+			wantMsg:    "Your name?",
+			wantDefVal: "anonymous",
+			want:       "k6",
+		},
+		{
+			name:     "alert",
+			pageFunc: `() => { alert("Saved"); return "done"; }`,
+			handle:   (*common.Dialog).Dismiss,
+			wantType: "alert",
+			wantMsg:  "Saved",
+			want:     "done",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tb := newTestBrowser(t)
+			p := tb.NewPage(nil)
+
+			dialogs := make(chan *common.Dialog, 1)
+			err := p.On(common.EventPageDialogOpened, func(e common.PageOnEvent) error {
+				dialogs <- e.Dialog
+				return nil
+			})
+			require.NoError(t, err)
+
+			// The dialog blocks the page until it is handled, so it is
+			// handled while the evaluation is waiting for it.
+			go func() {
+				d := <-dialogs
+				assert.Equal(t, tt.wantType, d.Type())
+				assert.Equal(t, tt.wantMsg, d.Message())
+				assert.Equal(t, tt.wantDefVal, d.DefaultValue())
+				assert.NoError(t, tt.handle(d))
+				assert.ErrorIs(t, d.Dismiss(), common.ErrDialogHandled)
+			}()
+
+			got, err := p.Evaluate(tt.pageFunc)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPageOnPageError(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t)
+	p := tb.NewPage(nil)
+
+	pageErrors := make(chan *common.PageError, 1)
+	err := p.On(common.EventPageErrorThrown, func(e common.PageOnEvent) error {
+		pageErrors <- e.PageError
+		return nil
+	})
+	require.NoError(t, err)
+
+	// Errors that are thrown by an evaluation are returned to the
+	// caller, so the error is thrown outside of it.
+	_, err = p.Evaluate(`() => setTimeout(() => { throw new TypeError("boom"); }, 0)`)
+	require.NoError(t, err)
+
+	select {
+	case pe := <-pageErrors:
+		assert.Equal(t, "TypeError", pe.Name)
+		assert.Equal(t, "boom", pe.Message)
+		assert.Contains(t, pe.Stack, "TypeError: boom")
+	case <-tb.ctx.Done():
+		t.Fatal("page error was not reported")
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the page error")
+	}
+}
+
+func TestPageFailOnPageError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		failOnPageError bool
+		wantErr         string
+	}{
+		{
+			name:            "fail",
+			failOnPageError: true,
+			wantErr:         "uncaught page error",
+		},
+		{
+			name:            "no_fail",
+			failOnPageError: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var pageErrors atomic.Int32
+			done := make(chan struct{})
+			samples := make(chan k6metrics.SampleContainer)
+			go func() {
+				defer close(done)
+				for sc := range samples {
+					for _, s := range sc.GetSamples() {
+						if s.Metric.Name == "browser_page_errors" {
+							pageErrors.Add(int32(s.Value))
+						}
+					}
+				}
+			}()
+
+			vu, _, _, cleanUp := startIteration(t, k6test.WithSamples(samples))
+
+			_, err := vu.RunAsync(t, `
+				const context = await browser.newContext({ failOnPageError: %t });
+				const page = await context.newPage();
+				try {
+					await page.evaluate(() => setTimeout(() => { throw new TypeError("boom"); }, 0));
+					await page.waitForTimeout(500);
+				} finally {
+					await page.close();
+				}
+			`, tt.failOnPageError)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				assert.ErrorContains(t, err, "TypeError: boom")
+			} else {
+				require.NoError(t, err)
+			}
+
+			// The iteration must end before closing the samples,
+			// as a failed iteration doesn't close the page.
+			cleanUp()
+			close(samples)
+			<-done
+			assert.Equal(t, int32(1), pageErrors.Load())
+		})
+	}
+}
+
+func TestPageOnClose(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t)
+	p := tb.NewPage(nil)
+
+	closed := make(chan struct{})
+	p.OnClose(func() { close(closed) })
+	require.NoError(t, p.Close(nil))
+
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the page to close")
+	}
+
+	// The function is called right away once the page is closed.
+	var called bool
+	p.OnClose(func() { called = true })
+	assert.True(t, called)
+}
+
+func TestPageOnCloseHandler(t *testing.T) {
+	t.Parallel()
+
+	vu, _, log, cleanUp := startIteration(t)
+	defer cleanUp()
+
+	// The iteration ends only if closing the page also closes
+	// its task queue after running the page.on('close') handler.
+	gv, err := vu.RunAsync(t, `
+		const page = await browser.newPage();
+		page.on('close', () => log('close'));
+		await page.close();
+	`)
+	require.NoError(t, err)
+
+	got := k6test.ToPromise(t, gv)
+	assert.Equal(t, sobek.PromiseStateFulfilled, got.State())
+	assert.Equal(t, []string{"close"}, *log)
+}
+
+func TestPageOnLifecycleEvents(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t, withFileServer())
+	p := tb.NewPage(nil)
+
+	events := make(chan string, 100)
+	pageEvents := []common.PageOnEventName{
+		common.EventPageLoaded,
+		common.EventPageDOMContentLoaded,
+		common.EventPageClosed,
+	}
+	for _, event := range pageEvents {
+		event := event
+		err := p.On(event, func(e common.PageOnEvent) error {
+			assert.Same(t, p, e.Page)
+			events <- string(event)
+			return nil
+		})
+		require.NoError(t, err)
+	}
+	frameEvents := []common.PageOnEventName{
+		common.EventPageFrameWasAttached,
+		common.EventPageFrameWasDetached,
+		common.EventPageFrameWasNavigated,
+	}
+	for _, event := range frameEvents {
+		event := event
+		err := p.On(event, func(e common.PageOnEvent) error {
+			require.NotNil(t, e.Frame)
+			events <- string(event) + ":" + e.Frame.URL()
+			return nil
+		})
+		require.NoError(t, err)
+	}
+
+	// waitFor waits for the event, skipping the other events.
+	waitFor := func(want string) {
+		t.Helper()
+		for {
+			select {
+			case got := <-events:
+				if got == want {
+					return
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("timed out waiting for the %q event", want)
+			}
+		}
+	}
+
+	opts := &common.FrameGotoOptions{
+		Timeout: common.DefaultTimeout,
+	}
+	_, err := p.Goto(tb.staticURL("page1.html"), opts)
+	require.NoError(t, err)
+	waitFor("framenavigated:" + tb.staticURL("page1.html"))
+	waitFor("domcontentloaded")
+	waitFor("load")
+
+	_, err = p.Evaluate(`url => {
+		const frame = document.createElement('iframe');
+		frame.src = url;
+		document.body.appendChild(frame);
+		return new Promise(resolve => frame.onload = resolve);
+	}`, tb.staticURL("page2.html"))
+	require.NoError(t, err)
+	waitFor("frameattached:")
+	waitFor("framenavigated:" + tb.staticURL("page2.html"))
+
+	_, err = p.Evaluate(`() => document.querySelector('iframe').remove()`)
+	require.NoError(t, err)
+	waitFor("framedetached:" + tb.staticURL("page2.html"))
+
+	// Navigations within the document are reported too.
+	_, err = p.Evaluate(`() => history.pushState({}, '', '#spa')`)
+	require.NoError(t, err)
+	waitFor("framenavigated:" + tb.staticURL("page1.html") + "#spa")
+
+	require.NoError(t, p.Close(nil))
+	waitFor("close")
+}
+
+func TestPageOnOffOnce(t *testing.T) {
+	t.Parallel()
+
+	vu, _, _, cleanUp := startIteration(t)
+	defer cleanUp()
+
+	p := vu.RunPromise(t, `
+		const page = await browser.newPage();
+
+		const calls = [];
+		let resolve;
+		const next = () => new Promise(r => resolve = r);
+
+		const onConsole = msg => { calls.push('on:' + msg.text()); resolve(); };
+		page.on('console', onConsole);
+		page.once('console', msg => calls.push('once:' + msg.text()));
+
+		let logged = next();
+		await page.evaluate(() => console.log('first'));
+		await logged;
+
+		page.off('console', onConsole);
+		page.on('console', msg => { calls.push('last:' + msg.text()); resolve(); });
+
+		logged = next();
+		await page.evaluate(() => console.log('second'));
+		await logged;
+
+		page.removeAllListeners('console');
+		await page.evaluate(() => console.log('third'));
+
+		await page.close();
+
+		return calls.join(',');
+	`)
+	require.Equal(t, sobek.PromiseStateFulfilled, p.State())
+	assert.Equal(t, "on:first,once:first,last:second", p.Result().String())
+}
+
+func TestPageAddInitScript(t *testing.T) {
+	t.Parallel()
+
+	vu, _, _, cleanUp := startIteration(t)
+	defer cleanUp()
+
+	got := vu.RunPromise(t, `
+		const p = await browser.newPage();
+		await p.addInitScript((o) => { window.injected = o.value; }, { value: 42 });
+
+		// The init script is evaluated again after every navigation.
+		const results = [];
+		for (const url of ['about:blank', 'data:text/html,<p>page</p>']) {
+			await p.goto(url);
+			results.push(await p.evaluate(() => window.injected));
+		}
+
+		// The init scripts of a page don't affect the other pages.
+		const other = await browser.context().newPage();
+		results.push(await other.evaluate(() => window.injected === undefined));
+		await other.close();
+		await p.close();
+
+		return JSON.stringify(results);
+	`)
+	assert.Equal(t, `[42,42,true]`, got.Result().String())
+}
+
+func TestPageAddScriptAndStyleTags(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t, withFileServer())
+	vu, _, _, cleanUp := startIteration(t)
+	defer cleanUp()
+
+	got := vu.RunPromise(t, `
+		const p = await browser.newPage();
+		await p.setContent('<div id="banner">banner</div>');
+
+		const script = await p.addScriptTag({ content: 'window.sdk = { loaded: true };' });
+		const style = await p.addStyleTag({ content: '#banner { display: none; }' });
+
+		const results = await p.evaluate(() => [
+			window.sdk.loaded,
+			getComputedStyle(document.getElementById('banner')).display,
+		]);
+		results.push(await script.evaluate(e => e.tagName));
+		results.push(await style.evaluate(e => e.tagName));
+		await p.close();
+
+		return JSON.stringify(results);
+	`)
+	assert.Equal(t, `[true,"none","SCRIPT","STYLE"]`, got.Result().String())
+
+	// A script that fails to load rejects the promise.
+	_, err := vu.RunAsync(t, `
+		const p = await browser.newPage();
+		try {
+			await p.addScriptTag({ url: %q });
+		} finally {
+			await p.close();
+		}
+	`, tb.staticURL("missing.js"))
+	assert.ErrorContains(t, err, "loading script from")
+}
@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/xk6-browser/common"
+)
+
+func TestPagePopup(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t, withHTTPServer())
+	tb.withHandler("/popup", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `<html><head><title>Popup</title></head><body>popup</body></html>`)
+	})
+
+	p := tb.NewPage(nil)
+	err := p.SetContent(fmt.Sprintf(`<a href="%s" target="_blank">open</a>`, tb.url("/popup")), nil)
+	require.NoError(t, err)
+
+	onPopup := make(chan *common.Page, 1)
+	err = p.On(common.EventPagePopupOpened, func(e common.PageOnEvent) error {
+		onPopup <- e.Popup
+		return nil
+	})
+	require.NoError(t, err)
+
+	wait, err := p.ExpectEvent(common.EventPagePopup, nil, p.Timeout())
+	require.NoError(t, err)
+	require.NoError(t, p.Click("a", common.NewFrameClickOptions(p.Timeout())))
+	v, err := wait()
+	require.NoError(t, err)
+	popup, _ := v.(*common.Page)
+	require.NotNil(t, popup)
+
+	select {
+	case got := <-onPopup:
+		assert.Same(t, popup, got)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the popup event")
+	}
+
+	// The popup is reported after its initial navigation has committed.
+	popupURL, err := popup.URL()
+	require.NoError(t, err)
+	assert.Equal(t, tb.url("/popup"), popupURL)
+	assert.Same(t, p, popup.Opener())
+}
+
+func TestBrowserContextOnPage(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t)
+	bc, err := tb.NewContext(nil)
+	require.NoError(t, err)
+
+	onPage := make(chan *common.Page, 1)
+	err = bc.On(common.EventBrowserContextPageCreated, func(e common.BrowserContextOnEvent) error {
+		onPage <- e.Page
+		return nil
+	})
+	require.NoError(t, err)
+
+	err = bc.On("close", func(common.BrowserContextOnEvent) error { return nil })
+	assert.ErrorContains(t, err, `unknown browser context event: "close"`)
+
+	p, err := bc.NewPage()
+	require.NoError(t, err)
+
+	select {
+	case got := <-onPage:
+		assert.Same(t, p, got)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the page event")
+	}
+}
@@ -0,0 +1,116 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectorsRegister(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t)
+
+	// The engine has to be registered before the page's execution
+	// contexts are created.
+	err := tb.Selectors().Register("data-qa", `({
+		query(root, selector) {
+			return root.querySelector('[data-qa="' + selector + '"]');
+		},
+		queryAll(root, selector) {
+			return root.querySelectorAll('[data-qa="' + selector + '"]');
+		}
+	})`, nil)
+	require.NoError(t, err)
+
+	p := tb.NewPage(nil)
+	err = p.SetContent(`
+		<div data-qa="title">Title</div>
+		<ul><li data-qa="item">one</li><li data-qa="item">two</li></ul>
+	`, nil)
+	require.NoError(t, err)
+
+	text, ok, err := p.Locator("data-qa=title", nil).TextContent(nil)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "Title", text)
+
+	items, err := p.QueryAll("ul >> data-qa=item")
+	require.NoError(t, err)
+	assert.Len(t, items, 2)
+
+	// The engines of a VU are not registered in the other VUs.
+	other := newTestBrowser(t).NewPage(nil)
+	_, err = other.QueryAll("data-qa=item")
+	assert.ErrorContains(t, err, `unknown selector engine name "data-qa"`)
+}
+
+func TestSelectorsPseudoClasses(t *testing.T) {
+	t.Parallel()
+
+	const html = `
+		<style>
+			.box { position: absolute; box-sizing: border-box; width: 50px; height: 20px; }
+		</style>
+		<ul>
+			<li>Apple <span>red</span></li>
+			<li>Banana</li>
+			<li style="display:none">Cherry</li>
+			<li>banana split</li>
+		</ul>
+		<div>
+			<label class="box" style="left: 100px; top: 100px;">Name</label>
+			<input class="box" id="right" style="left: 160px; top: 100px;">
+			<input class="box" id="far-right" style="left: 400px; top: 100px;">
+			<input class="box" id="left" style="left: 10px; top: 100px;">
+			<input class="box" id="above" style="left: 100px; top: 50px;">
+			<input class="box" id="below" style="left: 100px; top: 140px;">
+		</div>
+	`
+
+	tests := []struct {
+		name     string
+		selector string
+		want     []string
+	}{
+		{name: "has_text", selector: "li:has-text('banana')", want: []string{"Banana", "banana split"}},
+		{name: "text_is", selector: `li:text-is("Banana")`, want: []string{"Banana"}},
+		{name: "text_is_smallest", selector: `:text-is("red")`, want: []string{"red"}},
+		{name: "text_matches", selector: `li:text-matches("^ban", "i")`, want: []string{"Banana", "banana split"}},
+		{name: "text_engine", selector: `text="Banana"`, want: []string{"Banana"}},
+		{name: "visible_pseudo_class", selector: "li:visible", want: []string{"Apple red", "Banana", "banana split"}},
+		{name: "visible_engine", selector: "li >> visible=false", want: []string{"Cherry"}},
+		{name: "nth", selector: "li >> nth=1", want: []string{"Banana"}},
+		{name: "nth_last", selector: "li >> nth=-1", want: []string{"banana split"}},
+		{name: "nth_out_of_range", selector: "li >> nth=10", want: []string{}},
+		{name: "right_of", selector: "input:right-of(:text-is('Name'))", want: []string{"right", "far-right"}},
+		{name: "right_of_max_distance", selector: "input:right-of(:text-is('Name'), 100)", want: []string{"right"}},
+		{name: "left_of", selector: "input:left-of(label)", want: []string{"left"}},
+		{name: "above", selector: "input:above(label)", want: []string{"above"}},
+		{name: "below", selector: "input:below(label)", want: []string{"below"}},
+		{name: "near", selector: "input:near(label)", want: []string{"right", "below", "above", "left"}},
+		{name: "layout_nth", selector: "input:near(label) >> nth=0", want: []string{"right"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tb := newTestBrowser(t)
+			p := tb.NewPage(nil)
+			require.NoError(t, p.SetContent(html, nil))
+
+			handles, err := p.QueryAll(tt.selector)
+			require.NoError(t, err)
+
+			got := make([]string, 0, len(handles))
+			for _, h := range handles {
+				v, err := h.Evaluate(`e => e.id || e.textContent.trim()`)
+				require.NoError(t, err)
+				got = append(got, v.(string)) //nolint:forcetypeassert
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
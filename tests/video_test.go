@@ -0,0 +1,59 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/xk6-browser/common"
+	"github.com/grafana/xk6-browser/storage"
+)
+
+func TestPageVideo(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t)
+	dir := t.TempDir()
+
+	// Pages aren't recorded without a videos path.
+	unrecordedCtx, err := tb.NewContext(nil)
+	require.NoError(t, err)
+	unrecorded, err := unrecordedCtx.NewPage()
+	require.NoError(t, err)
+	assert.Nil(t, unrecorded.Video())
+
+	opts := common.DefaultBrowserContextOptions()
+	opts.VideosPath = filepath.Join(dir, "videos")
+	bctx, err := tb.NewContext(opts)
+	require.NoError(t, err)
+	page, err := bctx.NewPage()
+	require.NoError(t, err)
+
+	video := page.Video()
+	require.NotNil(t, video)
+	assert.Equal(t, filepath.Join(dir, "videos", page.TargetID()+".avi"), video.Path())
+
+	err = page.SetContent(`<h1 id="counter">0</h1>`, nil)
+	require.NoError(t, err)
+	for i := 1; i <= 5; i++ {
+		_, err := page.Evaluate(`(i) => { counter.textContent = i; }`, i)
+		require.NoError(t, err)
+		time.Sleep(100 * time.Millisecond)
+	}
+	require.NoError(t, page.Close(nil))
+
+	// The video is persisted when the page closes.
+	saved := filepath.Join(dir, "saved.avi")
+	require.NoError(t, video.SaveAs(saved, &storage.LocalFilePersister{}))
+	for _, path := range []string{video.Path(), saved} {
+		b, err := os.ReadFile(path) //nolint:forbidigo
+		require.NoError(t, err)
+		require.Greater(t, len(b), 224)
+		assert.Equal(t, "RIFF", string(b[:4]))
+		assert.Equal(t, "AVI ", string(b[8:12]))
+	}
+}